@@ -6,18 +6,28 @@ package main
 
 import (
 	"context"
+	"email-service/src/audit"
+	"email-service/src/broker"
+	"email-service/src/captcha"
 	"email-service/src/email"
 	grpcImpl "email-service/src/grpc"
+	"email-service/src/health"
+	"email-service/src/idempotency"
 	c "email-service/src/interfaces/config"
 	"email-service/src/interfaces/content"
 	e "email-service/src/interfaces/email"
 	g "email-service/src/interfaces/global"
 	pb "email-service/src/interfaces/grpc"
+	i "email-service/src/interfaces/idempotency"
+	"email-service/src/metrics"
 	"email-service/src/server"
+	"email-service/src/webhook"
+	"errors"
 	"fmt"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
 	"half-nothing.cn/service-core/cache"
 	"half-nothing.cn/service-core/cleaner"
 	"half-nothing.cn/service-core/config"
@@ -59,10 +69,6 @@ func main() {
 
 	defer cl.Clean()
 
-	cl.Add("EmailSender", func(_ context.Context) error {
-		return applicationConfig.EmailConfig.Closer.Close()
-	})
-
 	if applicationConfig.TelemetryConfig.Enable {
 		sdk := telemetry.NewSDK(lg, applicationConfig.TelemetryConfig)
 		shutdown, err := sdk.SetupOTelSDK(context.Background())
@@ -73,24 +79,185 @@ func main() {
 		cl.Add("Telemetry", shutdown)
 	}
 
+	metricsRecorder := metrics.NewMetrics(lg)
+
 	codeCache := cache.NewMemoryCache[string, *e.CodeData](applicationConfig.EmailConfig.VerifyExpireDuration)
 	sendCache := cache.NewMemoryCache[string, time.Time](applicationConfig.EmailConfig.VerifyIntervalDuration)
-	cl.Add("Cache", func(_ context.Context) error { codeCache.Close(); sendCache.Close(); return nil })
+	limitCache := cache.NewMemoryCache[string, int](time.Hour)
+	changeCache := cache.NewMemoryCache[string, *e.EmailChangeRequest](applicationConfig.EmailConfig.EmailChange.CodeExpireDuration)
+	resetCache := cache.NewMemoryCache[string, *e.ResetToken](applicationConfig.EmailConfig.PasswordReset.TokenExpireDuration)
+	cl.Add("Cache", func(_ context.Context) error {
+		codeCache.Close()
+		sendCache.Close()
+		limitCache.Close()
+		changeCache.Close()
+		resetCache.Close()
+		return nil
+	})
+
+	var sandbox e.SandboxInterface
+	var transport e.TransportInterface
+	if applicationConfig.EmailConfig.Sandbox.Enable {
+		emailSandbox, err := email.NewSandbox(lg, applicationConfig.EmailConfig.Sandbox)
+		if err != nil {
+			lg.Fatalf("fail to initialize email sandbox: %v", err)
+			return
+		}
+		lg.Warn("sandbox mode enabled, emails will be captured instead of sent")
+		metricsRecorder.RegisterSize("sandbox_emails", emailSandbox.Size)
+		sandbox = emailSandbox
+		transport = emailSandbox
+	} else {
+		emailTransport, err := email.NewTransport(lg, applicationConfig.EmailConfig)
+		if err != nil {
+			lg.Fatalf("fail to initialize email transport: %v", err)
+			return
+		}
+		transport = emailTransport
+	}
+	// 共享的SMTP连接同一时间只能发送一封邮件, 关键邮件使用独立的连接, 不在批量邮件之后排队
+	var criticalTransport e.TransportInterface
+	if sandbox == nil && applicationConfig.EmailConfig.Transport.Type == c.TransportSmtp &&
+		applicationConfig.EmailConfig.Priority.DedicatedCritical {
+		emailTransport, err := email.NewTransport(lg, applicationConfig.EmailConfig)
+		if err != nil {
+			lg.Fatalf("fail to initialize critical email transport: %v", err)
+			return
+		}
+		criticalTransport = emailTransport
+	}
+	if applicationConfig.EmailConfig.Dkim.Enable {
+		transport = email.NewDkimTransport(lg, applicationConfig.EmailConfig.Dkim, transport)
+		if criticalTransport != nil {
+			criticalTransport = email.NewDkimTransport(lg, applicationConfig.EmailConfig.Dkim, criticalTransport)
+		}
+	}
 
-	emailSender := email.NewSender(lg, applicationConfig.EmailConfig)
-	emailManager := email.NewCodeManager(lg, applicationConfig.EmailConfig, codeCache, sendCache)
+	webhookDispatcher := webhook.NewDispatcher(lg, applicationConfig.WebhookConfig, metricsRecorder)
+	if applicationConfig.WebhookConfig.Enable {
+		webhookDispatcher.Start()
+	}
+
+	suppressions := email.NewMemorySuppression()
+	var preferences e.PreferenceInterface
+	if applicationConfig.PreferenceConfig.Enable {
+		switch applicationConfig.PreferenceConfig.Store {
+		case c.PreferenceStoreFile:
+			filePreference, err := email.NewFilePreference(lg, applicationConfig.PreferenceConfig)
+			if err != nil {
+				lg.Fatalf("fail to initialize preference store: %v", err)
+				return
+			}
+			metricsRecorder.RegisterSize("preferences", filePreference.Size)
+			preferences = filePreference
+		default:
+			memoryPreference := email.NewMemoryPreference(applicationConfig.PreferenceConfig)
+			metricsRecorder.RegisterSize("preferences", memoryPreference.Size)
+			preferences = memoryPreference
+		}
+	}
+	emailSender := email.NewSender(lg, applicationConfig.EmailConfig, transport, criticalTransport, suppressions, preferences, metricsRecorder, webhookDispatcher)
+	asyncSender := email.NewAsyncSender(lg, applicationConfig.EmailConfig.Async, emailSender, metricsRecorder)
+	asyncSender.Start()
+	// 关闭顺序不依赖清理函数的注册顺序: 先发完异步队列中的邮件, 再发送暂存的汇总邮件,
+	// 之后关闭发送通道, 最后推送以上过程产生的事件
+	cl.Add("EmailSender", func(ctx context.Context) error {
+		asyncErr := asyncSender.Close(ctx)
+		emailSender.FlushDigests()
+		err := errors.Join(asyncErr, transport.Close())
+		if criticalTransport != nil {
+			err = errors.Join(err, criticalTransport.Close())
+		}
+		if applicationConfig.WebhookConfig.Enable {
+			err = errors.Join(err, webhookDispatcher.Close(ctx))
+		}
+		return err
+	})
+	emailManager := email.NewCodeManager(lg, applicationConfig.EmailConfig, codeCache, sendCache, changeCache, resetCache, metricsRecorder, webhookDispatcher)
+	codeLimiter := email.NewCodeLimiter(lg, applicationConfig.EmailConfig.CodeLimit, limitCache, metricsRecorder)
+	addressChecker := email.NewAddressChecker(lg, applicationConfig.EmailConfig.AddressCheck, email.NewNetResolver())
+
+	auditRecorder := audit.NewMemoryRecorder(lg, applicationConfig.AuditConfig.Capacity)
+	metricsRecorder.RegisterSize("audit_records", auditRecorder.Size)
+	metricsRecorder.RegisterSize("suppressions", suppressions.Size)
+
+	var idempotencyStore i.StoreInterface
+	if applicationConfig.IdempotencyConfig.Enable {
+		memoryStore := idempotency.NewMemoryStore(lg, applicationConfig.IdempotencyConfig)
+		cl.Add("IdempotencyStore", func(_ context.Context) error { memoryStore.Close(); return nil })
+		idempotencyStore = memoryStore
+	}
+
+	if applicationConfig.BrokerConfig.Enable {
+		subscriber, err := broker.NewNatsSubscriber(lg, applicationConfig.BrokerConfig.Nats)
+		if err != nil {
+			lg.Fatalf("fail to initialize broker subscriber: %v", err)
+			return
+		}
+		consumer := broker.NewConsumer(lg, applicationConfig.BrokerConfig, applicationConfig.EmailConfig.Location, subscriber, emailSender, auditRecorder)
+		if err := consumer.Start(); err != nil {
+			lg.Fatalf("fail to start broker consumer: %v", err)
+			return
+		}
+		cl.Add("BrokerSubscriber", func(_ context.Context) error {
+			err := subscriber.Close()
+			consumer.Close()
+			return err
+		})
+	}
+
+	transports := []e.TransportInterface{transport}
+	if criticalTransport != nil {
+		transports = append(transports, criticalTransport)
+	}
+	healthMonitor := health.NewMonitor(
+		lg,
+		applicationConfig.HealthConfig,
+		health.NewChecker(applicationConfig.EmailConfig, applicationConfig.HealthConfig, emailSender, asyncSender, transports...),
+		pb.Email_ServiceDesc.ServiceName,
+	)
+	healthCtx, healthCancel := context.WithCancel(context.Background())
+	healthMonitor.Start(healthCtx)
+	cl.Add("HealthMonitor", func(_ context.Context) error { healthCancel(); return nil })
 
 	contentBuilder := content.NewApplicationContentBuilder().
 		SetConfigManager(configManager).
 		SetCleaner(cl).
 		SetLogger(lg).
 		SetEmailSender(emailSender).
-		SetCodeManager(emailManager)
+		SetAsyncSender(asyncSender).
+		SetCodeManager(emailManager).
+		SetCodeLimiter(codeLimiter).
+		SetAddressChecker(addressChecker).
+		SetCaptchaVerifier(captcha.NewVerifier(lg, applicationConfig.EmailConfig.Captcha)).
+		SetAuditRecorder(auditRecorder).
+		SetSuppressions(suppressions).
+		SetPreferences(preferences).
+		SetMetrics(metricsRecorder).
+		SetHealthMonitor(healthMonitor).
+		SetSandbox(sandbox).
+		SetIdempotencyStore(idempotencyStore)
 
 	started := make(chan bool)
+	authenticator := grpcImpl.NewAuthenticator(lg, applicationConfig.GrpcAuthConfig)
+	unaryInterceptors := []grpc.UnaryServerInterceptor{authenticator.UnaryInterceptor}
+	if idempotencyStore != nil {
+		unaryInterceptors = append(unaryInterceptors, grpcImpl.NewIdempotency(lg, idempotencyStore).UnaryInterceptor)
+	}
+
 	initFunc := func(s *grpc.Server) {
-		grpcServer := grpcImpl.NewEmailServer(lg, emailSender, emailManager)
-		pb.RegisterEmailServer(s, grpcServer)
+		grpcServer := grpcImpl.NewEmailServer(lg, applicationConfig.EmailConfig, emailSender, emailManager, auditRecorder, asyncSender, sandbox)
+		s.RegisterService(grpcImpl.InterceptService(
+			&pb.Email_ServiceDesc,
+			unaryInterceptors,
+			[]grpc.StreamServerInterceptor{authenticator.StreamInterceptor},
+		), grpcServer)
+		// 已注册的健康检查服务无法获取检查结果, 服务注册中心会一直认为实例可用, 直接终止启动
+		if _, exist := s.GetServiceInfo()[grpc_health_v1.Health_ServiceDesc.ServiceName]; exist {
+			lg.Fatalf("grpc health service is already registered, health check results cannot be reported")
+			return
+		}
+		grpc_health_v1.RegisterHealthServer(s, healthMonitor.HealthServer())
 	}
 	if applicationConfig.TelemetryConfig.Enable && applicationConfig.TelemetryConfig.GrpcServerTrace {
 		go grpcUtils.StartGrpcServerWithTrace(lg, cl, applicationConfig.ServerConfig.GrpcServerConfig, started, initFunc)
@@ -111,6 +278,7 @@ func main() {
 	}
 
 	cl.Add("Discovery", consulClient.UnregisterServer)
+	healthMonitor.SetRegistrar(consulClient)
 
 	go func() {
 		for {
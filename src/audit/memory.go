@@ -0,0 +1,88 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package audit
+package audit
+
+import (
+	"email-service/src/interfaces/audit"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thanhpk/randstr"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// MemoryRecorder 基于环形缓冲区的审计记录器, 仅保留最近的记录
+type MemoryRecorder struct {
+	logger  logger.Interface
+	lock    sync.RWMutex
+	records []*audit.Record
+	next    int
+	size    int
+	index   map[string]*audit.Record
+}
+
+func NewMemoryRecorder(
+	lg logger.Interface,
+	capacity int,
+) *MemoryRecorder {
+	return &MemoryRecorder{
+		logger:  logger.NewLoggerAdapter(lg, "audit"),
+		records: make([]*audit.Record, capacity),
+		index:   make(map[string]*audit.Record, capacity),
+	}
+}
+
+func (m *MemoryRecorder) Record(record *audit.Record) {
+	if record.Id == "" {
+		record.Id = randstr.Hex(16)
+	}
+	if record.Time.IsZero() {
+		record.Time = time.Now()
+	}
+	m.logger.Infof("[%s] caller=%s method=%s type=%s to=%s cc=%d bcc=%d success=%t error=%s",
+		record.Id, record.Caller, record.Method, record.EmailType, strings.Join(record.To, ","),
+		len(record.Cc), len(record.Bcc), record.Success, record.Error)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if old := m.records[m.next]; old != nil {
+		delete(m.index, old.Id)
+	}
+	m.records[m.next] = record
+	m.index[record.Id] = record
+	m.next = (m.next + 1) % len(m.records)
+	if m.size < len(m.records) {
+		m.size++
+	}
+}
+
+func (m *MemoryRecorder) List(offset int, limit int) ([]*audit.Record, int) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	if offset < 0 || offset >= m.size || limit <= 0 {
+		return []*audit.Record{}, m.size
+	}
+	limit = min(limit, m.size-offset)
+	result := make([]*audit.Record, 0, limit)
+	for i := 0; i < limit; i++ {
+		index := (m.next - 1 - offset - i + 2*len(m.records)) % len(m.records)
+		result = append(result, m.records[index])
+	}
+	return result, m.size
+}
+
+func (m *MemoryRecorder) Get(id string) (*audit.Record, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	record, ok := m.index[id]
+	return record, ok
+}
+
+func (m *MemoryRecorder) Size() int {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.size
+}
@@ -0,0 +1,309 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package broker
+package broker
+
+import (
+	"bytes"
+	"email-service/src/interfaces/audit"
+	"email-service/src/interfaces/broker"
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/email"
+	"email-service/src/interfaces/render"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	cacheImpl "half-nothing.cn/service-core/cache"
+	"half-nothing.cn/service-core/interfaces/cache"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+const CallerBroker = "broker"
+
+// sentTtl 记录已发送映射的时间, 需要覆盖中间件重新投递的时间范围
+const sentTtl = time.Hour
+
+var ErrFieldNotFound = errors.New("field not found in event")
+
+// permanentErrors 重新投递也无法发送成功的错误, 记录日志后确认消息
+var permanentErrors = []error{
+	email.ErrEmailNotRegistered,
+	email.ErrEmailNotEnabled,
+	email.ErrEmailDataInvalid,
+	email.ErrNoRecipient,
+	email.ErrRecipientInvalid,
+	email.ErrEmailOptedOut,
+	email.ErrEmailSuppressed,
+}
+
+func isPermanent(err error) bool {
+	return slices.ContainsFunc(permanentErrors, func(target error) bool { return errors.Is(err, target) })
+}
+
+// Consumer 订阅其他服务发出的领域事件, 按配置映射为邮件后通过 Sender 发送
+type Consumer struct {
+	logger     logger.Interface
+	config     *config.BrokerConfig
+	location   *time.Location
+	subscriber broker.SubscriberInterface
+	sender     email.SenderInterface
+	recorder   audit.RecorderInterface
+	// 已发送成功的消息与映射, 重新投递时跳过, 避免同一主题的其他映射失败导致重复发送
+	sent cache.Interface[string, bool]
+}
+
+func NewConsumer(
+	lg logger.Interface,
+	c *config.BrokerConfig,
+	location *time.Location,
+	subscriber broker.SubscriberInterface,
+	sender email.SenderInterface,
+	recorder audit.RecorderInterface,
+) *Consumer {
+	return &Consumer{
+		logger:     logger.NewLoggerAdapter(lg, "broker-consumer"),
+		config:     c,
+		location:   location,
+		subscriber: subscriber,
+		sender:     sender,
+		recorder:   recorder,
+		sent:       cacheImpl.NewMemoryCache[string, bool](sentTtl),
+	}
+}
+
+// Close 释放已发送记录, 需要在订阅端关闭之后调用
+func (c *Consumer) Close() {
+	c.sent.Close()
+}
+
+// Start 订阅配置中的所有主题, 同一主题的多个映射共用一个订阅
+func (c *Consumer) Start() error {
+	subjects := make(map[string][]*config.BrokerSubscriptionConfig)
+	order := make([]string, 0, len(c.config.Subscriptions))
+	for _, subscription := range c.config.Subscriptions {
+		if _, ok := email.DataFactories[subscription.Email]; !ok {
+			return fmt.Errorf("email type %s cannot be sent from broker events", subscription.EmailType)
+		}
+		if _, ok := subjects[subscription.Subject]; !ok {
+			order = append(order, subscription.Subject)
+		}
+		subjects[subscription.Subject] = append(subjects[subscription.Subject], subscription)
+	}
+	for _, subject := range order {
+		if err := c.subscriber.Subscribe(subject, c.handler(subjects[subject])); err != nil {
+			return fmt.Errorf("fail to subscribe %s, %v", subject, err)
+		}
+		c.logger.Infof("subscribed to %s", subject)
+	}
+	return nil
+}
+
+// handler 只在邮件发送失败且可以重试时返回错误以便中间件重新投递, 无法解析的事件重试也无法成功, 记录日志后丢弃
+// 重新投递的消息只发送上次失败的映射
+func (c *Consumer) handler(subscriptions []*config.BrokerSubscriptionConfig) broker.Handler {
+	return func(message *broker.Message) error {
+		decoder := json.NewDecoder(bytes.NewReader(message.Data))
+		decoder.UseNumber()
+		payload := make(map[string]any)
+		if err := decoder.Decode(&payload); err != nil {
+			c.logger.Errorf("fail to decode event from %s, %v", message.Subject, err)
+			return nil
+		}
+		errs := make([]error, 0, len(subscriptions))
+		for index, subscription := range subscriptions {
+			if !c.match(subscription, payload) {
+				continue
+			}
+			key := message.Id + ":" + strconv.Itoa(index)
+			if message.Id != "" {
+				if _, ok := c.sent.Get(key); ok {
+					c.logger.Debugf("skip %s email for redelivered event %s", subscription.EmailType, message.Id)
+					continue
+				}
+			}
+			err := c.handle(message.Subject, subscription, payload)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if message.Id != "" {
+				c.sent.SetWithTTL(key, true, sentTtl)
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
+
+func (c *Consumer) match(subscription *config.BrokerSubscriptionConfig, payload map[string]any) bool {
+	for path, expected := range subscription.Filter {
+		value, ok := lookup(payload, path)
+		if !ok || stringify(value) != expected {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Consumer) handle(subject string, subscription *config.BrokerSubscriptionConfig, payload map[string]any) error {
+	recipients, err := c.recipients(subscription, payload)
+	if err != nil {
+		c.logger.Errorf("fail to build recipients of %s email from %s, %v", subscription.EmailType, subject, err)
+		return nil
+	}
+	data, err := c.data(subscription, payload)
+	if err != nil {
+		c.logger.Errorf("fail to build %s email from %s, %v", subscription.EmailType, subject, err)
+		return nil
+	}
+	c.logger.Infof("send %s email to %s for event from %s", subscription.EmailType, strings.Join(recipients.To, ","), subject)
+	err = c.sender.SendEmail(subscription.Email, recipients, data)
+	record := &audit.Record{
+		Caller:    CallerBroker,
+		Method:    subject,
+		EmailType: subscription.EmailType,
+		To:        recipients.To,
+		Cc:        recipients.Cc,
+		Bcc:       recipients.Bcc,
+		Data:      email.Redact(data),
+		Success:   err == nil,
+	}
+	if err != nil {
+		record.Error = err.Error()
+		c.logger.Errorf("fail to send %s email for event from %s, %v", subscription.EmailType, subject, err)
+	}
+	c.recorder.Record(record)
+	if isPermanent(err) {
+		return nil
+	}
+	return err
+}
+
+func (c *Consumer) recipients(subscription *config.BrokerSubscriptionConfig, payload map[string]any) (*email.Recipients, error) {
+	addresses := func(path string) ([]string, error) {
+		if path == "" {
+			return nil, nil
+		}
+		value, ok := lookup(payload, path)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrFieldNotFound, path)
+		}
+		if list, ok := value.([]any); ok {
+			result := make([]string, 0, len(list))
+			for _, item := range list {
+				result = append(result, stringify(item))
+			}
+			return result, nil
+		}
+		return []string{stringify(value)}, nil
+	}
+	to, err := addresses(subscription.Recipient)
+	if err != nil {
+		return nil, err
+	}
+	cc, err := addresses(subscription.Cc)
+	if err != nil {
+		return nil, err
+	}
+	bcc, err := addresses(subscription.Bcc)
+	if err != nil {
+		return nil, err
+	}
+	return &email.Recipients{To: to, Cc: cc, Bcc: bcc}, nil
+}
+
+// data 按配置的字段映射填充模板参数, 字段名不区分大小写
+func (c *Consumer) data(subscription *config.BrokerSubscriptionConfig, payload map[string]any) (interface{}, error) {
+	data := email.DataFactories[subscription.Email]()
+	target := reflect.ValueOf(data).Elem()
+	for name, path := range subscription.Fields {
+		field := target.FieldByNameFunc(func(fieldName string) bool { return strings.EqualFold(fieldName, name) })
+		if !field.IsValid() || !field.CanSet() {
+			return nil, fmt.Errorf("unknown field %s of %s email", name, subscription.EmailType)
+		}
+		value, ok := lookup(payload, path)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrFieldNotFound, path)
+		}
+		if err := c.assign(field, value); err != nil {
+			return nil, fmt.Errorf("fail to set field %s, %v", name, err)
+		}
+	}
+	return data, nil
+}
+
+// lookup 按.分隔的路径读取嵌套字段
+func lookup(payload map[string]any, path string) (any, bool) {
+	var current any = payload
+	for _, key := range strings.Split(path, ".") {
+		object, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func stringify(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+var timeType = reflect.TypeOf((*render.Time)(nil))
+
+// assign 设置模板参数字段, 时间字段接受时间文本或unix毫秒时间戳, 不带时区的时间按默认时区解析
+func (c *Consumer) assign(field reflect.Value, value any) error {
+	if field.Type() == timeType {
+		switch v := value.(type) {
+		case json.Number:
+			milli, err := v.Int64()
+			if err != nil {
+				return err
+			}
+			field.Set(reflect.ValueOf(render.NewTime(time.UnixMilli(milli))))
+		case float64:
+			field.Set(reflect.ValueOf(render.NewTime(time.UnixMilli(int64(v)))))
+		default:
+			field.Set(reflect.ValueOf(render.ParseTime(stringify(value), c.location)))
+		}
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(stringify(value))
+	case reflect.Bool:
+		if b, ok := value.(bool); ok {
+			field.SetBool(b)
+			return nil
+		}
+		b, err := strconv.ParseBool(stringify(value))
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	default:
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(raw, field.Addr().Interface())
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package broker
+package broker
+
+import (
+	auditImpl "email-service/src/audit"
+	"email-service/src/interfaces/broker"
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/email"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+type nopLogger struct{ logger.Interface }
+
+func (nopLogger) Debug(string)          {}
+func (nopLogger) Info(string)           {}
+func (nopLogger) Warn(string)           {}
+func (nopLogger) Error(string)          {}
+func (nopLogger) Fatal(string)          {}
+func (nopLogger) Debugf(string, ...any) {}
+func (nopLogger) Infof(string, ...any)  {}
+func (nopLogger) Warnf(string, ...any)  {}
+func (nopLogger) Errorf(string, ...any) {}
+func (nopLogger) Fatalf(string, ...any) {}
+
+type sentEmail struct {
+	emailType  config.Email
+	recipients *email.Recipients
+	data       interface{}
+}
+
+// fakeSender errFor按第一个收件人返回错误, 未命中时返回err
+type fakeSender struct {
+	lock   sync.Mutex
+	sent   []*sentEmail
+	err    error
+	errFor map[string]error
+}
+
+func (f *fakeSender) SendEmail(emailType config.Email, recipients *email.Recipients, data interface{}) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.sent = append(f.sent, &sentEmail{emailType: emailType, recipients: recipients, data: data})
+	if err, ok := f.errFor[recipients.To[0]]; ok {
+		return err
+	}
+	return f.err
+}
+
+func (f *fakeSender) Pending() int { return 0 }
+
+func bannedSubscription() *config.BrokerSubscriptionConfig {
+	return &config.BrokerSubscriptionConfig{
+		Subject:   "fsd.user.banned",
+		EmailType: config.EmailBanned.Value,
+		Email:     config.EmailBanned,
+		Filter:    map[string]string{"type": "ban_issued"},
+		Recipient: "user.email",
+		Bcc:       "auditors",
+		Fields: map[string]string{
+			"Cid":      "user.cid",
+			"reason":   "reason",
+			"Time":     "time",
+			"Operator": "operator",
+		},
+	}
+}
+
+func newTestConsumer(t *testing.T, sender *fakeSender, subscriptions ...*config.BrokerSubscriptionConfig) (*MemoryBroker, *auditImpl.MemoryRecorder) {
+	t.Helper()
+	lg := nopLogger{}
+	memoryBroker := NewMemoryBroker()
+	recorder := auditImpl.NewMemoryRecorder(lg, 16)
+	consumer := NewConsumer(lg, &config.BrokerConfig{Enable: true, Subscriptions: subscriptions}, time.UTC, memoryBroker, sender, recorder)
+	if err := consumer.Start(); err != nil {
+		t.Fatalf("start consumer: %v", err)
+	}
+	return memoryBroker, recorder
+}
+
+const bannedEvent = `{
+	"type": "ban_issued",
+	"user": {"cid": 2352, "email": "pilot@example.com"},
+	"auditors": ["a@example.com", "b@example.com"],
+	"reason": "违反飞行规定",
+	"time": 1767225600000,
+	"operator": "1001"
+}`
+
+func TestConsumerSendsMappedEmail(t *testing.T) {
+	sender := &fakeSender{}
+	memoryBroker, recorder := newTestConsumer(t, sender, bannedSubscription())
+
+	if err := memoryBroker.Publish("fsd.user.banned", []byte(bannedEvent)); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	if len(sender.sent) != 1 {
+		t.Fatalf("sent %d emails, want 1", len(sender.sent))
+	}
+	sent := sender.sent[0]
+	if sent.emailType != config.EmailBanned {
+		t.Errorf("email type = %s, want %s", sent.emailType.Value, config.EmailBanned.Value)
+	}
+	if !slices.Equal(sent.recipients.To, []string{"pilot@example.com"}) {
+		t.Errorf("to = %v", sent.recipients.To)
+	}
+	if !slices.Equal(sent.recipients.Bcc, []string{"a@example.com", "b@example.com"}) {
+		t.Errorf("bcc = %v", sent.recipients.Bcc)
+	}
+	data, ok := sent.data.(*email.BannedEmail)
+	if !ok {
+		t.Fatalf("data type = %T, want *email.BannedEmail", sent.data)
+	}
+	if data.Cid != "2352" || data.Reason != "违反飞行规定" || data.Operator != "1001" {
+		t.Errorf("data = %+v", data)
+	}
+	if data.Time == nil || !data.Time.At.Equal(time.UnixMilli(1767225600000)) {
+		t.Errorf("time = %v", data.Time)
+	}
+
+	records, total := recorder.List(0, 10)
+	if total != 1 || records[0].Caller != CallerBroker || records[0].Method != "fsd.user.banned" || !records[0].Success {
+		t.Errorf("audit records = %+v", records)
+	}
+}
+
+func TestConsumerSkipsUnmatchedFilter(t *testing.T) {
+	sender := &fakeSender{}
+	memoryBroker, _ := newTestConsumer(t, sender, bannedSubscription())
+
+	event := `{"type": "ban_lifted", "user": {"cid": 2352, "email": "pilot@example.com"}}`
+	if err := memoryBroker.Publish("fsd.user.banned", []byte(event)); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	if len(sender.sent) != 0 {
+		t.Errorf("sent %d emails, want 0", len(sender.sent))
+	}
+}
+
+func TestConsumerDropsMalformedEvent(t *testing.T) {
+	sender := &fakeSender{}
+	memoryBroker, recorder := newTestConsumer(t, sender, bannedSubscription())
+
+	for _, event := range []string{`not json`, `{"type": "ban_issued", "user": {"cid": 2352}}`} {
+		// 无法构造邮件的事件重新投递也不会成功, 不应返回错误
+		if err := memoryBroker.Publish("fsd.user.banned", []byte(event)); err != nil {
+			t.Errorf("publish %q: %v", event, err)
+		}
+	}
+	if len(sender.sent) != 0 {
+		t.Errorf("sent %d emails, want 0", len(sender.sent))
+	}
+	if _, total := recorder.List(0, 10); total != 0 {
+		t.Errorf("audit total = %d, want 0", total)
+	}
+}
+
+func TestConsumerReturnsSendError(t *testing.T) {
+	errSmtp := errors.New("smtp unavailable")
+	sender := &fakeSender{err: errSmtp}
+	memoryBroker, recorder := newTestConsumer(t, sender, bannedSubscription())
+
+	if err := memoryBroker.Publish("fsd.user.banned", []byte(bannedEvent)); !errors.Is(err, errSmtp) {
+		t.Fatalf("publish error = %v, want %v", err, errSmtp)
+	}
+	records, total := recorder.List(0, 10)
+	if total != 1 || records[0].Success || records[0].Error != errSmtp.Error() {
+		t.Errorf("audit records = %+v", records)
+	}
+}
+
+func TestConsumerAcksPermanentSendError(t *testing.T) {
+	for _, permanent := range []error{email.ErrEmailNotEnabled, email.ErrRecipientInvalid, email.ErrEmailOptedOut, email.ErrEmailSuppressed} {
+		sender := &fakeSender{err: permanent}
+		memoryBroker, recorder := newTestConsumer(t, sender, bannedSubscription())
+
+		// 重新投递也无法发送成功, 不应返回错误
+		if err := memoryBroker.Publish("fsd.user.banned", []byte(bannedEvent)); err != nil {
+			t.Errorf("publish with %v: %v", permanent, err)
+		}
+		if records, total := recorder.List(0, 10); total != 1 || records[0].Success {
+			t.Errorf("audit records with %v = %+v", permanent, records)
+		}
+	}
+}
+
+func TestConsumerRedeliverySkipsSentMappings(t *testing.T) {
+	errSmtp := errors.New("smtp unavailable")
+	sender := &fakeSender{errFor: map[string]error{"2352@example.com": errSmtp}}
+	notice := bannedSubscription()
+	notice.Recipient = "user.cid"
+	consumer := NewConsumer(nopLogger{}, &config.BrokerConfig{Enable: true}, time.UTC, NewMemoryBroker(), sender, auditImpl.NewMemoryRecorder(nopLogger{}, 16))
+	handler := consumer.handler([]*config.BrokerSubscriptionConfig{bannedSubscription(), notice})
+	event := strings.Replace(bannedEvent, `"cid": 2352`, `"cid": "2352@example.com"`, 1)
+	message := &broker.Message{Id: "events:1", Subject: "fsd.user.banned", Data: []byte(event)}
+
+	if err := handler(message); !errors.Is(err, errSmtp) {
+		t.Fatalf("first delivery error = %v, want %v", err, errSmtp)
+	}
+	delete(sender.errFor, "2352@example.com")
+	if err := handler(message); err != nil {
+		t.Fatalf("redelivery error = %v", err)
+	}
+	// 第一次投递已发送成功的映射不再重复发送
+	to := make([]string, 0, len(sender.sent))
+	for _, sent := range sender.sent {
+		to = append(to, sent.recipients.To[0])
+	}
+	if !slices.Equal(to, []string{"pilot@example.com", "2352@example.com", "2352@example.com"}) {
+		t.Errorf("sent to %v", to)
+	}
+}
+
+func TestConsumerRejectsCredentialEmails(t *testing.T) {
+	for _, emailType := range []config.Email{config.EmailVerifyCode, config.EmailPasswordReset, config.EmailEmailChangeVerify} {
+		subscription := &config.BrokerSubscriptionConfig{
+			Subject:   "user.event",
+			EmailType: emailType.Value,
+			Email:     emailType,
+			Recipient: "email",
+		}
+		consumer := NewConsumer(nopLogger{}, &config.BrokerConfig{Enable: true, Subscriptions: []*config.BrokerSubscriptionConfig{subscription}},
+			time.UTC, NewMemoryBroker(), &fakeSender{}, auditImpl.NewMemoryRecorder(nopLogger{}, 1))
+		if err := consumer.Start(); err == nil {
+			t.Errorf("%s subscription accepted, want error", emailType.Value)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package broker
+package broker
+
+import (
+	"email-service/src/interfaces/broker"
+	"errors"
+	"sync"
+)
+
+var ErrBrokerClosed = errors.New("broker closed")
+
+// MemoryBroker 进程内的消息中间件, 发布时同步调用订阅者, 仅支持精确匹配主题
+// 用于测试与本地调试, 不经过网络, 订阅者返回的错误由Publish返回, 不会重新投递
+type MemoryBroker struct {
+	lock     sync.RWMutex
+	handlers map[string][]broker.Handler
+	closed   bool
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		handlers: make(map[string][]broker.Handler),
+	}
+}
+
+func (m *MemoryBroker) Subscribe(subject string, handler broker.Handler) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.closed {
+		return ErrBrokerClosed
+	}
+	m.handlers[subject] = append(m.handlers[subject], handler)
+	return nil
+}
+
+func (m *MemoryBroker) Publish(subject string, data []byte) error {
+	m.lock.RLock()
+	if m.closed {
+		m.lock.RUnlock()
+		return ErrBrokerClosed
+	}
+	handlers := m.handlers[subject]
+	m.lock.RUnlock()
+	errs := make([]error, 0, len(handlers))
+	for _, handler := range handlers {
+		errs = append(errs, handler(&broker.Message{Subject: subject, Data: data}))
+	}
+	return errors.Join(errs...)
+}
+
+func (m *MemoryBroker) Close() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.closed = true
+	m.handlers = make(map[string][]broker.Handler)
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package broker
+package broker
+
+import (
+	"email-service/src/interfaces/broker"
+	"email-service/src/interfaces/config"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// NatsSubscriber 通过NATS队列组订阅主题, 同一队列组内的实例分摊消息
+// 配置了JetStream流时处理成功才确认消息, 否则使用core NATS, 每条消息最多投递一次
+type NatsSubscriber struct {
+	logger    logger.Interface
+	config    *config.NatsBrokerConfig
+	conn      *nats.Conn
+	jetStream nats.JetStreamContext
+}
+
+func NewNatsSubscriber(
+	lg logger.Interface,
+	c *config.NatsBrokerConfig,
+) (*NatsSubscriber, error) {
+	subscriber := &NatsSubscriber{
+		logger: logger.NewLoggerAdapter(lg, "nats"),
+		config: c,
+	}
+	options := []nats.Option{
+		nats.Name(c.Name),
+		nats.MaxReconnects(-1),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			if err != nil {
+				subscriber.logger.Warnf("disconnected from nats server, %v", err)
+			}
+		}),
+		nats.ReconnectHandler(func(conn *nats.Conn) {
+			subscriber.logger.Infof("reconnected to nats server %s", conn.ConnectedUrl())
+		}),
+	}
+	if c.Token != "" {
+		options = append(options, nats.Token(c.Token))
+	}
+	conn, err := nats.Connect(c.Url, options...)
+	if err != nil {
+		return nil, fmt.Errorf("connect to nats server fail, %v", err)
+	}
+	subscriber.conn = conn
+	if c.Stream == "" {
+		subscriber.logger.Warnf("jetstream is not configured, events are delivered at most once and lost while the service is unavailable")
+		return subscriber, nil
+	}
+	jetStream, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("create jetstream context fail, %v", err)
+	}
+	subscriber.jetStream = jetStream
+	return subscriber, nil
+}
+
+var durableReplacer = strings.NewReplacer(".", "_", "*", "any", ">", "all")
+
+func (n *NatsSubscriber) Subscribe(subject string, handler broker.Handler) error {
+	if n.jetStream == nil {
+		_, err := n.conn.QueueSubscribe(subject, n.config.Queue, func(msg *nats.Msg) {
+			if err := handler(&broker.Message{Subject: msg.Subject, Data: msg.Data}); err != nil {
+				n.logger.Warnf("event from %s is not redelivered under core nats, %v", msg.Subject, err)
+			}
+		})
+		return err
+	}
+	// 同一队列组的不同主题需要各自的持久化消费者
+	durable := n.config.Queue + "_" + durableReplacer.Replace(subject)
+	_, err := n.jetStream.QueueSubscribe(subject, n.config.Queue, func(msg *nats.Msg) {
+		message := &broker.Message{Subject: msg.Subject, Data: msg.Data}
+		if metadata, err := msg.Metadata(); err == nil {
+			message.Id = metadata.Stream + ":" + strconv.FormatUint(metadata.Sequence.Stream, 10)
+		}
+		if err := handler(message); err != nil {
+			n.logger.Warnf("fail to handle event from %s, request redelivery, %v", msg.Subject, err)
+			if err := msg.Nak(); err != nil {
+				n.logger.Errorf("fail to nak event from %s, %v", msg.Subject, err)
+			}
+			return
+		}
+		if err := msg.Ack(); err != nil {
+			n.logger.Errorf("fail to ack event from %s, %v", msg.Subject, err)
+		}
+	}, nats.BindStream(n.config.Stream), nats.Durable(durable), nats.ManualAck(), nats.MaxDeliver(n.config.MaxDeliver))
+	return err
+}
+
+// Close 处理完已收到的消息后断开连接
+func (n *NatsSubscriber) Close() error {
+	return n.conn.Drain()
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package captcha
+package captcha
+
+import (
+	"email-service/src/interfaces/captcha"
+	"slices"
+)
+
+// FakeVerifier 本地假验证器, 仅接受预设的令牌, 用于测试与本地开发
+type FakeVerifier struct {
+	tokens []string
+}
+
+func NewFakeVerifier(tokens ...string) *FakeVerifier {
+	return &FakeVerifier{tokens: tokens}
+}
+
+func (v *FakeVerifier) Verify(token string, _ string) error {
+	if token == "" {
+		return captcha.ErrCaptchaRequired
+	}
+	if !slices.Contains(v.tokens, token) {
+		return captcha.ErrCaptchaInvalid
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package captcha
+package captcha
+
+import (
+	"email-service/src/interfaces/captcha"
+	"email-service/src/interfaces/config"
+	"errors"
+	"testing"
+)
+
+func TestFakeVerifier(t *testing.T) {
+	verifier := NewVerifier(nil, &config.CaptchaConfig{Enable: true, Provider: config.CaptchaProviderFake, Secret: "pass"})
+	tests := []struct {
+		name  string
+		token string
+		err   error
+	}{
+		{"missing token", "", captcha.ErrCaptchaRequired},
+		{"wrong token", "fail", captcha.ErrCaptchaInvalid},
+		{"preset token", "pass", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifier.Verify(tt.token, "127.0.0.1"); !errors.Is(err, tt.err) {
+				t.Errorf("Verify(%q) = %v, want %v", tt.token, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestNewVerifierDisabled(t *testing.T) {
+	if verifier := NewVerifier(nil, &config.CaptchaConfig{Enable: false, Provider: config.CaptchaProviderFake}); verifier != nil {
+		t.Errorf("verifier = %T, want nil when disabled", verifier)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package captcha
+package captcha
+
+import (
+	"email-service/src/interfaces/captcha"
+	"email-service/src/interfaces/config"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+var defaultVerifyUrls = map[string]string{
+	config.CaptchaProviderHCaptcha:  "https://api.hcaptcha.com/siteverify",
+	config.CaptchaProviderTurnstile: "https://challenges.cloudflare.com/turnstile/v0/siteverify",
+	config.CaptchaProviderReCaptcha: "https://www.google.com/recaptcha/api/siteverify",
+}
+
+type siteVerifyResponse struct {
+	Success    bool     `json:"success"`
+	Score      *float64 `json:"score"`
+	ErrorCodes []string `json:"error-codes"`
+}
+
+// SiteVerifier 兼容 hCaptcha, Turnstile 与 reCAPTCHA 的 siteverify 接口
+type SiteVerifier struct {
+	logger    logger.Interface
+	config    *config.CaptchaConfig
+	verifyUrl string
+	client    *http.Client
+}
+
+func NewSiteVerifier(
+	lg logger.Interface,
+	config *config.CaptchaConfig,
+) *SiteVerifier {
+	verifyUrl := config.VerifyUrl
+	if verifyUrl == "" {
+		verifyUrl = defaultVerifyUrls[config.Provider]
+	}
+	return &SiteVerifier{
+		logger:    logger.NewLoggerAdapter(lg, "captcha-"+config.Provider),
+		config:    config,
+		verifyUrl: verifyUrl,
+		client:    &http.Client{Timeout: config.TimeoutDuration},
+	}
+}
+
+func (v *SiteVerifier) Verify(token string, remoteIp string) error {
+	if token == "" {
+		return captcha.ErrCaptchaRequired
+	}
+	form := url.Values{}
+	form.Set("secret", v.config.Secret)
+	form.Set("response", token)
+	if remoteIp != "" {
+		form.Set("remoteip", remoteIp)
+	}
+	resp, err := v.client.PostForm(v.verifyUrl, form)
+	if err != nil {
+		v.logger.Errorf("fail to request captcha verify api, %v", err)
+		return fmt.Errorf("%w: %v", captcha.ErrCaptchaUnavailable, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		v.logger.Errorf("captcha verify api returned status %d", resp.StatusCode)
+		return fmt.Errorf("%w: unexpected status %d", captcha.ErrCaptchaUnavailable, resp.StatusCode)
+	}
+	result := &siteVerifyResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		v.logger.Errorf("fail to decode captcha verify response, %v", err)
+		return fmt.Errorf("%w: %v", captcha.ErrCaptchaUnavailable, err)
+	}
+	if !result.Success {
+		v.logger.Warnf("captcha verify failed for %s, error codes: %v", remoteIp, result.ErrorCodes)
+		return captcha.ErrCaptchaInvalid
+	}
+	if result.Score != nil && *result.Score < v.config.MinScore {
+		v.logger.Warnf("captcha score %.2f of %s is lower than %.2f", *result.Score, remoteIp, v.config.MinScore)
+		return captcha.ErrCaptchaInvalid
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package captcha
+package captcha
+
+import (
+	"bytes"
+	"email-service/src/interfaces/captcha"
+	"email-service/src/interfaces/config"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+type sliderVerifyRequest struct {
+	Secret string `json:"secret"`
+	Token  string `json:"token"`
+	Ip     string `json:"ip"`
+}
+
+type sliderVerifyResponse struct {
+	Success bool `json:"success"`
+}
+
+// SliderVerifier 对接自建滑块验证服务, 以JSON格式提交令牌
+type SliderVerifier struct {
+	logger logger.Interface
+	config *config.CaptchaConfig
+	client *http.Client
+}
+
+func NewSliderVerifier(
+	lg logger.Interface,
+	config *config.CaptchaConfig,
+) *SliderVerifier {
+	return &SliderVerifier{
+		logger: logger.NewLoggerAdapter(lg, "captcha-slider"),
+		config: config,
+		client: &http.Client{Timeout: config.TimeoutDuration},
+	}
+}
+
+func (v *SliderVerifier) Verify(token string, remoteIp string) error {
+	if token == "" {
+		return captcha.ErrCaptchaRequired
+	}
+	body, err := json.Marshal(&sliderVerifyRequest{Secret: v.config.Secret, Token: token, Ip: remoteIp})
+	if err != nil {
+		return err
+	}
+	resp, err := v.client.Post(v.config.VerifyUrl, "application/json", bytes.NewReader(body))
+	if err != nil {
+		v.logger.Errorf("fail to request slider verify api, %v", err)
+		return fmt.Errorf("%w: %v", captcha.ErrCaptchaUnavailable, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		v.logger.Errorf("slider verify api returned status %d", resp.StatusCode)
+		return fmt.Errorf("%w: unexpected status %d", captcha.ErrCaptchaUnavailable, resp.StatusCode)
+	}
+	result := &sliderVerifyResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		v.logger.Errorf("fail to decode slider verify response, %v", err)
+		return fmt.Errorf("%w: %v", captcha.ErrCaptchaUnavailable, err)
+	}
+	if !result.Success {
+		v.logger.Warnf("slider verify failed for %s", remoteIp)
+		return captcha.ErrCaptchaInvalid
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package captcha
+package captcha
+
+import (
+	"email-service/src/interfaces/captcha"
+	"email-service/src/interfaces/config"
+
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// NewVerifier 根据配置创建验证器, 未启用时返回nil
+func NewVerifier(
+	lg logger.Interface,
+	c *config.CaptchaConfig,
+) captcha.VerifierInterface {
+	if !c.Enable {
+		return nil
+	}
+	switch c.Provider {
+	case config.CaptchaProviderSlider:
+		return NewSliderVerifier(lg, c)
+	case config.CaptchaProviderFake:
+		return NewFakeVerifier(c.Secret)
+	default:
+		return NewSiteVerifier(lg, c)
+	}
+}
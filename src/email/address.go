@@ -0,0 +1,129 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"context"
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/email"
+	"errors"
+	"net"
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+type AddressChecker struct {
+	logger   logger.Interface
+	config   *config.AddressCheckConfig
+	resolver email.DomainResolverInterface
+}
+
+func NewAddressChecker(
+	lg logger.Interface,
+	config *config.AddressCheckConfig,
+	resolver email.DomainResolverInterface,
+) *AddressChecker {
+	return &AddressChecker{
+		logger:   logger.NewLoggerAdapter(lg, "address-checker"),
+		config:   config,
+		resolver: resolver,
+	}
+}
+
+func (a *AddressChecker) Check(address string) (string, error) {
+	address = strings.TrimSpace(address)
+	parsed, err := mail.ParseAddress(address)
+	if err != nil || parsed.Name != "" || parsed.Address != address {
+		return "", email.ErrAddressInvalid
+	}
+	at := strings.LastIndex(parsed.Address, "@")
+	domain := strings.ToLower(parsed.Address[at+1:])
+	if !validDomain(domain) {
+		return "", email.ErrAddressInvalid
+	}
+	normalized := strings.ToLower(parsed.Address)
+
+	if matchDomain(a.config.Allowlist, domain) {
+		return normalized, nil
+	}
+	if matchDomain(a.config.Blocklist, domain) {
+		a.logger.Warnf("reject disposable email address %s", normalized)
+		return "", email.ErrDomainDisposable
+	}
+	if a.config.CheckMx {
+		if err := a.checkMx(domain); err != nil {
+			return "", err
+		}
+	}
+	return normalized, nil
+}
+
+func (a *AddressChecker) checkMx(domain string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), a.config.MxTimeoutDuration)
+	defer cancel()
+	records, err := a.resolver.LookupMX(ctx, domain)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			a.logger.Warnf("domain %s has no mx record", domain)
+			return email.ErrDomainNoMx
+		}
+		// DNS服务异常时放行, 避免影响正常用户
+		a.logger.Errorf("fail to lookup mx record of %s, %v", domain, err)
+		return nil
+	}
+	// RFC 7505 Null MX 表示该域名不接收邮件
+	if len(records) == 0 || (len(records) == 1 && records[0].Host == ".") {
+		a.logger.Warnf("domain %s does not accept mail", domain)
+		return email.ErrDomainNoMx
+	}
+	return nil
+}
+
+// matchDomain 判断域名本身或其上级域名是否在列表中
+func matchDomain(domains map[string]struct{}, domain string) bool {
+	if len(domains) == 0 {
+		return false
+	}
+	for {
+		if _, ok := domains[domain]; ok {
+			return true
+		}
+		index := strings.Index(domain, ".")
+		if index < 0 {
+			return false
+		}
+		domain = domain[index+1:]
+	}
+}
+
+func validDomain(domain string) bool {
+	if len(domain) > 253 {
+		return false
+	}
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, label := range labels {
+		if label == "" || utf8.RuneCountInString(label) > 63 {
+			return false
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, r := range label {
+			if r == '-' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= utf8.RuneSelf {
+				continue
+			}
+			return false
+		}
+	}
+	tld := labels[len(labels)-1]
+	return strings.Trim(tld, "0123456789") != ""
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"context"
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/email"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeResolver 基于内存表的解析器, 不在表中的域名视为不存在, err不为空时所有查询都返回该错误
+type fakeResolver struct {
+	records map[string][]*net.MX
+	err     error
+	lookups int
+}
+
+func (r *fakeResolver) LookupMX(_ context.Context, domain string) ([]*net.MX, error) {
+	r.lookups++
+	if r.err != nil {
+		return nil, r.err
+	}
+	records, ok := r.records[strings.ToLower(domain)]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: domain, IsNotFound: true}
+	}
+	return records, nil
+}
+
+func newTestAddressChecker(resolver email.DomainResolverInterface) *AddressChecker {
+	return NewAddressChecker(nopLogger{}, &config.AddressCheckConfig{
+		CheckMx:           true,
+		MxTimeoutDuration: time.Second,
+		Blocklist:         map[string]struct{}{"mailinator.com": {}},
+		Allowlist:         map[string]struct{}{"internal.example": {}},
+	}, resolver)
+}
+
+func TestAddressCheckerCheck(t *testing.T) {
+	resolver := &fakeResolver{records: map[string][]*net.MX{
+		"example.com":   {{Host: "mx.example.com.", Pref: 10}},
+		"null-mx.com":   {{Host: ".", Pref: 0}},
+		"empty-mx.com":  {},
+		"sub.other.org": {{Host: "mx.other.org.", Pref: 10}},
+	}}
+	checker := newTestAddressChecker(resolver)
+	tests := []struct {
+		name    string
+		address string
+		want    string
+		err     error
+	}{
+		{"normalized", "  Pilot@Example.COM ", "pilot@example.com", nil},
+		{"subdomain", "user@sub.other.org", "user@sub.other.org", nil},
+		{"display name", "Pilot <pilot@example.com>", "", email.ErrAddressInvalid},
+		{"missing domain", "pilot@", "", email.ErrAddressInvalid},
+		{"single label", "pilot@localhost", "", email.ErrAddressInvalid},
+		{"numeric tld", "pilot@example.123", "", email.ErrAddressInvalid},
+		{"disposable", "pilot@mailinator.com", "", email.ErrDomainDisposable},
+		{"disposable subdomain", "pilot@spam.mailinator.com", "", email.ErrDomainDisposable},
+		// 白名单中的域名不查询MX记录
+		{"allowlist", "pilot@internal.example", "pilot@internal.example", nil},
+		{"no such domain", "pilot@missing.com", "", email.ErrDomainNoMx},
+		{"null mx", "pilot@null-mx.com", "", email.ErrDomainNoMx},
+		{"empty mx", "pilot@empty-mx.com", "", email.ErrDomainNoMx},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := checker.Check(tt.address)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Check(%q) error = %v, want %v", tt.address, err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("Check(%q) = %q, want %q", tt.address, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddressCheckerAllowsOnDnsFailure(t *testing.T) {
+	resolver := &fakeResolver{err: &net.DNSError{Err: "server misbehaving", Name: "example.com", IsTemporary: true}}
+	checker := newTestAddressChecker(resolver)
+	// DNS服务异常时放行, 不影响正常用户
+	if got, err := checker.Check("pilot@example.com"); err != nil || got != "pilot@example.com" {
+		t.Errorf("Check() = %q, %v, want address accepted", got, err)
+	}
+	if resolver.lookups != 1 {
+		t.Errorf("lookups = %d, want 1", resolver.lookups)
+	}
+}
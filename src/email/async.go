@@ -0,0 +1,265 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"context"
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/email"
+	"email-service/src/interfaces/metrics"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/thanhpk/randstr"
+	"half-nothing.cn/service-core/cache"
+	c "half-nothing.cn/service-core/interfaces/cache"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// watchBuffer 每个订阅者的缓冲区大小, 需要不小于一封邮件的状态变化次数
+const watchBuffer = 4
+
+type asyncJob struct {
+	id         string
+	emailType  config.Email
+	recipients *email.Recipients
+	data       interface{}
+	done       func(err error)
+}
+
+// AsyncSender 每个优先级使用独立的队列与固定数量的工作协程发送邮件, 并记录每封邮件的状态
+type AsyncSender struct {
+	logger   logger.Interface
+	config   *config.AsyncConfig
+	sender   email.QueuedSenderInterface
+	statuses c.Interface[string, *email.MessageStatus]
+	queues   map[string]chan *asyncJob
+	pending  atomic.Int64
+	workers  sync.WaitGroup
+	// 保护状态更新、订阅者列表与队列关闭
+	lock     sync.Mutex
+	watchers map[string][]chan *email.MessageStatus
+	closed   bool
+}
+
+func NewAsyncSender(
+	lg logger.Interface,
+	asyncConfig *config.AsyncConfig,
+	sender email.QueuedSenderInterface,
+	metrics metrics.RecorderInterface,
+) *AsyncSender {
+	asyncSender := &AsyncSender{
+		logger:   logger.NewLoggerAdapter(lg, "async-sender"),
+		config:   asyncConfig,
+		sender:   sender,
+		statuses: cache.NewMemoryCache[string, *email.MessageStatus](asyncConfig.StatusTtlDuration),
+		queues:   make(map[string]chan *asyncJob),
+		watchers: make(map[string][]chan *email.MessageStatus),
+	}
+	for _, priority := range config.Priorities {
+		queue := make(chan *asyncJob, asyncConfig.QueueSize)
+		asyncSender.queues[priority] = queue
+		metrics.RegisterSize("async_queue_"+priority, func() int { return len(queue) })
+	}
+	metrics.RegisterSize("async_queue", asyncSender.Pending)
+	return asyncSender
+}
+
+// Start 启动工作协程
+func (a *AsyncSender) Start() {
+	for _, queue := range a.queues {
+		for i := 0; i < a.config.Workers; i++ {
+			a.workers.Add(1)
+			go a.work(queue)
+		}
+	}
+}
+
+// Close 停止接收新邮件, 等待队列中的邮件发送完毕或ctx结束
+func (a *AsyncSender) Close(ctx context.Context) error {
+	a.lock.Lock()
+	if !a.closed {
+		a.closed = true
+		for _, queue := range a.queues {
+			close(queue)
+		}
+	}
+	a.lock.Unlock()
+	finished := make(chan struct{})
+	go func() {
+		a.workers.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+	case <-ctx.Done():
+		a.logger.Warnf("async sender closed with %d emails left in queue", a.Pending())
+		return ctx.Err()
+	}
+	a.statuses.Close()
+	return nil
+}
+
+func (a *AsyncSender) Pending() int {
+	return int(a.pending.Load())
+}
+
+func (a *AsyncSender) Enqueue(emailType config.Email, recipients *email.Recipients, data interface{}, done func(err error)) (*email.MessageStatus, error) {
+	var callback func(*email.Recipients, error)
+	if done != nil {
+		callback = func(_ *email.Recipients, err error) { done(err) }
+	}
+	statuses, err := a.EnqueueAll(emailType, []*email.Recipients{recipients}, data, callback)
+	if err != nil {
+		return nil, err
+	}
+	return statuses[0], nil
+}
+
+func (a *AsyncSender) EnqueueAll(emailType config.Email, recipients []*email.Recipients, data interface{}, done func(recipients *email.Recipients, err error)) ([]*email.MessageStatus, error) {
+	now := time.Now()
+	statuses := make([]*email.MessageStatus, 0, len(recipients))
+	jobs := make([]*asyncJob, 0, len(recipients))
+	for _, target := range recipients {
+		status := &email.MessageStatus{
+			Id:        randstr.Hex(16),
+			EmailType: emailType.Value,
+			State:     email.MessageQueued,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		job := &asyncJob{id: status.Id, emailType: emailType, recipients: target, data: data}
+		if done != nil {
+			job.done = func(err error) { done(target, err) }
+		}
+		statuses = append(statuses, status)
+		jobs = append(jobs, job)
+	}
+	queue, ok := a.queues[emailType.Data.Priority]
+	if !ok {
+		queue = a.queues[config.PriorityNormal]
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.closed {
+		return nil, email.ErrSendQueueFull
+	}
+	// 放入队列只在持有锁时进行, 工作协程只会取出邮件, 检查后的剩余空间不会变少
+	if cap(queue)-len(queue) < len(jobs) {
+		a.logger.Warnf("%s send queue is full, reject %d %s emails", emailType.Data.Priority, len(jobs), emailType.Value)
+		return nil, email.ErrSendQueueFull
+	}
+	results := make([]*email.MessageStatus, 0, len(statuses))
+	for index, job := range jobs {
+		queue <- job
+		a.pending.Add(1)
+		a.statuses.SetWithTTL(job.id, statuses[index], a.config.StatusTtlDuration)
+		result := *statuses[index]
+		results = append(results, &result)
+	}
+	return results, nil
+}
+
+func (a *AsyncSender) work(queue chan *asyncJob) {
+	defer a.workers.Done()
+	for job := range queue {
+		a.pending.Add(-1)
+		a.update(job.id, email.MessageSending, nil)
+		err := a.sender.SendQueuedEmail(job.id, job.emailType, job.recipients, job.data)
+		if err != nil {
+			a.update(job.id, email.MessageFailed, err)
+		} else {
+			a.update(job.id, email.MessageSent, nil)
+		}
+		if job.done != nil {
+			job.done(err)
+		}
+	}
+}
+
+// update 更新消息状态并通知订阅者, 进入终态后关闭所有订阅通道
+func (a *AsyncSender) update(id string, state email.MessageState, err error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	current, ok := a.statuses.Get(id)
+	if !ok {
+		return
+	}
+	status := *current
+	status.State = state
+	status.UpdatedAt = time.Now()
+	if err != nil {
+		status.Error = err.Error()
+	}
+	a.statuses.SetWithTTL(id, &status, a.config.StatusTtlDuration)
+
+	for _, watcher := range a.watchers[id] {
+		result := status
+		select {
+		case watcher <- &result:
+		default:
+			a.logger.Warnf("drop status update of message %s, watcher is too slow", id)
+		}
+		if state.Finished() {
+			close(watcher)
+		}
+	}
+	if state.Finished() {
+		delete(a.watchers, id)
+	}
+}
+
+func (a *AsyncSender) Status(id string) (*email.MessageStatus, bool) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	status, ok := a.statuses.Get(id)
+	if !ok {
+		return nil, false
+	}
+	result := *status
+	return &result, true
+}
+
+func (a *AsyncSender) Watch(ctx context.Context, id string) (<-chan *email.MessageStatus, bool) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	status, ok := a.statuses.Get(id)
+	if !ok {
+		return nil, false
+	}
+	watcher := make(chan *email.MessageStatus, watchBuffer)
+	result := *status
+	watcher <- &result
+	if status.State.Finished() {
+		close(watcher)
+		return watcher, true
+	}
+	a.watchers[id] = append(a.watchers[id], watcher)
+	go func() {
+		<-ctx.Done()
+		a.unwatch(id, watcher)
+	}()
+	return watcher, true
+}
+
+// unwatch 移除尚未关闭的订阅通道
+func (a *AsyncSender) unwatch(id string, watcher chan *email.MessageStatus) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	watchers := a.watchers[id]
+	index := slices.Index(watchers, watcher)
+	if index < 0 {
+		return
+	}
+	close(watcher)
+	if len(watchers) == 1 {
+		delete(a.watchers, id)
+		return
+	}
+	a.watchers[id] = slices.Delete(watchers, index, index+1)
+}
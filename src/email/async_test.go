@@ -0,0 +1,89 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"context"
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/email"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingSender 记录每封邮件的收件人与异步消息Id
+type countingSender struct {
+	lock sync.Mutex
+	to   []string
+	ids  []string
+}
+
+func (s *countingSender) SendEmail(_ config.Email, recipients *email.Recipients, _ interface{}) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.to = append(s.to, recipients.To...)
+	return nil
+}
+
+func (s *countingSender) SendQueuedEmail(id string, emailType config.Email, recipients *email.Recipients, data interface{}) error {
+	s.lock.Lock()
+	s.ids = append(s.ids, id)
+	s.lock.Unlock()
+	return s.SendEmail(emailType, recipients, data)
+}
+
+func (s *countingSender) Pending() int { return 0 }
+
+func TestAsyncSenderEnqueueAllIsAllOrNothing(t *testing.T) {
+	sender := &countingSender{}
+	asyncSender := NewAsyncSender(nopLogger{}, &config.AsyncConfig{Workers: 1, QueueSize: 2, StatusTtlDuration: time.Minute}, sender, nopMetrics{})
+	batch := func(addresses ...string) []*email.Recipients {
+		recipients := make([]*email.Recipients, 0, len(addresses))
+		for _, address := range addresses {
+			recipients = append(recipients, email.NewRecipients(address))
+		}
+		return recipients
+	}
+
+	// 队列只剩两个位置, 三封邮件整体拒绝, 不留下部分入队的邮件
+	if _, err := asyncSender.EnqueueAll(config.EmailRoleChange, batch("a@example.com", "b@example.com", "c@example.com"), nil, nil); !errors.Is(err, email.ErrSendQueueFull) {
+		t.Fatalf("enqueue error = %v, want %v", err, email.ErrSendQueueFull)
+	}
+	if pending := asyncSender.Pending(); pending != 0 {
+		t.Fatalf("pending = %d after rejected batch, want 0", pending)
+	}
+
+	var done sync.WaitGroup
+	done.Add(2)
+	statuses, err := asyncSender.EnqueueAll(config.EmailRoleChange, batch("a@example.com", "b@example.com"), nil, func(*email.Recipients, error) { done.Done() })
+	if err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	if len(statuses) != 2 || statuses[0].Id == statuses[1].Id {
+		t.Fatalf("statuses = %+v, want two distinct messages", statuses)
+	}
+	for _, status := range statuses {
+		if _, ok := asyncSender.Status(status.Id); !ok {
+			t.Errorf("status of %s not found", status.Id)
+		}
+	}
+
+	asyncSender.Start()
+	done.Wait()
+	if err := asyncSender.Close(context.Background()); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if len(sender.to) != 2 {
+		t.Errorf("sent to %v, want two recipients", sender.to)
+	}
+	// 发送时携带入队返回的消息Id, 投递结果事件据此与消息状态对应
+	for _, status := range statuses {
+		if !slices.Contains(sender.ids, status.Id) {
+			t.Errorf("sent ids %v, missing %s", sender.ids, status.Id)
+		}
+	}
+}
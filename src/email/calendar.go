@@ -0,0 +1,105 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"crypto/sha256"
+	"email-service/src/interfaces/email"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	calendarMethodRequest = "REQUEST"
+	calendarMethodCancel  = "CANCEL"
+	calendarTimeLayout    = "20060102T150405Z"
+	// calendarLineLimit RFC 5545规定每行不超过75个字节, 超出部分需要折行
+	calendarLineLimit = 75
+)
+
+var calendarEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// calendarUid 由事件Key生成稳定的UID, 同一活动的取消通知与邀请使用相同的UID
+func calendarUid(key string, domain string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:16]) + "@" + domain
+}
+
+// foldCalendarLine 按RFC 5545折行, 不在UTF-8字符中间断开
+func foldCalendarLine(sb *strings.Builder, line string) {
+	limit := calendarLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		sb.WriteString(line[:cut])
+		sb.WriteString("\r\n ")
+		line = line[cut:]
+		// 续行以空格开头, 占用一个字节
+		limit = calendarLineLimit - 1
+	}
+	sb.WriteString(line)
+	sb.WriteString("\r\n")
+}
+
+// buildCalendar 生成iCalendar邀请或取消通知, 返回内容与METHOD
+func (sender *Sender) buildCalendar(event *email.CalendarEvent, attendees []string) (string, string, error) {
+	method := calendarMethodRequest
+	if event.Cancel {
+		method = calendarMethodCancel
+	} else if !event.StartTime.Valid() {
+		return "", "", fmt.Errorf("unrecognized activity time %q", event.StartTime.String())
+	}
+
+	// SEQUENCE取DTSTAMP的秒级时间戳, 同一UID之后发出的更新与取消通知总是更大
+	now := time.Now().UTC()
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//email-service//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:" + method,
+		"BEGIN:VEVENT",
+		"UID:" + calendarUid(event.Key, sender.domain()),
+		"DTSTAMP:" + now.Format(calendarTimeLayout),
+		"SEQUENCE:" + strconv.FormatInt(now.Unix(), 10),
+		"SUMMARY:" + calendarEscaper.Replace(event.Summary),
+	}
+	organizer := "ORGANIZER"
+	if sender.config.FromName != "" {
+		// 参数值不支持转义, 使用引号包裹并去掉其中的引号
+		organizer += `;CN="` + strings.ReplaceAll(sender.config.FromName, `"`, "") + `"`
+	}
+	lines = append(lines, organizer+":mailto:"+sender.config.From)
+	for _, attendee := range attendees {
+		lines = append(lines, "ATTENDEE;ROLE=REQ-PARTICIPANT;PARTSTAT=ACCEPTED:mailto:"+attendee)
+	}
+	if event.Cancel {
+		lines = append(lines, "STATUS:CANCELLED")
+	} else {
+		lines = append(lines,
+			"STATUS:CONFIRMED",
+			"DTSTART:"+event.StartTime.At.UTC().Format(calendarTimeLayout),
+			"DTEND:"+event.StartTime.At.Add(sender.config.Calendar.EventLengthDuration).UTC().Format(calendarTimeLayout),
+		)
+		if event.Location != "" {
+			lines = append(lines, "LOCATION:"+calendarEscaper.Replace(event.Location))
+		}
+		if event.Description != "" {
+			lines = append(lines, "DESCRIPTION:"+calendarEscaper.Replace(event.Description))
+		}
+	}
+	lines = append(lines, "END:VEVENT", "END:VCALENDAR")
+
+	var sb strings.Builder
+	for _, line := range lines {
+		foldCalendarLine(&sb, line)
+	}
+	return sb.String(), method, nil
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/email"
+	"email-service/src/interfaces/render"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newCalendarSender() *Sender {
+	return &Sender{config: &config.EmailConfig{
+		Username: "mailer",
+		From:     "noreply@example.com",
+		FromName: "Example",
+		Calendar: &config.CalendarConfig{Enable: true, EventLengthDuration: 2 * time.Hour},
+	}}
+}
+
+// calendarProperty 返回iCalendar中指定属性的值
+func calendarProperty(t *testing.T, ics string, name string) string {
+	t.Helper()
+	for _, line := range strings.Split(ics, "\r\n") {
+		if key, value, ok := strings.Cut(line, ":"); ok && (key == name || strings.HasPrefix(key, name+";")) {
+			return value
+		}
+	}
+	t.Fatalf("property %s not found in:\n%s", name, ics)
+	return ""
+}
+
+func TestBuildCalendarInviteAndCancel(t *testing.T) {
+	sender := newCalendarSender()
+	join := &email.ActivityAtcJoinEmail{
+		Cid:          "2352",
+		ActivityId:   "42",
+		ActivityName: "联飞活动",
+		ActivityTime: render.NewTime(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)),
+		Facility:     "ZSSS_APP",
+		Frequency:    "125.950",
+	}
+	before := time.Now().Unix()
+	invite, method, err := sender.buildCalendar(join.CalendarEvent(), []string{"pilot@example.com"})
+	if err != nil {
+		t.Fatalf("build invite: %v", err)
+	}
+	if method != calendarMethodRequest {
+		t.Errorf("method = %s, want %s", method, calendarMethodRequest)
+	}
+	if organizer := calendarProperty(t, invite, "ORGANIZER"); organizer != "mailto:noreply@example.com" {
+		t.Errorf("organizer = %s", organizer)
+	}
+	sequence, err := strconv.ParseInt(calendarProperty(t, invite, "SEQUENCE"), 10, 64)
+	if err != nil || sequence < before {
+		t.Errorf("invite sequence = %d, want unix seconds >= %d", sequence, before)
+	}
+
+	// 同一活动的取消通知使用相同的UID, 且SEQUENCE不小于邀请
+	leave := &email.ActivityAtcLeaveEmail{Cid: "2352", ActivityId: "42", ActivityName: "联飞活动"}
+	cancel, method, err := sender.buildCalendar(leave.CalendarEvent(), []string{"pilot@example.com"})
+	if err != nil {
+		t.Fatalf("build cancel: %v", err)
+	}
+	if method != calendarMethodCancel {
+		t.Errorf("method = %s, want %s", method, calendarMethodCancel)
+	}
+	if calendarProperty(t, cancel, "UID") != calendarProperty(t, invite, "UID") {
+		t.Error("cancel uid differs from invite uid")
+	}
+	cancelSequence, _ := strconv.ParseInt(calendarProperty(t, cancel, "SEQUENCE"), 10, 64)
+	if cancelSequence < sequence {
+		t.Errorf("cancel sequence = %d, want >= %d", cancelSequence, sequence)
+	}
+
+	// 同名的另一场活动使用不同的UID
+	other := &email.ActivityAtcJoinEmail{ActivityId: "43", ActivityName: "联飞活动", ActivityTime: join.ActivityTime}
+	otherInvite, _, err := sender.buildCalendar(other.CalendarEvent(), nil)
+	if err != nil {
+		t.Fatalf("build other invite: %v", err)
+	}
+	if calendarProperty(t, otherInvite, "UID") == calendarProperty(t, invite, "UID") {
+		t.Error("different activities share the same uid")
+	}
+}
+
+func TestCalendarEventRequiresActivityId(t *testing.T) {
+	sender := newCalendarSender()
+	data := &email.ActivityPilotJoinEmail{Cid: "2352", ActivityName: "联飞活动", ActivityTime: render.NewTime(time.Now())}
+	if event := sender.calendarEventOf(data); event != nil {
+		t.Errorf("event = %+v, want nil without activity id", event)
+	}
+	data.ActivityId = "42"
+	if event := sender.calendarEventOf(data); event == nil {
+		t.Error("event = nil, want invite")
+	}
+	sender.config.Calendar.Enable = false
+	if event := sender.calendarEventOf(data); event != nil {
+		t.Errorf("event = %+v, want nil when calendar disabled", event)
+	}
+}
@@ -5,10 +5,15 @@
 package email
 
 import (
+	"crypto/sha256"
+	"crypto/subtle"
 	"email-service/src/interfaces/config"
 	"email-service/src/interfaces/email"
+	"email-service/src/interfaces/metrics"
+	"email-service/src/interfaces/webhook"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/thanhpk/randstr"
@@ -17,10 +22,18 @@ import (
 )
 
 type CodeManager struct {
-	logger    logger.Interface
-	config    *config.EmailConfig
-	cache     cache.Interface[string, *email.CodeData]
-	sendCache cache.Interface[string, time.Time]
+	logger      logger.Interface
+	config      *config.EmailConfig
+	cache       cache.Interface[string, *email.CodeData]
+	sendCache   cache.Interface[string, time.Time]
+	changeCache cache.Interface[string, *email.EmailChangeRequest]
+	resetCache  cache.Interface[string, *email.ResetToken]
+	metrics     metrics.RecorderInterface
+	webhooks    webhook.DispatcherInterface
+	// 保护邮箱变更请求的状态与尝试次数
+	changeLock sync.Mutex
+	// 保护重置令牌的尝试次数
+	resetLock sync.Mutex
 }
 
 func NewCodeManager(
@@ -28,18 +41,27 @@ func NewCodeManager(
 	config *config.EmailConfig,
 	cache cache.Interface[string, *email.CodeData],
 	sendCache cache.Interface[string, time.Time],
+	changeCache cache.Interface[string, *email.EmailChangeRequest],
+	resetCache cache.Interface[string, *email.ResetToken],
+	metrics metrics.RecorderInterface,
+	webhooks webhook.DispatcherInterface,
 ) *CodeManager {
 	return &CodeManager{
-		logger:    logger.NewLoggerAdapter(lg, "code-manager"),
-		config:    config,
-		cache:     cache,
-		sendCache: sendCache,
+		logger:      logger.NewLoggerAdapter(lg, "code-manager"),
+		config:      config,
+		cache:       cache,
+		sendCache:   sendCache,
+		changeCache: changeCache,
+		resetCache:  resetCache,
+		metrics:     metrics,
+		webhooks:    webhooks,
 	}
 }
 
 func (c *CodeManager) GenerateEmailCode(target string) (*email.VerifyCodeEmail, time.Duration, error) {
 	target = strings.ToLower(target)
 	if val, ok := c.sendCache.Get(target); ok {
+		c.metrics.CodeRejected(metrics.RejectCooldown)
 		return nil, val.Add(c.config.VerifyIntervalDuration).Sub(time.Now()), email.ErrEmailCodeCooldown
 	}
 	code := randstr.String(6)
@@ -56,16 +78,20 @@ func (c *CodeManager) GenerateEmailCode(target string) (*email.VerifyCodeEmail,
 
 func (c *CodeManager) VerifyEmailCode(target string, code string) error {
 	target = strings.ToLower(target)
-	c.logger.Infof("verifying email code for %s and code %s", target, code)
+	c.logger.Infof("verifying email code for %s", target)
 	val, ok := c.cache.Get(target)
 	if !ok {
 		c.logger.Warnf("email code for %s expired", target)
+		c.metrics.CodeVerified(metrics.VerifyExpired)
 		return email.ErrEmailCodeExpired
 	}
-	if val.Code != code {
+	if subtle.ConstantTimeCompare([]byte(val.Code), []byte(code)) != 1 {
 		c.logger.Warnf("email code for %s invalid", target)
+		c.metrics.CodeVerified(metrics.VerifyInvalid)
 		return email.ErrEmailCodeInvalid
 	}
+	c.metrics.CodeVerified(metrics.VerifySuccess)
+	c.webhooks.Dispatch(config.WebhookEventCodeVerified, &webhook.CodeEvent{Email: target})
 	return nil
 }
 
@@ -73,3 +99,157 @@ func (c *CodeManager) RemoveEmailCode(target string) {
 	c.cache.Del(target)
 	c.sendCache.Del(target)
 }
+
+func changeCidKey(cid string) string { return "cid:" + cid }
+
+func changeTokenKey(token string) string { return "token:" + token }
+
+func (c *CodeManager) StartEmailChange(cid string, oldEmail string, newEmail string) (*email.EmailChangeRequest, time.Duration, error) {
+	newEmail = strings.ToLower(newEmail)
+	if val, ok := c.sendCache.Get(newEmail); ok {
+		c.metrics.CodeRejected(metrics.RejectCooldown)
+		return nil, val.Add(c.config.VerifyIntervalDuration).Sub(time.Now()), email.ErrEmailCodeCooldown
+	}
+	request := &email.EmailChangeRequest{
+		Cid:         cid,
+		OldEmail:    strings.ToLower(oldEmail),
+		NewEmail:    newEmail,
+		Code:        randstr.String(6),
+		CancelToken: randstr.Hex(32),
+		ExpireAt:    time.Now().Add(c.config.EmailChange.CodeExpireDuration),
+	}
+
+	c.changeLock.Lock()
+	defer c.changeLock.Unlock()
+	// 已确认的旧请求仍保留令牌索引, 旧邮箱在撤销窗口内依然可以撤销
+	if old, ok := c.changeCache.Get(changeCidKey(cid)); ok && !old.Confirmed {
+		c.changeCache.Del(changeTokenKey(old.CancelToken))
+	}
+	c.changeCache.Set(changeCidKey(cid), request, request.ExpireAt)
+	c.changeCache.Set(changeTokenKey(request.CancelToken), request, request.ExpireAt)
+	c.sendCache.SetWithTTL(newEmail, time.Now(), c.config.VerifyIntervalDuration)
+	c.logger.Infof("email change of %s started, %s -> %s", cid, request.OldEmail, request.NewEmail)
+	result := *request
+	return &result, time.Duration(0), nil
+}
+
+func (c *CodeManager) ConfirmEmailChange(cid string, code string) (*email.EmailChangeRequest, error) {
+	c.changeLock.Lock()
+	defer c.changeLock.Unlock()
+	request, ok := c.changeCache.Get(changeCidKey(cid))
+	if !ok || request.Confirmed {
+		c.metrics.CodeVerified(metrics.VerifyExpired)
+		return nil, email.ErrEmailChangeNotFound
+	}
+	if subtle.ConstantTimeCompare([]byte(request.Code), []byte(code)) != 1 {
+		request.Attempts++
+		c.logger.Warnf("email change code for %s invalid, attempt %d", cid, request.Attempts)
+		c.metrics.CodeVerified(metrics.VerifyInvalid)
+		if request.Attempts >= c.config.EmailChange.MaxAttempts {
+			c.changeCache.Del(changeCidKey(cid))
+			c.changeCache.Del(changeTokenKey(request.CancelToken))
+			return nil, email.ErrEmailChangeTooManyTries
+		}
+		return nil, email.ErrEmailCodeInvalid
+	}
+
+	request.Confirmed = true
+	request.RevertDeadline = time.Now().Add(c.config.EmailChange.RevertWindowDuration)
+	c.changeCache.Del(changeCidKey(cid))
+	c.changeCache.Set(changeTokenKey(request.CancelToken), request, request.RevertDeadline)
+	c.metrics.CodeVerified(metrics.VerifySuccess)
+	c.logger.Infof("email change of %s confirmed, %s -> %s", cid, request.OldEmail, request.NewEmail)
+	result := *request
+	return &result, nil
+}
+
+func (c *CodeManager) CancelEmailChange(token string) (*email.EmailChangeRequest, error) {
+	c.changeLock.Lock()
+	defer c.changeLock.Unlock()
+	request, ok := c.changeCache.Get(changeTokenKey(token))
+	if !ok {
+		return nil, email.ErrEmailChangeNotFound
+	}
+	if request.Confirmed && time.Now().After(request.RevertDeadline) {
+		return nil, email.ErrEmailChangeRevertExpired
+	}
+	c.changeCache.Del(changeTokenKey(token))
+	if current, ok := c.changeCache.Get(changeCidKey(request.Cid)); ok && current == request {
+		c.changeCache.Del(changeCidKey(request.Cid))
+	}
+	c.logger.Infof("email change of %s cancelled by old address, confirmed: %t", request.Cid, request.Confirmed)
+	result := *request
+	return &result, nil
+}
+
+func (c *CodeManager) IssueResetToken(cid string, target string) (string, *email.ResetToken, time.Duration, error) {
+	target = strings.ToLower(target)
+	cooldownKey := "reset:" + cid
+	if val, ok := c.sendCache.Get(cooldownKey); ok {
+		c.metrics.CodeRejected(metrics.RejectCooldown)
+		return "", nil, val.Add(c.config.VerifyIntervalDuration).Sub(time.Now()), email.ErrEmailCodeCooldown
+	}
+	token := randstr.Hex(32)
+	resetToken := &email.ResetToken{
+		Cid:       cid,
+		Email:     target,
+		TokenHash: sha256.Sum256([]byte(token)),
+		ExpireAt:  time.Now().Add(c.config.PasswordReset.TokenExpireDuration),
+	}
+	c.resetLock.Lock()
+	c.resetCache.Set(cid, resetToken, resetToken.ExpireAt)
+	c.resetLock.Unlock()
+	c.sendCache.SetWithTTL(cooldownKey, time.Now(), c.config.VerifyIntervalDuration)
+	c.logger.Infof("password reset token issued for %s", cid)
+	result := *resetToken
+	return token, &result, time.Duration(0), nil
+}
+
+// checkResetToken 校验令牌与绑定的邮箱, 失败次数达到上限后令牌作废, 调用方需持有resetLock
+func (c *CodeManager) checkResetToken(cid string, target string, token string) (*email.ResetToken, error) {
+	resetToken, ok := c.resetCache.Get(cid)
+	if !ok {
+		c.metrics.CodeVerified(metrics.VerifyExpired)
+		return nil, email.ErrResetTokenNotFound
+	}
+	hash := sha256.Sum256([]byte(token))
+	if subtle.ConstantTimeCompare(hash[:], resetToken.TokenHash[:]) != 1 || strings.ToLower(target) != resetToken.Email {
+		resetToken.Attempts++
+		c.logger.Warnf("password reset token for %s invalid, attempt %d", cid, resetToken.Attempts)
+		c.metrics.CodeVerified(metrics.VerifyInvalid)
+		if resetToken.Attempts >= c.config.PasswordReset.MaxAttempts {
+			c.resetCache.Del(cid)
+			return nil, email.ErrResetTokenLocked
+		}
+		return nil, email.ErrResetTokenInvalid
+	}
+	c.metrics.CodeVerified(metrics.VerifySuccess)
+	result := *resetToken
+	return &result, nil
+}
+
+func (c *CodeManager) ValidateResetToken(cid string, target string, token string) (*email.ResetToken, error) {
+	c.resetLock.Lock()
+	defer c.resetLock.Unlock()
+	return c.checkResetToken(cid, target, token)
+}
+
+func (c *CodeManager) ConsumeResetToken(cid string, target string, token string) (*email.ResetToken, error) {
+	c.resetLock.Lock()
+	defer c.resetLock.Unlock()
+	resetToken, err := c.checkResetToken(cid, target, token)
+	if err != nil {
+		return nil, err
+	}
+	c.resetCache.Del(cid)
+	c.logger.Infof("password reset token for %s consumed", cid)
+	return resetToken, nil
+}
+
+func (c *CodeManager) RevokeResetToken(cid string) {
+	c.resetLock.Lock()
+	c.resetCache.Del(cid)
+	c.resetLock.Unlock()
+	c.sendCache.Del("reset:" + cid)
+	c.logger.Infof("password reset token for %s revoked", cid)
+}
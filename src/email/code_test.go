@@ -0,0 +1,55 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/email"
+	"errors"
+	"testing"
+	"time"
+
+	"half-nothing.cn/service-core/cache"
+)
+
+func newTestCodeManager() *CodeManager {
+	c := &config.EmailConfig{
+		VerifyIntervalDuration: time.Minute,
+		PasswordReset:          &config.PasswordResetConfig{MaxAttempts: 3, TokenExpireDuration: time.Hour},
+	}
+	return NewCodeManager(nopLogger{}, c,
+		cache.NewMemoryCache[string, *email.CodeData](time.Minute),
+		cache.NewMemoryCache[string, time.Time](time.Minute),
+		cache.NewMemoryCache[string, *email.EmailChangeRequest](time.Minute),
+		cache.NewMemoryCache[string, *email.ResetToken](time.Minute),
+		nopMetrics{}, nil)
+}
+
+func TestResetTokenBoundToEmail(t *testing.T) {
+	manager := newTestCodeManager()
+	token, _, _, err := manager.IssueResetToken("2352", "Pilot@Example.com")
+	if err != nil {
+		t.Fatalf("issue: %v", err)
+	}
+
+	// 令牌正确但邮箱不一致时视为无效令牌, 并计入失败次数
+	if _, err := manager.ValidateResetToken("2352", "other@example.com", token); !errors.Is(err, email.ErrResetTokenInvalid) {
+		t.Fatalf("validate with other email = %v, want %v", err, email.ErrResetTokenInvalid)
+	}
+	if _, err := manager.ConsumeResetToken("2352", "other@example.com", token); !errors.Is(err, email.ErrResetTokenInvalid) {
+		t.Fatalf("consume with other email = %v, want %v", err, email.ErrResetTokenInvalid)
+	}
+
+	resetToken, err := manager.ConsumeResetToken("2352", "pilot@example.com", token)
+	if err != nil {
+		t.Fatalf("consume: %v", err)
+	}
+	if resetToken.Email != "pilot@example.com" {
+		t.Errorf("email = %s, want pilot@example.com", resetToken.Email)
+	}
+	if _, err := manager.ValidateResetToken("2352", "pilot@example.com", token); !errors.Is(err, email.ErrResetTokenNotFound) {
+		t.Errorf("validate consumed token = %v, want %v", err, email.ErrResetTokenNotFound)
+	}
+}
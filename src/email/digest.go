@@ -0,0 +1,94 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/email"
+	"sync"
+	"time"
+
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+type digestBucket struct {
+	items []*email.DigestItem
+	timer *time.Timer
+}
+
+// Digest 按收件地址暂存低优先级通知, 窗口结束或条数达到上限时合并发送
+type Digest struct {
+	logger  logger.Interface
+	config  *config.DigestConfig
+	flush   func(address string, items []*email.DigestItem)
+	lock    sync.Mutex
+	buckets map[string]*digestBucket
+}
+
+func NewDigest(
+	lg logger.Interface,
+	config *config.DigestConfig,
+	flush func(address string, items []*email.DigestItem),
+) *Digest {
+	return &Digest{
+		logger:  logger.NewLoggerAdapter(lg, "email-digest"),
+		config:  config,
+		flush:   flush,
+		buckets: make(map[string]*digestBucket),
+	}
+}
+
+func (d *Digest) Add(address string, item *email.DigestItem) {
+	d.lock.Lock()
+	bucket, ok := d.buckets[address]
+	if !ok {
+		bucket = &digestBucket{}
+		bucket.timer = time.AfterFunc(d.config.WindowDuration, func() { d.flushAddress(address, bucket) })
+		d.buckets[address] = bucket
+	}
+	bucket.items = append(bucket.items, item)
+	full := len(bucket.items) >= d.config.MaxItems
+	d.lock.Unlock()
+	if full {
+		bucket.timer.Stop()
+		go d.flushAddress(address, bucket)
+	}
+}
+
+// flushAddress 发送指定地址的汇总, bucket已被替换或已发送时直接返回
+func (d *Digest) flushAddress(address string, bucket *digestBucket) {
+	d.lock.Lock()
+	if d.buckets[address] != bucket {
+		d.lock.Unlock()
+		return
+	}
+	delete(d.buckets, address)
+	d.lock.Unlock()
+	d.logger.Debugf("flushing %d digest items to %s", len(bucket.items), address)
+	d.flush(address, bucket.items)
+}
+
+// Flush 立即发送所有暂存的通知, 用于服务关闭
+func (d *Digest) Flush() {
+	d.lock.Lock()
+	buckets := d.buckets
+	d.buckets = make(map[string]*digestBucket)
+	d.lock.Unlock()
+	for address, bucket := range buckets {
+		bucket.timer.Stop()
+		d.flush(address, bucket.items)
+	}
+}
+
+// Size 返回暂存的通知数量
+func (d *Digest) Size() int {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	size := 0
+	for _, bucket := range d.buckets {
+		size += len(bucket.items)
+	}
+	return size
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/email"
+	"email-service/src/interfaces/webhook"
+	"sync"
+	"testing"
+)
+
+type dispatchedEvent struct {
+	event string
+	data  *webhook.EmailEvent
+}
+
+type recordDispatcher struct {
+	lock   sync.Mutex
+	events []*dispatchedEvent
+}
+
+func (r *recordDispatcher) Dispatch(event string, data any) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.events = append(r.events, &dispatchedEvent{event: event, data: data.(*webhook.EmailEvent)})
+}
+
+func TestSendDigestDispatchesItemEvents(t *testing.T) {
+	// 汇总邮件发送失败时, 每条暂存的通知都推送失败事件
+	config.EmailDigest.Data.SetEnable(false)
+	defer config.EmailDigest.Data.SetEnable(true)
+	dispatcher := &recordDispatcher{}
+	sender := &Sender{logger: nopLogger{}, metrics: nopMetrics{}, webhooks: dispatcher}
+
+	sender.sendDigest("user@example.com", []*email.DigestItem{
+		{EmailType: config.EmailTicketReply.Value, ThreadId: "ticket-1", AsyncId: "async-1"},
+		{EmailType: config.EmailBanned.Value},
+	})
+
+	if len(dispatcher.events) != 2 {
+		t.Fatalf("got %d events, want 2", len(dispatcher.events))
+	}
+	for i, want := range []string{config.EmailTicketReply.Value, config.EmailBanned.Value} {
+		got := dispatcher.events[i]
+		if got.event != config.WebhookEventFailed || got.data.EmailType != want {
+			t.Errorf("event %d = %s %s, want %s %s", i, got.event, got.data.EmailType, config.WebhookEventFailed, want)
+		}
+		if len(got.data.To) != 1 || got.data.To[0] != "user@example.com" || got.data.Error == "" {
+			t.Errorf("event %d = %+v, want failed delivery to user@example.com", i, got.data)
+		}
+	}
+	if first := dispatcher.events[0].data; first.ThreadId != "ticket-1" || first.AsyncId != "async-1" {
+		t.Errorf("event = %+v, want thread id ticket-1 and async id async-1", first)
+	}
+}
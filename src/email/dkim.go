@@ -0,0 +1,67 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"bytes"
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/email"
+	"fmt"
+	"io"
+
+	"github.com/emersion/go-msgauth/dkim"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// DkimTransport 在交给下层发送通道之前为邮件添加DKIM签名
+type DkimTransport struct {
+	logger    logger.Interface
+	options   *dkim.SignOptions
+	transport email.TransportInterface
+}
+
+func NewDkimTransport(
+	lg logger.Interface,
+	c *config.DkimConfig,
+	transport email.TransportInterface,
+) *DkimTransport {
+	var canonicalization dkim.Canonicalization = dkim.CanonicalizationRelaxed
+	if c.Canonicalization == config.DkimCanonicalizationSimple {
+		canonicalization = dkim.CanonicalizationSimple
+	}
+	return &DkimTransport{
+		logger: logger.NewLoggerAdapter(lg, "dkim"),
+		options: &dkim.SignOptions{
+			Domain:                 c.Domain,
+			Selector:               c.Selector,
+			Signer:                 c.Signer,
+			HeaderCanonicalization: canonicalization,
+			BodyCanonicalization:   canonicalization,
+			HeaderKeys:             c.Headers,
+		},
+		transport: transport,
+	}
+}
+
+func (t *DkimTransport) Send(from string, to []string, msg io.WriterTo) error {
+	raw := &bytes.Buffer{}
+	if _, err := msg.WriteTo(raw); err != nil {
+		return err
+	}
+	signed := &bytes.Buffer{}
+	if err := dkim.Sign(signed, raw, t.options); err != nil {
+		return fmt.Errorf("fail to sign email with dkim, %v", err)
+	}
+	return t.transport.Send(from, to, signed)
+}
+
+func (t *DkimTransport) Close() error { return t.transport.Close() }
+
+func (t *DkimTransport) CheckConnection() error {
+	if checker, ok := t.transport.(email.ConnectionCheckerInterface); ok {
+		return checker.CheckConnection()
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"email-service/src/interfaces/config"
+	"encoding/base64"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/emersion/go-msgauth/dkim"
+	"gopkg.in/gomail.v2"
+)
+
+const (
+	dkimDomain   = "example.com"
+	dkimSelector = "mail"
+)
+
+func dkimKeys(t *testing.T) map[string]struct {
+	signer crypto.Signer
+	record string
+} {
+	t.Helper()
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate ed25519 key: %v", err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate rsa key: %v", err)
+	}
+	rsaPublic, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	if err != nil {
+		t.Fatalf("marshal rsa public key: %v", err)
+	}
+	return map[string]struct {
+		signer crypto.Signer
+		record string
+	}{
+		"ed25519": {edKey, "v=DKIM1; k=ed25519; p=" + base64.StdEncoding.EncodeToString(edKey.Public().(ed25519.PublicKey))},
+		"rsa":     {rsaKey, "v=DKIM1; k=rsa; p=" + base64.StdEncoding.EncodeToString(rsaPublic)},
+	}
+}
+
+func dkimMessage(bcc ...string) *gomail.Message {
+	m := gomail.NewMessage()
+	m.SetHeader("From", "noreply@example.com")
+	m.SetHeader("To", "pilot@example.net")
+	m.SetHeader("Cc", "atc@example.net")
+	if len(bcc) > 0 {
+		m.SetHeader("Bcc", bcc...)
+	}
+	m.SetHeader("Subject", "管制员申请通过")
+	m.SetBody("text/html", "<p>恭喜, 您的申请已通过</p>")
+	m.AddAlternative("text/plain", "恭喜, 您的申请已通过")
+	return m
+}
+
+func verifyDkim(t *testing.T, raw []byte, record string) {
+	t.Helper()
+	verifications, err := dkim.VerifyWithOptions(bytes.NewReader(raw), &dkim.VerifyOptions{
+		LookupTXT: func(domain string) ([]string, error) {
+			if domain != dkimSelector+"._domainkey."+dkimDomain {
+				t.Errorf("lookup unexpected domain %s", domain)
+			}
+			return []string{record}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if len(verifications) != 1 {
+		t.Fatalf("got %d signatures, want 1", len(verifications))
+	}
+	if verifications[0].Err != nil {
+		t.Fatalf("signature invalid: %v", verifications[0].Err)
+	}
+	if verifications[0].Domain != dkimDomain {
+		t.Errorf("signature domain = %s, want %s", verifications[0].Domain, dkimDomain)
+	}
+}
+
+func TestDkimTransportSignsVerifiableMessage(t *testing.T) {
+	for name, key := range dkimKeys(t) {
+		for _, canonicalization := range []string{config.DkimCanonicalizationRelaxed, config.DkimCanonicalizationSimple} {
+			t.Run(name+"/"+canonicalization, func(t *testing.T) {
+				inner := &recordTransport{}
+				transport := NewDkimTransport(nopLogger{}, &config.DkimConfig{
+					Domain:           dkimDomain,
+					Selector:         dkimSelector,
+					Signer:           key.signer,
+					Canonicalization: canonicalization,
+					Headers:          []string{"From", "To", "Cc", "Subject", "Date", "Message-ID"},
+				}, inner)
+				if err := gomail.Send(transport, dkimMessage()); err != nil {
+					t.Fatalf("send: %v", err)
+				}
+				if len(inner.delivered) != 1 {
+					t.Fatalf("delivered %d emails, want 1", len(inner.delivered))
+				}
+				verifyDkim(t, inner.delivered[0].raw, key.record)
+			})
+		}
+	}
+}
+
+func TestDkimTransportSignsBccMessage(t *testing.T) {
+	key := dkimKeys(t)["ed25519"]
+	inner := &recordTransport{}
+	transport := NewDkimTransport(nopLogger{}, &config.DkimConfig{
+		Domain:           dkimDomain,
+		Selector:         dkimSelector,
+		Signer:           key.signer,
+		Canonicalization: config.DkimCanonicalizationRelaxed,
+		Headers:          []string{"From", "To", "Cc", "Subject"},
+	}, inner)
+	if err := gomail.Send(transport, dkimMessage("auditor@example.org")); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	delivered := inner.delivered[0]
+	if !slices.Contains(delivered.to, "auditor@example.org") {
+		t.Errorf("envelope recipients %v missing bcc", delivered.to)
+	}
+	// 密送地址只出现在信封中, 不能出现在签名后的邮件里
+	if strings.Contains(string(delivered.raw), "auditor@example.org") {
+		t.Errorf("signed message leaks bcc address")
+	}
+	verifyDkim(t, delivered.raw, key.record)
+}
+
+func TestDkimTransportDetectsTampering(t *testing.T) {
+	key := dkimKeys(t)["ed25519"]
+	inner := &recordTransport{}
+	transport := NewDkimTransport(nopLogger{}, &config.DkimConfig{
+		Domain:           dkimDomain,
+		Selector:         dkimSelector,
+		Signer:           key.signer,
+		Canonicalization: config.DkimCanonicalizationRelaxed,
+		Headers:          []string{"From", "Subject"},
+	}, inner)
+	if err := gomail.Send(transport, dkimMessage()); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	tampered := bytes.Replace(inner.delivered[0].raw, []byte("noreply@example.com"), []byte("admin@example.com"), 1)
+	verifications, err := dkim.VerifyWithOptions(bytes.NewReader(tampered), &dkim.VerifyOptions{
+		LookupTXT: func(string) ([]string, error) { return []string{key.record}, nil },
+	})
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if len(verifications) != 1 || verifications[0].Err == nil {
+		t.Errorf("tampered message verified")
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"bytes"
+	"email-service/src/interfaces/metrics"
+	"io"
+	"sync"
+	"time"
+
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+type nopLogger struct{ logger.Interface }
+
+func (nopLogger) Debug(string)          {}
+func (nopLogger) Info(string)           {}
+func (nopLogger) Warn(string)           {}
+func (nopLogger) Error(string)          {}
+func (nopLogger) Fatal(string)          {}
+func (nopLogger) Debugf(string, ...any) {}
+func (nopLogger) Infof(string, ...any)  {}
+func (nopLogger) Warnf(string, ...any)  {}
+func (nopLogger) Errorf(string, ...any) {}
+func (nopLogger) Fatalf(string, ...any) {}
+
+type nopMetrics struct{ metrics.RecorderInterface }
+
+func (nopMetrics) EmailSent(string, string)             {}
+func (nopMetrics) RenderDuration(string, time.Duration) {}
+func (nopMetrics) SmtpLatency(time.Duration, bool)      {}
+func (nopMetrics) LaneWait(string, time.Duration)       {}
+func (nopMetrics) RegisterSize(string, func() int)      {}
+func (nopMetrics) CodeRejected(string)                  {}
+func (nopMetrics) CodeVerified(string)                  {}
+
+type deliveredEmail struct {
+	from string
+	to   []string
+	raw  []byte
+}
+
+// recordTransport 记录投递的邮件, errs中的错误按顺序返回给每次发送
+type recordTransport struct {
+	lock      sync.Mutex
+	delivered []*deliveredEmail
+	errs      []error
+	closed    bool
+}
+
+func (r *recordTransport) Send(from string, to []string, msg io.WriterTo) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if len(r.errs) > 0 {
+		err := r.errs[0]
+		r.errs = r.errs[1:]
+		if err != nil {
+			return err
+		}
+	}
+	raw := &bytes.Buffer{}
+	if _, err := msg.WriteTo(raw); err != nil {
+		return err
+	}
+	r.delivered = append(r.delivered, &deliveredEmail{from: from, to: to, raw: raw.Bytes()})
+	return nil
+}
+
+func (r *recordTransport) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.closed = true
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"context"
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/metrics"
+	"sync/atomic"
+	"time"
+)
+
+type lane struct {
+	slots   chan struct{}
+	waiting atomic.Int64
+}
+
+// Lanes 按优先级划分的发送通道, 每条通道独立限制并发, 大批量邮件不会占满验证码等关键邮件的发送位置
+type Lanes struct {
+	lanes   map[string]*lane
+	metrics metrics.RecorderInterface
+}
+
+func NewLanes(c *config.PriorityConfig, recorder metrics.RecorderInterface) *Lanes {
+	lanes := &Lanes{
+		lanes:   make(map[string]*lane),
+		metrics: recorder,
+	}
+	for _, priority := range config.Priorities {
+		l := &lane{slots: make(chan struct{}, c.Concurrency(priority))}
+		lanes.lanes[priority] = l
+		recorder.RegisterSize("lane_"+priority+"_waiting", func() int { return int(l.waiting.Load()) })
+		recorder.RegisterSize("lane_"+priority+"_active", func() int { return len(l.slots) })
+	}
+	return lanes
+}
+
+// Acquire 等待指定优先级通道的空闲位置, 返回释放位置的函数, 未知优先级按normal处理
+// ctx结束前仍未等到空闲位置时返回ctx的错误
+func (l *Lanes) Acquire(ctx context.Context, priority string) (func(), error) {
+	target, ok := l.lanes[priority]
+	if !ok {
+		priority = config.PriorityNormal
+		target = l.lanes[priority]
+	}
+	start := time.Now()
+	target.waiting.Add(1)
+	defer target.waiting.Add(-1)
+	select {
+	case target.slots <- struct{}{}:
+		l.metrics.LaneWait(priority, time.Since(start))
+		return func() { <-target.slots }, nil
+	case <-ctx.Done():
+		l.metrics.LaneWait(priority, time.Since(start))
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"context"
+	"email-service/src/interfaces/config"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLanesAcquireTimeout(t *testing.T) {
+	lanes := NewLanes(&config.PriorityConfig{Critical: 1, Normal: 1, Bulk: 1}, nopMetrics{})
+	release, err := lanes.Acquire(context.Background(), config.PriorityBulk)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+
+	// 批量通道已满时等待超时, 其他通道不受影响
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := lanes.Acquire(ctx, config.PriorityBulk); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("acquire full lane error = %v, want deadline exceeded", err)
+	}
+	criticalRelease, err := lanes.Acquire(context.Background(), config.PriorityCritical)
+	if err != nil {
+		t.Fatalf("acquire critical: %v", err)
+	}
+	criticalRelease()
+
+	release()
+	release, err = lanes.Acquire(context.Background(), config.PriorityBulk)
+	if err != nil {
+		t.Fatalf("acquire after release: %v", err)
+	}
+	release()
+}
+
+func TestSenderUsesCriticalTransport(t *testing.T) {
+	shared, critical := &recordTransport{}, &recordTransport{}
+	sender := &Sender{transport: shared, criticalTransport: critical}
+	if sender.transportOf(config.PriorityCritical) != critical {
+		t.Error("critical email does not use the dedicated transport")
+	}
+	for _, priority := range []string{config.PriorityNormal, config.PriorityBulk} {
+		if sender.transportOf(priority) != shared {
+			t.Errorf("%s email does not use the shared transport", priority)
+		}
+	}
+	sender.criticalTransport = nil
+	if sender.transportOf(config.PriorityCritical) != shared {
+		t.Error("critical email without dedicated transport does not use the shared transport")
+	}
+}
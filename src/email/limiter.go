@@ -0,0 +1,112 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/email"
+	"email-service/src/interfaces/metrics"
+	"sync"
+	"time"
+
+	"half-nothing.cn/service-core/interfaces/cache"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+const (
+	limitKeyGlobal      = "global"
+	limitKeyIp          = "ip:"
+	limitKeyFingerprint = "fp:"
+)
+
+type CodeLimiter struct {
+	logger  logger.Interface
+	config  *config.CodeLimitConfig
+	lock    sync.Mutex
+	cache   cache.Interface[string, int]
+	metrics metrics.RecorderInterface
+}
+
+func NewCodeLimiter(
+	lg logger.Interface,
+	config *config.CodeLimitConfig,
+	cache cache.Interface[string, int],
+	metrics metrics.RecorderInterface,
+) *CodeLimiter {
+	return &CodeLimiter{
+		logger:  logger.NewLoggerAdapter(lg, "code-limiter"),
+		config:  config,
+		cache:   cache,
+		metrics: metrics,
+	}
+}
+
+type limitRule struct {
+	key    string
+	limit  int
+	err    error
+	reason string
+}
+
+func (l *CodeLimiter) rules(ip string, fingerprint string) []*limitRule {
+	rules := make([]*limitRule, 0, 3)
+	if l.config.GlobalHourlyLimit > 0 {
+		rules = append(rules, &limitRule{
+			key:    limitKeyGlobal,
+			limit:  l.config.GlobalHourlyLimit,
+			err:    email.ErrCodeGlobalLimited,
+			reason: metrics.RejectGlobalQuota,
+		})
+	}
+	// 即使未设置IP配额也记录IP请求次数, 用于判断是否需要验证码
+	if ip != "" {
+		rules = append(rules, &limitRule{
+			key:    limitKeyIp + ip,
+			limit:  l.config.IpHourlyLimit,
+			err:    email.ErrCodeIpLimited,
+			reason: metrics.RejectIpQuota,
+		})
+	}
+	if l.config.FingerprintHourlyLimit > 0 && fingerprint != "" {
+		rules = append(rules, &limitRule{
+			key:    limitKeyFingerprint + fingerprint,
+			limit:  l.config.FingerprintHourlyLimit,
+			err:    email.ErrCodeFingerprintLimited,
+			reason: metrics.RejectFingerprintQuota,
+		})
+	}
+	return rules
+}
+
+func (l *CodeLimiter) Acquire(ip string, fingerprint string) (time.Duration, error) {
+	now := time.Now()
+	resetAt := now.Truncate(time.Hour).Add(time.Hour)
+	rules := l.rules(ip, fingerprint)
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	counts := make([]int, len(rules))
+	for i, rule := range rules {
+		count, _ := l.cache.Get(rule.key)
+		if rule.limit > 0 && count >= rule.limit {
+			l.logger.Warnf("email code quota %s exceeded, ip: %s, fingerprint: %s", rule.key, ip, fingerprint)
+			l.metrics.CodeRejected(rule.reason)
+			return resetAt.Sub(now), rule.err
+		}
+		counts[i] = count
+	}
+	for i, rule := range rules {
+		l.cache.Set(rule.key, counts[i]+1, resetAt)
+	}
+	return time.Duration(0), nil
+}
+
+func (l *CodeLimiter) IpUsage(ip string) int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	count, _ := l.cache.Get(limitKeyIp + ip)
+	return count
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/render"
+	"reflect"
+)
+
+// templateData 将配置为markdown的字段渲染为清理后的HTML, 其余字段原样传给模板
+// 未配置markdown字段时直接返回原参数
+func templateData(emailType config.Email, data interface{}) interface{} {
+	if len(emailType.Data.Markdown) == 0 {
+		return data
+	}
+	value := reflect.Indirect(reflect.ValueOf(data))
+	if value.Kind() != reflect.Struct {
+		return data
+	}
+	fields := make(map[string]interface{}, value.NumField())
+	for i := 0; i < value.NumField(); i++ {
+		if field := value.Type().Field(i); field.IsExported() {
+			fields[field.Name] = value.Field(i).Interface()
+		}
+	}
+	for _, name := range emailType.Data.Markdown {
+		if text, ok := fields[name].(string); ok {
+			fields[name] = render.Markdown(text)
+		}
+	}
+	return fields
+}
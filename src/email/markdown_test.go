@@ -0,0 +1,74 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"bytes"
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/email"
+	"html/template"
+	"strings"
+	"testing"
+
+	"half-nothing.cn/service-core/utils"
+)
+
+func renderTicketReply(t *testing.T, markdown []string, data *email.TicketReplyEmail) string {
+	t.Helper()
+	emailType := utils.NewEnum("ticket_reply_test", &config.EmailData{Markdown: markdown})
+	tmpl := template.Must(template.New("ticket").Parse(`<h1>{{.Title}}</h1><div>{{.Reply}}</div>`))
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, templateData(emailType, data)); err != nil {
+		t.Fatalf("execute template: %v", err)
+	}
+	return buf.String()
+}
+
+func TestTemplateDataSanitizesMarkdownFields(t *testing.T) {
+	tests := []struct {
+		name      string
+		reply     string
+		want      string
+		forbidden []string
+	}{
+		{"formatting", "**已处理**", "<strong>已处理</strong>", nil},
+		{"script", "<script>alert(1)</script>", "", []string{"<script", "alert(1)"}},
+		{"javascript link", "[详情](javascript:alert(1))", "详情", []string{"javascript:"}},
+		{"onerror", `<img src=x onerror=alert(1)>`, "", []string{"onerror"}},
+		{"raw html block", "<div onclick=\"steal()\">\n\n内容\n\n</div>", "内容", []string{"onclick"}},
+		{"autolink", "<https://fsd.example.com>", `<a href="https://fsd.example.com" rel="nofollow">`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := renderTicketReply(t, []string{"Reply"}, &email.TicketReplyEmail{Title: "工单", Reply: tt.reply})
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("rendered %q, want to contain %q", got, tt.want)
+			}
+			for _, s := range tt.forbidden {
+				if strings.Contains(got, s) {
+					t.Errorf("rendered %q, contains %q", got, s)
+				}
+			}
+		})
+	}
+}
+
+func TestTemplateDataEscapesOtherFields(t *testing.T) {
+	data := &email.TicketReplyEmail{Title: "<b>标题</b>", Reply: "**回复**"}
+
+	got := renderTicketReply(t, []string{"Reply"}, data)
+	if !strings.Contains(got, "&lt;b&gt;标题&lt;/b&gt;") {
+		t.Errorf("title not escaped: %q", got)
+	}
+	if data.Reply != "**回复**" {
+		t.Errorf("caller data modified: %q", data.Reply)
+	}
+
+	// 未配置markdown字段时按普通文本转义
+	got = renderTicketReply(t, nil, &email.TicketReplyEmail{Title: "工单", Reply: "<script>alert(1)</script>"})
+	if !strings.Contains(got, "&lt;script&gt;") {
+		t.Errorf("reply not escaped: %q", got)
+	}
+}
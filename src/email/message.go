@@ -0,0 +1,95 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"bytes"
+	"email-service/src/interfaces/email"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+)
+
+// parseMessage 解析完整的MIME邮件, 提取解码后的邮件头与正文
+func parseMessage(raw []byte) (*email.CapturedEmail, error) {
+	message, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	decoder := &mime.WordDecoder{}
+	captured := &email.CapturedEmail{
+		Headers: make(map[string][]string, len(message.Header)),
+		Raw:     string(raw),
+	}
+	for key, values := range message.Header {
+		decoded := make([]string, 0, len(values))
+		for _, value := range values {
+			if header, err := decoder.DecodeHeader(value); err == nil {
+				value = header
+			}
+			decoded = append(decoded, value)
+		}
+		captured.Headers[key] = decoded
+	}
+	if subject, ok := captured.Headers["Subject"]; ok && len(subject) > 0 {
+		captured.Subject = subject[0]
+	}
+	if err := readPart(captured, message.Header, message.Body); err != nil {
+		return nil, err
+	}
+	return captured, nil
+}
+
+// readPart 递归读取邮件正文, 提取第一个text/html与text/plain部分
+func readPart(captured *email.CapturedEmail, header map[string][]string, body io.Reader) error {
+	mediaType, params, err := mime.ParseMediaType(firstHeader(header, "Content-Type"))
+	if err != nil {
+		mediaType = "text/plain"
+	}
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := readPart(captured, part.Header, part); err != nil {
+				return err
+			}
+		}
+	}
+
+	switch strings.ToLower(firstHeader(header, "Content-Transfer-Encoding")) {
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	}
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	switch {
+	case mediaType == "text/html" && captured.Html == "":
+		captured.Html = string(content)
+	case mediaType == "text/plain" && captured.Text == "":
+		captured.Text = string(content)
+	}
+	return nil
+}
+
+func firstHeader(header map[string][]string, key string) string {
+	if values := header[key]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
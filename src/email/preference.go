@@ -0,0 +1,146 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/email"
+	"slices"
+	"sync"
+	"time"
+)
+
+// MemoryPreference 内存中的用户通知偏好
+type MemoryPreference struct {
+	config      *config.PreferenceConfig
+	lock        sync.RWMutex
+	preferences map[string]*email.Preference
+}
+
+func NewMemoryPreference(config *config.PreferenceConfig) *MemoryPreference {
+	return &MemoryPreference{
+		config:      config,
+		preferences: make(map[string]*email.Preference),
+	}
+}
+
+func (m *MemoryPreference) Get(cid string) *email.Preference {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	preference, ok := m.preferences[cid]
+	if !ok {
+		return &email.Preference{Cid: cid, OptOut: []string{}}
+	}
+	result := *preference
+	result.OptOut = slices.Clone(preference.OptOut)
+	return &result
+}
+
+func (m *MemoryPreference) Set(cid string, categories map[string]bool) (*email.Preference, error) {
+	if err := verifyCategories(m.config, categories); err != nil {
+		return nil, err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	preference, ok := m.preferences[cid]
+	if !ok {
+		preference = &email.Preference{Cid: cid, OptOut: []string{}}
+		m.preferences[cid] = preference
+	}
+	applyCategories(preference, categories)
+	result := *preference
+	result.OptOut = slices.Clone(preference.OptOut)
+	return &result, nil
+}
+
+func (m *MemoryPreference) SetTimezone(cid string, timezone string) (*email.Preference, error) {
+	if err := verifyTimezone(timezone); err != nil {
+		return nil, err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	preference, ok := m.preferences[cid]
+	if !ok {
+		preference = &email.Preference{Cid: cid, OptOut: []string{}}
+		m.preferences[cid] = preference
+	}
+	preference.Timezone = timezone
+	preference.UpdatedAt = time.Now()
+	result := *preference
+	result.OptOut = slices.Clone(preference.OptOut)
+	return &result, nil
+}
+
+func (m *MemoryPreference) IsOptedOut(cid string, emailType config.Email) bool {
+	category, ok := m.config.Category(emailType)
+	if !ok {
+		return false
+	}
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	preference, ok := m.preferences[cid]
+	return ok && slices.Contains(preference.OptOut, category)
+}
+
+func (m *MemoryPreference) Size() int {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return len(m.preferences)
+}
+
+func (m *MemoryPreference) Location(cid string) (*time.Location, bool) {
+	m.lock.RLock()
+	preference, ok := m.preferences[cid]
+	timezone := ""
+	if ok {
+		timezone = preference.Timezone
+	}
+	m.lock.RUnlock()
+	return locationOf(timezone)
+}
+
+func verifyCategories(c *config.PreferenceConfig, categories map[string]bool) error {
+	for category := range categories {
+		if _, ok := c.Categories[category]; !ok {
+			return email.ErrPreferenceCategoryUnknown
+		}
+	}
+	return nil
+}
+
+func verifyTimezone(timezone string) error {
+	if timezone == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return email.ErrPreferenceTimezoneInvalid
+	}
+	return nil
+}
+
+// applyCategories 按categories更新退订的分类, 未出现的分类保持不变
+func applyCategories(preference *email.Preference, categories map[string]bool) {
+	for category, enable := range categories {
+		index := slices.Index(preference.OptOut, category)
+		if enable && index >= 0 {
+			preference.OptOut = slices.Delete(preference.OptOut, index, index+1)
+		} else if !enable && index < 0 {
+			preference.OptOut = append(preference.OptOut, category)
+		}
+	}
+	slices.Sort(preference.OptOut)
+	preference.UpdatedAt = time.Now()
+}
+
+func locationOf(timezone string) (*time.Location, bool) {
+	if timezone == "" {
+		return nil, false
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, false
+	}
+	return location, true
+}
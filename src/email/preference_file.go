@@ -0,0 +1,163 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/email"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thanhpk/randstr"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+const preferenceFileExt = ".json"
+
+// FilePreference 每个用户的通知偏好保存为目录下的一个JSON文件, 重启后不会丢失
+// 读取时总是访问文件, 多个实例挂载同一目录时看到的偏好一致, 同一用户的并发更新以最后写入为准
+type FilePreference struct {
+	logger logger.Interface
+	config *config.PreferenceConfig
+	// 串行化本实例内的读-改-写
+	lock sync.Mutex
+}
+
+func NewFilePreference(
+	lg logger.Interface,
+	c *config.PreferenceConfig,
+) (*FilePreference, error) {
+	if err := os.MkdirAll(c.Path, 0750); err != nil {
+		return nil, fmt.Errorf("fail to create preference directory, %v", err)
+	}
+	return &FilePreference{
+		logger: logger.NewLoggerAdapter(lg, "preference"),
+		config: c,
+	}, nil
+}
+
+// file 对cid做路径转义, 避免cid中的路径分隔符越出偏好目录
+func (f *FilePreference) file(cid string) string {
+	return filepath.Join(f.config.Path, url.PathEscape(cid)+preferenceFileExt)
+}
+
+func (f *FilePreference) load(cid string) (*email.Preference, error) {
+	data, err := os.ReadFile(f.file(cid))
+	if errors.Is(err, os.ErrNotExist) {
+		return &email.Preference{Cid: cid, OptOut: []string{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	preference := &email.Preference{}
+	if err := json.Unmarshal(data, preference); err != nil {
+		return nil, err
+	}
+	if preference.OptOut == nil {
+		preference.OptOut = []string{}
+	}
+	preference.Cid = cid
+	return preference, nil
+}
+
+// save 先写入临时文件再重命名, 其他实例不会读到写了一半的文件
+func (f *FilePreference) save(preference *email.Preference) error {
+	data, err := json.Marshal(preference)
+	if err != nil {
+		return err
+	}
+	file := f.file(preference.Cid)
+	tmp := file + "." + randstr.Hex(8) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0640); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, file); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// get 读取失败时按未设置偏好处理, 不影响邮件发送
+func (f *FilePreference) get(cid string) *email.Preference {
+	preference, err := f.load(cid)
+	if err != nil {
+		f.logger.Errorf("fail to read preference of %s, %v", cid, err)
+		return &email.Preference{Cid: cid, OptOut: []string{}}
+	}
+	return preference
+}
+
+func (f *FilePreference) Get(cid string) *email.Preference {
+	return f.get(cid)
+}
+
+func (f *FilePreference) update(cid string, apply func(preference *email.Preference)) (*email.Preference, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	preference, err := f.load(cid)
+	if err != nil {
+		return nil, fmt.Errorf("fail to read preference of %s, %v", cid, err)
+	}
+	apply(preference)
+	if err := f.save(preference); err != nil {
+		return nil, fmt.Errorf("fail to save preference of %s, %v", cid, err)
+	}
+	return preference, nil
+}
+
+func (f *FilePreference) Set(cid string, categories map[string]bool) (*email.Preference, error) {
+	if err := verifyCategories(f.config, categories); err != nil {
+		return nil, err
+	}
+	return f.update(cid, func(preference *email.Preference) {
+		applyCategories(preference, categories)
+	})
+}
+
+func (f *FilePreference) SetTimezone(cid string, timezone string) (*email.Preference, error) {
+	if err := verifyTimezone(timezone); err != nil {
+		return nil, err
+	}
+	return f.update(cid, func(preference *email.Preference) {
+		preference.Timezone = timezone
+		preference.UpdatedAt = time.Now()
+	})
+}
+
+func (f *FilePreference) IsOptedOut(cid string, emailType config.Email) bool {
+	category, ok := f.config.Category(emailType)
+	if !ok {
+		return false
+	}
+	return slices.Contains(f.get(cid).OptOut, category)
+}
+
+func (f *FilePreference) Location(cid string) (*time.Location, bool) {
+	return locationOf(f.get(cid).Timezone)
+}
+
+// Size 返回保存了偏好的用户数量
+func (f *FilePreference) Size() int {
+	entries, err := os.ReadDir(f.config.Path)
+	if err != nil {
+		return 0
+	}
+	count := 0
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), preferenceFileExt) {
+			count++
+		}
+	}
+	return count
+}
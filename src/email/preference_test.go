@@ -0,0 +1,157 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/email"
+	"email-service/src/interfaces/render"
+	"errors"
+	"os"
+	"slices"
+	"testing"
+	"time"
+)
+
+func preferenceConfig(t *testing.T) *config.PreferenceConfig {
+	t.Helper()
+	c := &config.PreferenceConfig{}
+	c.InitDefaults()
+	c.Enable = true
+	c.Token = "0123456789abcdef"
+	c.Path = t.TempDir()
+	if ok, err := c.Verify(); !ok {
+		t.Fatalf("verify config: %v", err)
+	}
+	return c
+}
+
+func TestFilePreferenceSharedBetweenInstances(t *testing.T) {
+	c := preferenceConfig(t)
+	first, err := NewFilePreference(nopLogger{}, c)
+	if err != nil {
+		t.Fatalf("new preference: %v", err)
+	}
+	if _, err := first.Set("2352", map[string]bool{"activity": false, "ticket": false}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if _, err := first.SetTimezone("2352", "Asia/Shanghai"); err != nil {
+		t.Fatalf("set timezone: %v", err)
+	}
+
+	// 重启或另一个实例挂载同一目录时读到相同的偏好
+	second, err := NewFilePreference(nopLogger{}, c)
+	if err != nil {
+		t.Fatalf("new preference: %v", err)
+	}
+	if !second.IsOptedOut("2352", config.EmailActivityAtcJoin) || !second.IsOptedOut("2352", config.EmailTicketReply) {
+		t.Errorf("opt out not persisted: %+v", second.Get("2352"))
+	}
+	if location, ok := second.Location("2352"); !ok || location.String() != "Asia/Shanghai" {
+		t.Errorf("location = %v, %t", location, ok)
+	}
+
+	if _, err := second.Set("2352", map[string]bool{"ticket": true}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if got := first.Get("2352").OptOut; !slices.Equal(got, []string{"activity"}) {
+		t.Errorf("opt out = %v, want [activity]", got)
+	}
+	if second.Size() != 1 {
+		t.Errorf("size = %d, want 1", second.Size())
+	}
+}
+
+func TestFilePreferenceDefaultsAndValidation(t *testing.T) {
+	preferences, err := NewFilePreference(nopLogger{}, preferenceConfig(t))
+	if err != nil {
+		t.Fatalf("new preference: %v", err)
+	}
+	if got := preferences.Get("1001"); got.Cid != "1001" || len(got.OptOut) != 0 || got.Timezone != "" {
+		t.Errorf("default preference = %+v", got)
+	}
+	if preferences.IsOptedOut("1001", config.EmailActivityAtcJoin) {
+		t.Errorf("opted out without preference")
+	}
+	if _, err := preferences.Set("1001", map[string]bool{"unknown": false}); !errors.Is(err, email.ErrPreferenceCategoryUnknown) {
+		t.Errorf("set unknown category error = %v", err)
+	}
+	if _, err := preferences.SetTimezone("1001", "Mars/Olympus"); !errors.Is(err, email.ErrPreferenceTimezoneInvalid) {
+		t.Errorf("set invalid timezone error = %v", err)
+	}
+	if preferences.Size() != 0 {
+		t.Errorf("invalid updates were saved")
+	}
+}
+
+func TestFilePreferenceEscapesCid(t *testing.T) {
+	c := preferenceConfig(t)
+	preferences, err := NewFilePreference(nopLogger{}, c)
+	if err != nil {
+		t.Fatalf("new preference: %v", err)
+	}
+	for _, cid := range []string{"../escape", "a/b", ".."} {
+		if _, err := preferences.Set(cid, map[string]bool{"ticket": false}); err != nil {
+			t.Fatalf("set %q: %v", cid, err)
+		}
+		if !preferences.IsOptedOut(cid, config.EmailTicketReply) {
+			t.Errorf("preference of %q not saved", cid)
+		}
+	}
+	entries, err := os.ReadDir(c.Path)
+	if err != nil {
+		t.Fatalf("read dir: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Errorf("got %d files in preference directory, want 3", len(entries))
+	}
+}
+
+func TestCidOf(t *testing.T) {
+	tests := []struct {
+		data interface{}
+		want string
+	}{
+		{&email.TicketReplyEmail{Cid: "2352"}, "2352"},
+		{&email.ActivityAtcJoinEmail{Cid: "1001"}, "1001"},
+		{&email.VerifyCodeEmail{Code: "123456"}, ""},
+		{&email.DigestEmail{}, ""},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := cidOf(tt.data); got != tt.want {
+			t.Errorf("cidOf(%T) = %q, want %q", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestLocalizeCopiesData(t *testing.T) {
+	preferences, err := NewFilePreference(nopLogger{}, preferenceConfig(t))
+	if err != nil {
+		t.Fatalf("new preference: %v", err)
+	}
+	if _, err := preferences.SetTimezone("2352", "Asia/Shanghai"); err != nil {
+		t.Fatalf("set timezone: %v", err)
+	}
+	sender := &Sender{config: &config.EmailConfig{Location: time.UTC}, preferences: preferences}
+	at := render.NewTime(time.Date(2026, 1, 1, 16, 30, 0, 0, time.UTC))
+
+	// 同一时间值发给不同时区的用户, 调用方传入的参数不应被修改
+	original := &email.BannedEmail{Cid: "2352", Time: at}
+	localized := sender.localize(original).(*email.BannedEmail)
+	if at.Location != nil {
+		t.Errorf("caller time location = %v, want unchanged", at.Location)
+	}
+	if got := localized.Time.Local(); got != "2026-01-02 00:30 (UTC+08:00)" {
+		t.Errorf("localized time = %q", got)
+	}
+	if localized.Cid != "2352" {
+		t.Errorf("localized cid = %q", localized.Cid)
+	}
+	other := sender.localize(&email.BannedEmail{Cid: "1001", Time: at}).(*email.BannedEmail)
+	if got := other.Time.Local(); got != "2026-01-01 16:30 (UTC+00:00)" {
+		t.Errorf("default location time = %q", got)
+	}
+}
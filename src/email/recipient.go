@@ -0,0 +1,52 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"email-service/src/interfaces/email"
+	"fmt"
+	"net/mail"
+	"strings"
+)
+
+// normalizeRecipients 校验并规范化收件人地址, 同一地址只保留在优先级最高的列表中(To > Cc > Bcc)
+func normalizeRecipients(recipients *email.Recipients) (*email.Recipients, error) {
+	seen := make(map[string]bool)
+	normalize := func(addresses []string) ([]string, error) {
+		result := make([]string, 0, len(addresses))
+		for _, address := range addresses {
+			address = strings.ToLower(strings.TrimSpace(address))
+			if address == "" {
+				continue
+			}
+			parsed, err := mail.ParseAddress(address)
+			if err != nil || parsed.Address != address {
+				return nil, fmt.Errorf("%w: %s", email.ErrRecipientInvalid, address)
+			}
+			if seen[address] {
+				continue
+			}
+			seen[address] = true
+			result = append(result, address)
+		}
+		return result, nil
+	}
+	to, err := normalize(recipients.To)
+	if err != nil {
+		return nil, err
+	}
+	cc, err := normalize(recipients.Cc)
+	if err != nil {
+		return nil, err
+	}
+	bcc, err := normalize(recipients.Bcc)
+	if err != nil {
+		return nil, err
+	}
+	if len(to) == 0 {
+		return nil, email.ErrNoRecipient
+	}
+	return &email.Recipients{To: to, Cc: cc, Bcc: bcc}, nil
+}
@@ -0,0 +1,23 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"context"
+	"net"
+)
+
+// NetResolver 使用系统DNS解析MX记录
+type NetResolver struct {
+	resolver *net.Resolver
+}
+
+func NewNetResolver() *NetResolver {
+	return &NetResolver{resolver: net.DefaultResolver}
+}
+
+func (r *NetResolver) LookupMX(ctx context.Context, domain string) ([]*net.MX, error) {
+	return r.resolver.LookupMX(ctx, domain)
+}
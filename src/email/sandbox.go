@@ -0,0 +1,165 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"bytes"
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/email"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thanhpk/randstr"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// Sandbox 沙盒收件箱, 仅保留最近的邮件, maildir模式下同时写入maildir目录
+type Sandbox struct {
+	logger logger.Interface
+	config *config.SandboxConfig
+	lock   sync.RWMutex
+	emails []*email.CapturedEmail
+	files  map[string]string
+	next   int
+	size   int
+}
+
+func NewSandbox(
+	lg logger.Interface,
+	c *config.SandboxConfig,
+) (*Sandbox, error) {
+	sandbox := &Sandbox{
+		logger: logger.NewLoggerAdapter(lg, "email-sandbox"),
+		config: c,
+		emails: make([]*email.CapturedEmail, c.Capacity),
+		files:  make(map[string]string),
+	}
+	if c.Sink == config.SandboxSinkMaildir {
+		for _, dir := range []string{"tmp", "new", "cur"} {
+			if err := os.MkdirAll(filepath.Join(c.MaildirPath, dir), 0750); err != nil {
+				return nil, fmt.Errorf("fail to create maildir, %v", err)
+			}
+		}
+	}
+	return sandbox, nil
+}
+
+func (s *Sandbox) Send(from string, to []string, msg io.WriterTo) error {
+	buffer := &bytes.Buffer{}
+	if _, err := msg.WriteTo(buffer); err != nil {
+		return err
+	}
+	captured, err := parseMessage(buffer.Bytes())
+	if err != nil {
+		return fmt.Errorf("fail to parse captured email, %v", err)
+	}
+	captured.Id = randstr.Hex(16)
+	captured.Time = time.Now()
+	captured.From = from
+	captured.To = to
+
+	file := ""
+	if s.config.Sink == config.SandboxSinkMaildir {
+		if file, err = s.writeMaildir(captured); err != nil {
+			return err
+		}
+	}
+
+	s.logger.Infof("captured email %s from %s to %s, subject: %s", captured.Id, from, strings.Join(to, ","), captured.Subject)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if old := s.emails[s.next]; old != nil {
+		s.removeFile(old.Id)
+	}
+	s.emails[s.next] = captured
+	if file != "" {
+		s.files[captured.Id] = file
+	}
+	s.next = (s.next + 1) % len(s.emails)
+	if s.size < len(s.emails) {
+		s.size++
+	}
+	return nil
+}
+
+func (s *Sandbox) Close() error { return nil }
+
+func (s *Sandbox) List(target string, limit int) []*email.CapturedEmail {
+	target = strings.ToLower(target)
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	result := make([]*email.CapturedEmail, 0)
+	for i := 0; i < s.size; i++ {
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+		captured := s.emails[(s.next-1-i+len(s.emails))%len(s.emails)]
+		if target != "" && !slices.Contains(captured.To, target) {
+			continue
+		}
+		result = append(result, captured)
+	}
+	return result
+}
+
+func (s *Sandbox) Get(id string) (*email.CapturedEmail, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	for i := 0; i < s.size; i++ {
+		if s.emails[i].Id == id {
+			return s.emails[i], true
+		}
+	}
+	return nil, false
+}
+
+func (s *Sandbox) Clear() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for id := range s.files {
+		s.removeFile(id)
+	}
+	s.emails = make([]*email.CapturedEmail, len(s.emails))
+	s.next = 0
+	s.size = 0
+}
+
+func (s *Sandbox) Size() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.size
+}
+
+// writeMaildir 按maildir规范先写入tmp目录再移动到new目录
+func (s *Sandbox) writeMaildir(captured *email.CapturedEmail) (string, error) {
+	name := fmt.Sprintf("%d.%s.email-service", captured.Time.UnixNano(), captured.Id)
+	tmp := filepath.Join(s.config.MaildirPath, "tmp", name)
+	if err := os.WriteFile(tmp, []byte(captured.Raw), 0640); err != nil {
+		return "", fmt.Errorf("fail to write maildir, %v", err)
+	}
+	file := filepath.Join(s.config.MaildirPath, "new", name)
+	if err := os.Rename(tmp, file); err != nil {
+		return "", fmt.Errorf("fail to write maildir, %v", err)
+	}
+	return file, nil
+}
+
+func (s *Sandbox) removeFile(id string) {
+	file, ok := s.files[id]
+	if !ok {
+		return
+	}
+	delete(s.files, id)
+	if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+		s.logger.Warnf("fail to remove maildir file %s, %v", file, err)
+	}
+}
@@ -5,62 +5,312 @@
 package email
 
 import (
+	"context"
 	"email-service/src/interfaces/config"
 	"email-service/src/interfaces/email"
+	"email-service/src/interfaces/metrics"
+	"email-service/src/interfaces/render"
+	"email-service/src/interfaces/webhook"
+	"errors"
+	"fmt"
 	"html/template"
+	"io"
+	"net/textproto"
+	"reflect"
+	"slices"
 	"strings"
+	"sync/atomic"
+	"time"
 
+	"github.com/thanhpk/randstr"
 	"gopkg.in/gomail.v2"
 	"half-nothing.cn/service-core/interfaces/logger"
 )
 
 type Sender struct {
-	logger    logger.Interface
-	config    *config.EmailConfig
-	templates *config.TemplateConfig
+	logger       logger.Interface
+	config       *config.EmailConfig
+	templates    *config.TemplateConfig
+	suppressions email.SuppressionInterface
+	metrics      metrics.RecorderInterface
+	transport    email.TransportInterface
+	// 关键邮件专用的发送通道, 与其他邮件共用发送通道时为nil
+	criticalTransport email.TransportInterface
+	webhooks          webhook.DispatcherInterface
+	pending           atomic.Int64
+	// 用户通知偏好, 未启用时为nil
+	preferences email.PreferenceInterface
+	// 汇总邮件, 未启用时为nil
+	digest *Digest
+	lanes  *Lanes
 }
 
 func NewSender(
 	lg logger.Interface,
 	c *config.EmailConfig,
+	transport email.TransportInterface,
+	criticalTransport email.TransportInterface,
+	suppressions email.SuppressionInterface,
+	preferences email.PreferenceInterface,
+	metrics metrics.RecorderInterface,
+	webhooks webhook.DispatcherInterface,
 ) *Sender {
 	sender := &Sender{
-		logger:    logger.NewLoggerAdapter(lg, "email-sender"),
-		config:    c,
-		templates: c.Template.Templates,
+		logger:            logger.NewLoggerAdapter(lg, "email-sender"),
+		config:            c,
+		templates:         c.Template.Templates,
+		suppressions:      suppressions,
+		preferences:       preferences,
+		metrics:           metrics,
+		transport:         transport,
+		criticalTransport: criticalTransport,
+		webhooks:          webhooks,
+		lanes:             NewLanes(c.Priority, metrics),
+	}
+	metrics.RegisterSize("send_queue", sender.Pending)
+	if c.Digest.Enable {
+		sender.digest = NewDigest(lg, c.Digest, sender.sendDigest)
+		metrics.RegisterSize("digest", sender.digest.Size)
 	}
 	return sender
 }
 
-func (sender *Sender) SendEmail(emailType config.Email, target string, data interface{}) error {
-	if !emailType.Data.Enable {
+// FlushDigests 立即发送所有暂存的汇总通知
+func (sender *Sender) FlushDigests() {
+	if sender.digest != nil {
+		sender.digest.Flush()
+	}
+}
+
+func (sender *Sender) Pending() int {
+	return int(sender.pending.Load())
+}
+
+func (sender *Sender) SendEmail(emailType config.Email, recipients *email.Recipients, data interface{}) error {
+	return sender.sendEmail("", emailType, recipients, data)
+}
+
+func (sender *Sender) SendQueuedEmail(id string, emailType config.Email, recipients *email.Recipients, data interface{}) error {
+	return sender.sendEmail(id, emailType, recipients, data)
+}
+
+// sendEmail 发送邮件, asyncId为异步队列分配的消息Id, 同步发送时为空
+func (sender *Sender) sendEmail(asyncId string, emailType config.Email, recipients *email.Recipients, data interface{}) error {
+	if !emailType.Data.Enabled() {
+		sender.metrics.EmailSent(emailType.Value, metrics.OutcomeDisabled)
 		return email.ErrEmailNotEnabled
 	}
 	validator, exist := email.Validators[emailType]
 	if !exist {
+		sender.metrics.EmailSent(emailType.Value, metrics.OutcomeInvalid)
 		return email.ErrEmailNotRegistered
 	}
 	if !validator(data) {
+		sender.metrics.EmailSent(emailType.Value, metrics.OutcomeInvalid)
 		return email.ErrEmailDataInvalid
 	}
+	if sender.preferences != nil {
+		if cid := cidOf(data); cid != "" && sender.preferences.IsOptedOut(cid, emailType) {
+			sender.logger.Infof("skip %s email, %s opted out", emailType.Value, cid)
+			sender.metrics.EmailSent(emailType.Value, metrics.OutcomeOptedOut)
+			return email.ErrEmailOptedOut
+		}
+	}
+	data = sender.localize(data)
 
-	target = strings.ToLower(target)
+	recipients, err := normalizeRecipients(&email.Recipients{
+		To:  recipients.To,
+		Cc:  append(slices.Clone(recipients.Cc), emailType.Data.Cc...),
+		Bcc: append(slices.Clone(recipients.Bcc), emailType.Data.Bcc...),
+	})
+	if err != nil {
+		sender.metrics.EmailSent(emailType.Value, metrics.OutcomeInvalid)
+		return err
+	}
+	event := &webhook.EmailEvent{EmailType: emailType.Value, AsyncId: asyncId}
+	if threaded, ok := data.(email.ThreadedEmail); ok {
+		event.ThreadId = threaded.ThreadId()
+	}
+	recipients, event.Suppressed = sender.filterSuppressed(emailType, recipients)
+	event.To, event.Cc = recipients.To, recipients.Cc
+	if len(recipients.To) == 0 {
+		sender.metrics.EmailSent(emailType.Value, metrics.OutcomeSuppressed)
+		sender.webhooks.Dispatch(config.WebhookEventSuppressed, event)
+		return email.ErrEmailSuppressed
+	}
+	if len(event.Suppressed) > 0 {
+		sender.webhooks.Dispatch(config.WebhookEventSuppressed, event)
+	}
+	// 带抄送或密送的邮件无法按单个收件人合并, 仍然立即发送
+	// 汇总邮件不附带日历邀请, 带日历邀请的邮件同样立即发送
+	if sender.digest != nil && emailType.Data.Delivery == config.DeliveryDigest &&
+		len(recipients.Cc) == 0 && len(recipients.Bcc) == 0 && sender.calendarEventOf(data) == nil {
+		return sender.holdForDigest(emailType, recipients, data, event)
+	}
 
-	m, err := sender.generateEmail(target, emailType, data)
+	m, err := sender.generateEmail(recipients, emailType, data)
 	if err != nil {
 		sender.logger.Errorf("failed to generate %s email: %s", emailType.Value, err.Error())
+		sender.metrics.EmailSent(emailType.Value, metrics.OutcomeFailed)
+		event.Error = err.Error()
+		sender.webhooks.Dispatch(config.WebhookEventFailed, event)
 		return err
 	}
+	event.MessageId = m.GetHeader("Message-ID")[0]
 
-	sender.logger.Infof("sending %s email to %s with args: %#v", emailType.Value, target, data)
+	sender.logger.Infof("sending %s email to %s (cc %d, bcc %d) with args: %#v",
+		emailType.Value, strings.Join(recipients.To, ","), len(recipients.Cc), len(recipients.Bcc), email.Redact(data))
 
-	if err := gomail.Send(sender.config.Closer, m); err != nil {
+	sender.pending.Add(1)
+	defer sender.pending.Add(-1)
+	ctx, cancel := context.WithTimeout(context.Background(), sender.config.Priority.TimeoutDuration)
+	release, err := sender.lanes.Acquire(ctx, emailType.Data.Priority)
+	cancel()
+	if err != nil {
+		sender.logger.Errorf("failed to send %s email, %s lane busy: %s", emailType.Value, emailType.Data.Priority, err.Error())
+		sender.metrics.EmailSent(emailType.Value, metrics.OutcomeFailed)
+		event.Error = err.Error()
+		sender.webhooks.Dispatch(config.WebhookEventFailed, event)
+		return err
+	}
+	defer release()
+	transport := sender.transportOf(emailType.Data.Priority)
+	start := time.Now()
+	// gomail.Send不会保留原始错误, 通过包装记录发送通道返回的错误以区分退信
+	var transportErr error
+	err = gomail.Send(gomail.SendFunc(func(from string, to []string, msg io.WriterTo) error {
+		transportErr = transport.Send(from, to, msg)
+		return transportErr
+	}), m)
+	sender.metrics.SmtpLatency(time.Since(start), err == nil)
+	if err != nil {
 		sender.logger.Errorf("failed to send %s email: %s", emailType.Value, err.Error())
+		sender.metrics.EmailSent(emailType.Value, metrics.OutcomeFailed)
+		event.Error = err.Error()
+		if isPermanentFailure(transportErr) {
+			sender.webhooks.Dispatch(config.WebhookEventBounced, event)
+		} else {
+			sender.webhooks.Dispatch(config.WebhookEventFailed, event)
+		}
 		return err
 	}
+	sender.metrics.EmailSent(emailType.Value, metrics.OutcomeSuccess)
+	sender.webhooks.Dispatch(config.WebhookEventSent, event)
 	return nil
 }
 
+// transportOf 返回指定优先级使用的发送通道
+func (sender *Sender) transportOf(priority string) email.TransportInterface {
+	if priority == config.PriorityCritical && sender.criticalTransport != nil {
+		return sender.criticalTransport
+	}
+	return sender.transport
+}
+
+// holdForDigest 渲染通知内容并按收件地址暂存, 等待合并为汇总邮件
+// 暂存的通知在汇总邮件发出后才推送投递结果事件
+func (sender *Sender) holdForDigest(emailType config.Email, recipients *email.Recipients, data interface{}, event *webhook.EmailEvent) error {
+	start := time.Now()
+	content, err := sender.renderTemplate(emailType.Data.Template(), templateData(emailType, data))
+	sender.metrics.RenderDuration(emailType.Value, time.Since(start))
+	if err != nil {
+		sender.logger.Errorf("failed to render %s email for digest: %s", emailType.Value, err.Error())
+		sender.metrics.EmailSent(emailType.Value, metrics.OutcomeFailed)
+		return err
+	}
+	item := &email.DigestItem{
+		EmailType: emailType.Value,
+		ThreadId:  event.ThreadId,
+		AsyncId:   event.AsyncId,
+		Subject:   emailType.Data.Subject,
+		Time:      start.Format(time.DateTime),
+		Html:      template.HTML(content),
+	}
+	for _, address := range recipients.To {
+		sender.digest.Add(address, item)
+	}
+	sender.logger.Infof("holding %s email to %s for digest", emailType.Value, strings.Join(recipients.To, ","))
+	sender.metrics.EmailSent(emailType.Value, metrics.OutcomeDigested)
+	return nil
+}
+
+// sendDigest 发送汇总邮件, 并按原通知类型逐条推送投递结果事件
+func (sender *Sender) sendDigest(address string, items []*email.DigestItem) {
+	data := &email.DigestEmail{Count: len(items), Items: items}
+	err := sender.SendEmail(config.EmailDigest, email.NewRecipients(address), data)
+	if err != nil {
+		sender.logger.Errorf("failed to send digest of %d items to %s: %s", len(items), address, err.Error())
+	}
+	for _, item := range items {
+		event := &webhook.EmailEvent{EmailType: item.EmailType, ThreadId: item.ThreadId, AsyncId: item.AsyncId, To: []string{address}}
+		if err != nil {
+			event.Error = err.Error()
+			sender.webhooks.Dispatch(config.WebhookEventFailed, event)
+			continue
+		}
+		sender.webhooks.Dispatch(config.WebhookEventSent, event)
+	}
+}
+
+var timeType = reflect.TypeOf((*render.Time)(nil))
+
+// localize 返回模板参数的副本, 其中的时间字段按收件人时区显示, 用户未设置时区时使用默认时区
+// 调用方传入的参数可能被重试或并发发送复用, 不能直接修改
+func (sender *Sender) localize(data interface{}) interface{} {
+	value := reflect.ValueOf(data)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return data
+	}
+	location := sender.config.Location
+	if cid := cidOf(data); cid != "" && sender.preferences != nil {
+		if userLocation, ok := sender.preferences.Location(cid); ok {
+			location = userLocation
+		}
+	}
+	localized := reflect.New(value.Elem().Type())
+	localized.Elem().Set(value.Elem())
+	for i := 0; i < localized.Elem().NumField(); i++ {
+		field := localized.Elem().Field(i)
+		if field.Type() != timeType || field.IsNil() || !field.CanSet() {
+			continue
+		}
+		field.Set(reflect.ValueOf(field.Interface().(*render.Time).In(location)))
+	}
+	return localized.Interface()
+}
+
+// cidOf 返回邮件收件用户的Cid, 不是发给特定用户的邮件返回空字符串
+func cidOf(data interface{}) string {
+	if user, ok := data.(email.UserEmail); ok {
+		return user.UserCid()
+	}
+	return ""
+}
+
+// isPermanentFailure SMTP服务器以5xx拒绝投递时视为退信
+func isPermanentFailure(err error) bool {
+	var smtpErr *textproto.Error
+	return errors.As(err, &smtpErr) && smtpErr.Code >= 500 && smtpErr.Code < 600
+}
+
+// filterSuppressed 去掉禁止投递的收件人, 同时返回被去掉的收件与抄送地址
+func (sender *Sender) filterSuppressed(emailType config.Email, recipients *email.Recipients) (*email.Recipients, []string) {
+	var suppressed []string
+	filter := func(addresses []string, report bool) []string {
+		return slices.DeleteFunc(addresses, func(address string) bool {
+			if sender.suppressions.IsSuppressed(address) {
+				sender.logger.Warnf("skip %s email to suppressed address %s", emailType.Value, address)
+				if report {
+					suppressed = append(suppressed, address)
+				}
+				return true
+			}
+			return false
+		})
+	}
+	return &email.Recipients{To: filter(recipients.To, true), Cc: filter(recipients.Cc, true), Bcc: filter(recipients.Bcc, false)}, suppressed
+}
+
 func (sender *Sender) renderTemplate(template *template.Template, data interface{}) (string, error) {
 	var sb strings.Builder
 	if err := template.Execute(&sb, data); err != nil {
@@ -69,17 +319,99 @@ func (sender *Sender) renderTemplate(template *template.Template, data interface
 	return sb.String(), nil
 }
 
-func (sender *Sender) generateEmail(email string, emailType config.Email, data interface{}) (*gomail.Message, error) {
-	content, err := sender.renderTemplate(emailType.Data.Template, data)
+func (sender *Sender) generateEmail(recipients *email.Recipients, emailType config.Email, data interface{}) (*gomail.Message, error) {
+	start := time.Now()
+	content, err := sender.renderTemplate(emailType.Data.Template(), templateData(emailType, data))
+	sender.metrics.RenderDuration(emailType.Value, time.Since(start))
 	if err != nil {
 		return nil, err
 	}
 
 	m := gomail.NewMessage()
-	m.SetHeader("From", sender.config.Username)
-	m.SetHeader("To", email)
+	for key, value := range sender.config.Headers {
+		m.SetHeader(key, value)
+	}
+	for key, value := range emailType.Data.Headers {
+		m.SetHeader(key, value)
+	}
+
+	fromName := sender.config.FromName
+	if emailType.Data.FromName != "" {
+		fromName = emailType.Data.FromName
+	}
+	if fromName != "" {
+		m.SetAddressHeader("From", sender.config.From, fromName)
+	} else {
+		m.SetHeader("From", sender.config.From)
+	}
+	replyTo := sender.config.ReplyTo
+	if emailType.Data.ReplyTo != "" {
+		replyTo = emailType.Data.ReplyTo
+	}
+	if replyTo != "" {
+		m.SetHeader("Reply-To", replyTo)
+	}
+
+	m.SetHeader("To", recipients.To...)
+	if len(recipients.Cc) > 0 {
+		m.SetHeader("Cc", recipients.Cc...)
+	}
+	// gomail在写出邮件时会去掉Bcc邮件头, 密送地址只出现在SMTP信封中
+	if len(recipients.Bcc) > 0 {
+		m.SetHeader("Bcc", recipients.Bcc...)
+	}
+
+	domain := sender.domain()
+	m.SetHeader("Message-ID", fmt.Sprintf("<%s.%d@%s>", randstr.Hex(16), time.Now().UnixNano(), domain))
+	if threaded, ok := data.(email.ThreadedEmail); ok && threaded.ThreadId() != "" {
+		thread := fmt.Sprintf("<%s@%s>", threaded.ThreadId(), domain)
+		m.SetHeader("In-Reply-To", thread)
+		m.SetHeader("References", thread)
+	}
+
 	m.SetHeader("Subject", emailType.Data.Subject)
-	m.SetBody("text/html", content)
+	// 纯文本部分在前, 支持HTML的客户端优先显示最后一个备选正文
+	m.SetBody("text/plain", render.PlainText(content))
+	m.AddAlternative("text/html", content)
+	if event := sender.calendarEventOf(data); event != nil {
+		sender.attachCalendar(m, emailType, event, recipients.To)
+	}
 
 	return m, nil
 }
+
+// calendarEventOf 返回邮件需要附带的日历邀请, 未启用日历邀请或邮件不附带日历邀请时返回nil
+func (sender *Sender) calendarEventOf(data interface{}) *email.CalendarEvent {
+	if !sender.config.Calendar.Enable {
+		return nil
+	}
+	if calendar, ok := data.(email.CalendarEmail); ok {
+		return calendar.CalendarEvent()
+	}
+	return nil
+}
+
+// attachCalendar 同时以备选正文与附件两种形式附带日历邀请, 生成失败时只记录日志, 邮件照常发送
+func (sender *Sender) attachCalendar(m *gomail.Message, emailType config.Email, event *email.CalendarEvent, attendees []string) {
+	ics, method, err := sender.buildCalendar(event, attendees)
+	if err != nil {
+		sender.logger.Warnf("skip calendar of %s email: %s", emailType.Value, err.Error())
+		return
+	}
+	m.AddAlternative("text/calendar; method="+method, ics)
+	m.Attach("invite.ics",
+		gomail.SetHeader(map[string][]string{"Content-Type": {"application/ics; name=\"invite.ics\""}}),
+		gomail.SetCopyFunc(func(w io.Writer) error {
+			_, err := io.WriteString(w, ics)
+			return err
+		}),
+	)
+}
+
+// domain 返回发件地址的域名, 用于生成Message-ID
+func (sender *Sender) domain() string {
+	if index := strings.LastIndex(sender.config.From, "@"); index >= 0 {
+		return sender.config.From[index+1:]
+	}
+	return "localhost"
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"email-service/src/interfaces/email"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemorySuppression 内存中的禁止投递列表
+type MemorySuppression struct {
+	lock         sync.RWMutex
+	suppressions map[string]*email.Suppression
+}
+
+func NewMemorySuppression() *MemorySuppression {
+	return &MemorySuppression{
+		suppressions: make(map[string]*email.Suppression),
+	}
+}
+
+func (m *MemorySuppression) Add(address string, reason string) *email.Suppression {
+	suppression := &email.Suppression{
+		Email:     strings.ToLower(address),
+		Reason:    reason,
+		CreatedAt: time.Now(),
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.suppressions[suppression.Email] = suppression
+	return suppression
+}
+
+func (m *MemorySuppression) Remove(address string) bool {
+	address = strings.ToLower(address)
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if _, ok := m.suppressions[address]; !ok {
+		return false
+	}
+	delete(m.suppressions, address)
+	return true
+}
+
+func (m *MemorySuppression) List() []*email.Suppression {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	result := make([]*email.Suppression, 0, len(m.suppressions))
+	for _, suppression := range m.suppressions {
+		result = append(result, suppression)
+	}
+	slices.SortFunc(result, func(a, b *email.Suppression) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	return result
+}
+
+func (m *MemorySuppression) IsSuppressed(address string) bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	_, ok := m.suppressions[strings.ToLower(address)]
+	return ok
+}
+
+func (m *MemorySuppression) Size() int {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return len(m.suppressions)
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/email"
+	"fmt"
+
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// NewTransport 根据配置创建邮件发送通道
+func NewTransport(
+	lg logger.Interface,
+	c *config.EmailConfig,
+) (email.TransportInterface, error) {
+	switch c.Transport.Type {
+	case config.TransportSmtp:
+		return NewSmtpTransport(lg, c.Server)
+	case config.TransportSendmail:
+		return NewSendmailTransport(lg, c.Transport.Sendmail), nil
+	case config.TransportFile:
+		return NewFileTransport(lg, c.Transport.File)
+	case config.TransportHttp:
+		return NewHttpTransport(lg, c.Transport.Http), nil
+	default:
+		return nil, fmt.Errorf("unknown transport type %s", c.Transport.Type)
+	}
+}
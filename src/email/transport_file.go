@@ -0,0 +1,63 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"email-service/src/interfaces/config"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/thanhpk/randstr"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// FileTransport 将邮件以.eml文件的形式写入目录
+type FileTransport struct {
+	logger logger.Interface
+	config *config.FileTransportConfig
+}
+
+func NewFileTransport(
+	lg logger.Interface,
+	c *config.FileTransportConfig,
+) (*FileTransport, error) {
+	if err := os.MkdirAll(c.Path, 0750); err != nil {
+		return nil, fmt.Errorf("fail to create outbox directory, %v", err)
+	}
+	return &FileTransport{
+		logger: logger.NewLoggerAdapter(lg, "file-transport"),
+		config: c,
+	}, nil
+}
+
+func (t *FileTransport) Send(_ string, _ []string, msg io.WriterTo) error {
+	name := fmt.Sprintf("%d-%s.eml", time.Now().UnixNano(), randstr.Hex(8))
+	// 先写入临时文件再重命名, 避免读取方看到写了一半的邮件
+	tmp := filepath.Join(t.config.Path, "."+name)
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0640)
+	if err != nil {
+		return err
+	}
+	if _, err := msg.WriteTo(file); err != nil {
+		_ = file.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	path := filepath.Join(t.config.Path, name)
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+	t.logger.Debugf("email written to %s", path)
+	return nil
+}
+
+func (t *FileTransport) Close() error { return nil }
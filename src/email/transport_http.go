@@ -0,0 +1,120 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"bytes"
+	"email-service/src/interfaces/config"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"slices"
+	"strings"
+
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// httpPayload HTTP发送通道请求体模板的数据
+// To与Cc取自邮件头, 信封中其余的收件人为密送, 不会出现在邮件头与Raw中
+type httpPayload struct {
+	From    string
+	To      []string
+	Cc      []string
+	Bcc     []string
+	ReplyTo string
+	Subject string
+	Html    string
+	Text    string
+	// 解码后的邮件头, 包含Message-ID、In-Reply-To、References等
+	Headers map[string][]string
+	Raw     string
+}
+
+// HttpTransport 通过服务商的HTTP API发送邮件, 请求体由配置的模板生成
+type HttpTransport struct {
+	logger logger.Interface
+	config *config.HttpTransportConfig
+	client *http.Client
+}
+
+func NewHttpTransport(
+	lg logger.Interface,
+	c *config.HttpTransportConfig,
+) *HttpTransport {
+	return &HttpTransport{
+		logger: logger.NewLoggerAdapter(lg, "http-transport"),
+		config: c,
+		client: &http.Client{Timeout: c.TimeoutDuration},
+	}
+}
+
+func (t *HttpTransport) Send(from string, to []string, msg io.WriterTo) error {
+	raw := &bytes.Buffer{}
+	if _, err := msg.WriteTo(raw); err != nil {
+		return err
+	}
+	message, err := parseMessage(raw.Bytes())
+	if err != nil {
+		return fmt.Errorf("fail to parse email, %v", err)
+	}
+	payload := &httpPayload{
+		From:    from,
+		To:      headerAddresses(message.Headers, "To"),
+		Cc:      headerAddresses(message.Headers, "Cc"),
+		ReplyTo: firstHeader(message.Headers, "Reply-To"),
+		Subject: message.Subject,
+		Html:    message.Html,
+		Text:    message.Text,
+		Headers: message.Headers,
+		Raw:     base64.StdEncoding.EncodeToString(raw.Bytes()),
+	}
+	visible := append(slices.Clone(payload.To), payload.Cc...)
+	for _, address := range to {
+		if !slices.ContainsFunc(visible, func(v string) bool { return strings.EqualFold(v, address) }) {
+			payload.Bcc = append(payload.Bcc, address)
+		}
+	}
+	body := &bytes.Buffer{}
+	if err := t.config.PayloadTemplate.Execute(body, payload); err != nil {
+		return fmt.Errorf("fail to render http payload, %v", err)
+	}
+
+	req, err := http.NewRequest(t.config.Method, t.config.Url, body)
+	if err != nil {
+		return err
+	}
+	for key, value := range t.config.Headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		content, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("http transport responded with status %d: %s", resp.StatusCode, content)
+	}
+	return nil
+}
+
+func (t *HttpTransport) Close() error { return nil }
+
+// headerAddresses 返回邮件头中的收件地址, 不包含显示名称
+func headerAddresses(header map[string][]string, key string) []string {
+	var addresses []string
+	for _, value := range header[key] {
+		list, err := mail.ParseAddressList(value)
+		if err != nil {
+			continue
+		}
+		for _, address := range list {
+			addresses = append(addresses, address.Address)
+		}
+	}
+	return addresses
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"email-service/src/interfaces/config"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"gopkg.in/gomail.v2"
+)
+
+func TestHttpTransportKeepsBccOutOfVisibleRecipients(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+	c := &config.HttpTransportConfig{}
+	c.InitDefaults()
+	c.Url = server.URL
+	c.Payload = `{"to":{{json .To}},"cc":{{json .Cc}},"bcc":{{json .Bcc}},"reply_to":{{json .ReplyTo}},` +
+		`"in_reply_to":{{json (index .Headers "In-Reply-To")}},"raw":{{json .Raw}}}`
+	if ok, err := c.Verify(); !ok {
+		t.Fatalf("verify config: %v", err)
+	}
+	transport := NewHttpTransport(nopLogger{}, c)
+
+	m := gomail.NewMessage()
+	m.SetHeader("From", "noreply@example.com")
+	m.SetHeader("To", "to@example.com")
+	m.SetHeader("Cc", "cc@example.com")
+	m.SetHeader("Bcc", "hidden@example.com")
+	m.SetHeader("Reply-To", "support@example.com")
+	m.SetHeader("In-Reply-To", "<ticket-1@example.com>")
+	m.SetHeader("Subject", "subject")
+	m.SetBody("text/html", "<p>hello</p>")
+	err := gomail.Send(gomail.SendFunc(transport.Send), m)
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	payload := struct {
+		To        []string `json:"to"`
+		Cc        []string `json:"cc"`
+		Bcc       []string `json:"bcc"`
+		ReplyTo   string   `json:"reply_to"`
+		InReplyTo []string `json:"in_reply_to"`
+		Raw       string   `json:"raw"`
+	}{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("decode payload %s: %v", body, err)
+	}
+	// 密送地址只出现在Bcc中, 可见收件人与原始邮件中都没有
+	if !slices.Equal(payload.To, []string{"to@example.com"}) || !slices.Equal(payload.Cc, []string{"cc@example.com"}) {
+		t.Errorf("to = %v, cc = %v, want header recipients only", payload.To, payload.Cc)
+	}
+	if !slices.Equal(payload.Bcc, []string{"hidden@example.com"}) {
+		t.Errorf("bcc = %v, want [hidden@example.com]", payload.Bcc)
+	}
+	raw, err := base64.StdEncoding.DecodeString(payload.Raw)
+	if err != nil {
+		t.Fatalf("decode raw: %v", err)
+	}
+	if strings.Contains(string(raw), "hidden@example.com") {
+		t.Errorf("raw message leaks bcc address:\n%s", raw)
+	}
+	if payload.ReplyTo != "support@example.com" || !slices.Equal(payload.InReplyTo, []string{"<ticket-1@example.com>"}) {
+		t.Errorf("reply to = %q, in reply to = %v, want headers kept", payload.ReplyTo, payload.InReplyTo)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"bytes"
+	"email-service/src/interfaces/config"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// SendmailTransport 调用本地sendmail程序发送邮件
+type SendmailTransport struct {
+	logger logger.Interface
+	config *config.SendmailTransportConfig
+}
+
+func NewSendmailTransport(
+	lg logger.Interface,
+	c *config.SendmailTransportConfig,
+) *SendmailTransport {
+	return &SendmailTransport{
+		logger: logger.NewLoggerAdapter(lg, "sendmail-transport"),
+		config: c,
+	}
+}
+
+func (t *SendmailTransport) Send(from string, to []string, msg io.WriterTo) error {
+	args := append([]string{}, t.config.Args...)
+	args = append(args, "-f", from, "--")
+	args = append(args, to...)
+
+	stdin := &bytes.Buffer{}
+	if _, err := msg.WriteTo(stdin); err != nil {
+		return err
+	}
+	output := &bytes.Buffer{}
+	cmd := exec.Command(t.config.Path, args...)
+	cmd.Stdin = stdin
+	cmd.Stdout = output
+	cmd.Stderr = output
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("sendmail fail, %v: %s", err, strings.TrimSpace(output.String()))
+	}
+	return nil
+}
+
+func (t *SendmailTransport) Close() error { return nil }
@@ -0,0 +1,114 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"sync"
+	"syscall"
+
+	"gopkg.in/gomail.v2"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// smtpDialer 建立SMTP连接, 与gomail.Dialer兼容
+type smtpDialer interface {
+	Dial() (gomail.SendCloser, error)
+}
+
+// SmtpTransport 通过共享的SMTP连接发送邮件, 连接断开时自动重连
+type SmtpTransport struct {
+	logger logger.Interface
+	dialer smtpDialer
+	// 共享的SMTP连接不支持并发写入
+	lock   sync.Mutex
+	closer gomail.SendCloser
+}
+
+func NewSmtpTransport(
+	lg logger.Interface,
+	dialer *gomail.Dialer,
+) (*SmtpTransport, error) {
+	closer, err := dialer.Dial()
+	if err != nil {
+		return nil, fmt.Errorf("connect to smtp server fail, %v", err)
+	}
+	return &SmtpTransport{
+		logger: logger.NewLoggerAdapter(lg, "smtp-transport"),
+		dialer: dialer,
+		closer: closer,
+	}, nil
+}
+
+func (t *SmtpTransport) Send(from string, to []string, msg io.WriterTo) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.closer != nil {
+		err := t.closer.Send(from, to, msg)
+		if err == nil {
+			return nil
+		}
+		// 服务器拒信时连接可能停留在未完成的事务中, 关闭后下次发送重新连接, 但不重发
+		_ = t.closer.Close()
+		t.closer = nil
+		if !retryable(err) {
+			return err
+		}
+		t.logger.Warnf("send through shared smtp connection fail, reconnecting, %v", err)
+	}
+	closer, err := t.dialer.Dial()
+	if err != nil {
+		return fmt.Errorf("reconnect to smtp server fail, %v", err)
+	}
+	t.closer = closer
+	return t.closer.Send(from, to, msg)
+}
+
+// retryable 连接断开或读写失败时重连后重发, 服务器返回的SMTP错误(如收件人被拒)原样返回
+func retryable(err error) bool {
+	var protocolErr *textproto.Error
+	if errors.As(err, &protocolErr) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, syscall.ECONNRESET)
+}
+
+// CheckConnection 共享连接在上次发送失败后已关闭时重新连接, 正在发送时连接状态由发送结果决定, 直接返回
+func (t *SmtpTransport) CheckConnection() error {
+	if !t.lock.TryLock() {
+		return nil
+	}
+	defer t.lock.Unlock()
+	if t.closer != nil {
+		return nil
+	}
+	closer, err := t.dialer.Dial()
+	if err != nil {
+		return fmt.Errorf("reconnect to smtp server fail, %v", err)
+	}
+	t.closer = closer
+	return nil
+}
+
+func (t *SmtpTransport) Close() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.closer == nil {
+		return nil
+	}
+	err := t.closer.Close()
+	t.closer = nil
+	return err
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+
+	"gopkg.in/gomail.v2"
+)
+
+// scriptedConnection 按顺序返回errs中的错误, 用完后发送成功
+type scriptedConnection struct {
+	errs   []error
+	sends  int
+	closed bool
+}
+
+func (c *scriptedConnection) Send(string, []string, io.WriterTo) error {
+	c.sends++
+	if len(c.errs) == 0 {
+		return nil
+	}
+	err := c.errs[0]
+	c.errs = c.errs[1:]
+	return err
+}
+
+func (c *scriptedConnection) Close() error {
+	c.closed = true
+	return nil
+}
+
+// scriptedDialer 依次返回connections中的连接, 用完后返回dialErr
+type scriptedDialer struct {
+	connections []*scriptedConnection
+	dials       int
+	dialErr     error
+}
+
+func (d *scriptedDialer) Dial() (gomail.SendCloser, error) {
+	if d.dials >= len(d.connections) {
+		d.dials++
+		return nil, d.dialErr
+	}
+	connection := d.connections[d.dials]
+	d.dials++
+	return connection, nil
+}
+
+func newScriptedSmtpTransport(dialer *scriptedDialer) *SmtpTransport {
+	closer, _ := dialer.Dial()
+	return &SmtpTransport{logger: nopLogger{}, dialer: dialer, closer: closer}
+}
+
+func sendTestMessage(t *SmtpTransport) error {
+	return t.Send("noreply@example.com", []string{"pilot@example.net"}, strings.NewReader("Subject: test\r\n\r\nbody"))
+}
+
+func TestSmtpTransportReconnectsOnConnectionErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"eof", io.EOF},
+		{"unexpected eof", io.ErrUnexpectedEOF},
+		{"broken pipe", &net.OpError{Op: "write", Net: "tcp", Err: os.NewSyscallError("write", syscall.EPIPE)}},
+		{"connection reset", fmt.Errorf("read: %w", syscall.ECONNRESET)},
+		{"closed connection", net.ErrClosed},
+		{"timeout", &net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broken := &scriptedConnection{errs: []error{tt.err}}
+			fresh := &scriptedConnection{}
+			dialer := &scriptedDialer{connections: []*scriptedConnection{broken, fresh}}
+			transport := newScriptedSmtpTransport(dialer)
+
+			if err := sendTestMessage(transport); err != nil {
+				t.Fatalf("send: %v", err)
+			}
+			if !broken.closed {
+				t.Errorf("broken connection not closed")
+			}
+			if dialer.dials != 2 || fresh.sends != 1 {
+				t.Errorf("dials = %d, resends = %d, want 2 and 1", dialer.dials, fresh.sends)
+			}
+		})
+	}
+}
+
+func TestSmtpTransportReturnsProtocolErrors(t *testing.T) {
+	for _, code := range []int{450, 550, 552} {
+		t.Run(fmt.Sprint(code), func(t *testing.T) {
+			rejected := &textproto.Error{Code: code, Msg: "mailbox unavailable"}
+			connection := &scriptedConnection{errs: []error{rejected}}
+			dialer := &scriptedDialer{connections: []*scriptedConnection{connection, {}}}
+			transport := newScriptedSmtpTransport(dialer)
+
+			err := sendTestMessage(transport)
+			var protocolErr *textproto.Error
+			if !errors.As(err, &protocolErr) || protocolErr != rejected {
+				t.Fatalf("error = %#v, want the original *textproto.Error", err)
+			}
+			if connection.sends != 1 || dialer.dials != 1 {
+				t.Errorf("sends = %d, dials = %d, rejected email must not be resent", connection.sends, dialer.dials)
+			}
+
+			// 拒信后的连接状态不确定, 下一封邮件使用新连接
+			if err := sendTestMessage(transport); err != nil {
+				t.Fatalf("send after rejection: %v", err)
+			}
+			if !connection.closed || dialer.dials != 2 {
+				t.Errorf("connection closed = %t, dials = %d, want a new connection", connection.closed, dialer.dials)
+			}
+		})
+	}
+}
+
+func TestSmtpTransportReconnectFailure(t *testing.T) {
+	errDial := errors.New("connection refused")
+	dialer := &scriptedDialer{connections: []*scriptedConnection{{errs: []error{io.EOF}}}, dialErr: errDial}
+	transport := newScriptedSmtpTransport(dialer)
+
+	if err := sendTestMessage(transport); err == nil || !strings.Contains(err.Error(), errDial.Error()) {
+		t.Fatalf("error = %v, want reconnect failure", err)
+	}
+	if transport.closer != nil {
+		t.Errorf("failed connection kept")
+	}
+}
+
+func TestSmtpTransportCheckConnection(t *testing.T) {
+	errDial := errors.New("connection refused")
+	fresh := &scriptedConnection{}
+	dialer := &scriptedDialer{connections: []*scriptedConnection{{errs: []error{io.EOF}}}, dialErr: errDial}
+	transport := newScriptedSmtpTransport(dialer)
+
+	if err := transport.CheckConnection(); err != nil {
+		t.Fatalf("check open connection: %v", err)
+	}
+	// 发送失败且重连失败后共享连接不可用, 检查时重连仍然失败
+	_ = sendTestMessage(transport)
+	if err := transport.CheckConnection(); err == nil {
+		t.Fatal("check broken connection succeeded, want error")
+	}
+	// 服务器恢复后检查时恢复共享连接, 前两次失败的拨号占位后第四次拨号返回fresh
+	dialer.connections = append(dialer.connections, nil, nil, fresh)
+	if err := transport.CheckConnection(); err != nil {
+		t.Fatalf("check recovered connection: %v", err)
+	}
+	if err := sendTestMessage(transport); err != nil || fresh.sends != 1 {
+		t.Errorf("send after check = %v, sends = %d, want shared connection restored", err, fresh.sends)
+	}
+}
+
+func TestSmtpTransportClose(t *testing.T) {
+	connection := &scriptedConnection{}
+	transport := newScriptedSmtpTransport(&scriptedDialer{connections: []*scriptedConnection{connection}})
+	if err := transport.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if !connection.closed {
+		t.Errorf("connection not closed")
+	}
+	if err := transport.Close(); err != nil {
+		t.Errorf("second close: %v", err)
+	}
+}
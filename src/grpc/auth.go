@@ -0,0 +1,145 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package grpc
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"email-service/src/interfaces/audit"
+	"email-service/src/interfaces/config"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+const (
+	MetadataApiKey        = "x-api-key"
+	MetadataAuthorization = "authorization"
+
+	AllowAllMethods = "*"
+)
+
+// Authenticator 校验调用方身份并检查其是否有权调用目标方法
+// 调用方可以通过 x-api-key 元数据携带静态密钥, 或通过 authorization 元数据携带 Bearer JWT,
+// JWT 的 sub 声明需要与配置中的调用方名称一致
+type Authenticator struct {
+	logger  logger.Interface
+	config  *config.GrpcAuthConfig
+	keys    map[string]*config.GrpcCallerConfig
+	callers map[string]*config.GrpcCallerConfig
+}
+
+func NewAuthenticator(
+	lg logger.Interface,
+	c *config.GrpcAuthConfig,
+) *Authenticator {
+	authenticator := &Authenticator{
+		logger:  logger.NewLoggerAdapter(lg, "grpc-auth"),
+		config:  c,
+		keys:    make(map[string]*config.GrpcCallerConfig, len(c.Callers)),
+		callers: make(map[string]*config.GrpcCallerConfig, len(c.Callers)),
+	}
+	for _, caller := range c.Callers {
+		authenticator.callers[caller.Name] = caller
+		if caller.ApiKey != "" {
+			authenticator.keys[caller.ApiKey] = caller
+		}
+	}
+	return authenticator
+}
+
+func (a *Authenticator) findByApiKey(key string) *config.GrpcCallerConfig {
+	for apiKey, caller := range a.keys {
+		if subtle.ConstantTimeCompare([]byte(apiKey), []byte(key)) == 1 {
+			return caller
+		}
+	}
+	return nil
+}
+
+func (a *Authenticator) findByJwt(token string) *config.GrpcCallerConfig {
+	options := []jwt.ParserOption{jwt.WithValidMethods([]string{"HS256", "HS384", "HS512"}), jwt.WithExpirationRequired()}
+	if a.config.Jwt.Issuer != "" {
+		options = append(options, jwt.WithIssuer(a.config.Jwt.Issuer))
+	}
+	if a.config.Jwt.Audience != "" {
+		options = append(options, jwt.WithAudience(a.config.Jwt.Audience))
+	}
+	claims := &jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(_ *jwt.Token) (any, error) {
+		return []byte(a.config.Jwt.Secret), nil
+	}, options...)
+	if err != nil {
+		a.logger.Warnf("invalid jwt token, %v", err)
+		return nil
+	}
+	return a.callers[claims.Subject]
+}
+
+func (a *Authenticator) identify(ctx context.Context) *config.GrpcCallerConfig {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+	if keys := md.Get(MetadataApiKey); len(keys) > 0 {
+		return a.findByApiKey(keys[0])
+	}
+	if a.config.Jwt.Enable {
+		if values := md.Get(MetadataAuthorization); len(values) > 0 {
+			token, found := strings.CutPrefix(values[0], "Bearer ")
+			if found {
+				return a.findByJwt(token)
+			}
+		}
+	}
+	return nil
+}
+
+func (a *Authenticator) authorize(ctx context.Context, fullMethod string) (context.Context, error) {
+	if !a.config.Enable {
+		return audit.WithCaller(ctx, audit.AnonymousCaller), nil
+	}
+	caller := a.identify(ctx)
+	if caller == nil {
+		a.logger.Warnf("reject unauthenticated call to %s", fullMethod)
+		return nil, status.Error(codes.Unauthenticated, "invalid or missing credentials")
+	}
+	method := path.Base(fullMethod)
+	if !slices.Contains(caller.Methods, AllowAllMethods) && !slices.Contains(caller.Methods, method) {
+		a.logger.Warnf("reject call to %s from %s, method not allowed", method, caller.Name)
+		return nil, status.Errorf(codes.PermissionDenied, "caller %s is not allowed to call %s", caller.Name, method)
+	}
+	return audit.WithCaller(ctx, caller.Name), nil
+}
+
+func (a *Authenticator) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := a.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+type callerServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *callerServerStream) Context() context.Context { return s.ctx }
+
+func (a *Authenticator) StreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authorize(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &callerServerStream{ServerStream: ss, ctx: ctx})
+}
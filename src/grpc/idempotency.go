@@ -0,0 +1,91 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package grpc
+package grpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"email-service/src/interfaces/audit"
+	"email-service/src/interfaces/idempotency"
+	"encoding/hex"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// MetadataIdempotentReplayed 重复请求直接返回首次结果时在响应头中携带该元数据
+const MetadataIdempotentReplayed = "x-idempotent-replayed"
+
+type idempotentRequest interface {
+	GetIdempotencyKey() string
+}
+
+type idempotentResult struct {
+	response any
+	err      error
+}
+
+// Idempotency 为携带 idempotencyKey 的请求提供幂等保护
+// 幂等键按调用方与方法隔离, 需要放在 Authenticator 之后
+type Idempotency struct {
+	logger logger.Interface
+	store  idempotency.StoreInterface
+}
+
+func NewIdempotency(
+	lg logger.Interface,
+	store idempotency.StoreInterface,
+) *Idempotency {
+	return &Idempotency{
+		logger: logger.NewLoggerAdapter(lg, "grpc-idempotency"),
+		store:  store,
+	}
+}
+
+func (i *Idempotency) fingerprint(req any) string {
+	message, ok := req.(proto.Message)
+	if !ok {
+		return ""
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+	if err != nil {
+		i.logger.Errorf("fail to marshal request for fingerprint, %v", err)
+		return ""
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func (i *Idempotency) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	request, ok := req.(idempotentRequest)
+	if !ok || request.GetIdempotencyKey() == "" {
+		return handler(ctx, req)
+	}
+	key := audit.CallerFromContext(ctx) + ":" + info.FullMethod + ":" + request.GetIdempotencyKey()
+	result, replayed, err := i.store.Do(ctx, key, i.fingerprint(req), func() (any, bool) {
+		response, err := handler(ctx, req)
+		return &idempotentResult{response: response, err: err}, err == nil
+	})
+	if errors.Is(err, idempotency.ErrKeyReused) {
+		return nil, status.Error(codes.InvalidArgument, "idempotency key already used with different arguments")
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return nil, status.FromContextError(err).Err()
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+	if replayed {
+		i.logger.Infof("replay %s for idempotency key %s", info.FullMethod, request.GetIdempotencyKey())
+		_ = grpc.SetHeader(ctx, metadata.Pairs(MetadataIdempotentReplayed, "true"))
+	}
+	res := result.(*idempotentResult)
+	return res.response, res.err
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package grpc
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// chainUnary 将多个一元拦截器按顺序组合为一个, 第一个拦截器位于最外层
+func chainUnary(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, current := interceptors[i], next
+			next = func(ctx context.Context, req any) (any, error) {
+				return interceptor(ctx, req, info, current)
+			}
+		}
+		return next(ctx, req)
+	}
+}
+
+// chainStream 将多个流拦截器按顺序组合为一个, 第一个拦截器位于最外层
+func chainStream(interceptors ...grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, current := interceptors[i], next
+			next = func(srv any, ss grpc.ServerStream) error {
+				return interceptor(srv, ss, info, current)
+			}
+		}
+		return next(srv, ss)
+	}
+}
+
+// InterceptService 为单个服务挂载拦截器
+// gRPC服务器由 service-core 创建, 无法追加 ServerOption, 因此在服务描述上包装处理函数,
+// 服务器级别的拦截器(如链路追踪)仍位于最外层
+func InterceptService(
+	desc *grpc.ServiceDesc,
+	unary []grpc.UnaryServerInterceptor,
+	stream []grpc.StreamServerInterceptor,
+) *grpc.ServiceDesc {
+	wrapped := *desc
+	unaryInterceptor := chainUnary(unary...)
+	streamInterceptor := chainStream(stream...)
+
+	wrapped.Methods = make([]grpc.MethodDesc, len(desc.Methods))
+	for i, method := range desc.Methods {
+		handler := method.Handler
+		wrapped.Methods[i] = grpc.MethodDesc{
+			MethodName: method.MethodName,
+			Handler: func(srv any, ctx context.Context, dec func(any) error, outer grpc.UnaryServerInterceptor) (any, error) {
+				if outer == nil {
+					return handler(srv, ctx, dec, unaryInterceptor)
+				}
+				return handler(srv, ctx, dec, chainUnary(outer, unaryInterceptor))
+			},
+		}
+	}
+
+	wrapped.Streams = make([]grpc.StreamDesc, len(desc.Streams))
+	for i, stream := range desc.Streams {
+		handler := stream.Handler
+		fullMethod := "/" + desc.ServiceName + "/" + stream.StreamName
+		info := &grpc.StreamServerInfo{
+			FullMethod:     fullMethod,
+			IsClientStream: stream.ClientStreams,
+			IsServerStream: stream.ServerStreams,
+		}
+		wrapped.Streams[i] = stream
+		wrapped.Streams[i].Handler = func(srv any, ss grpc.ServerStream) error {
+			return streamInterceptor(srv, ss, info, handler)
+		}
+	}
+	return &wrapped
+}
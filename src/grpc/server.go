@@ -6,14 +6,21 @@ package grpc
 
 import (
 	"context"
+	"email-service/src/interfaces/audit"
 	"email-service/src/interfaces/config"
 	"email-service/src/interfaces/email"
 	pb "email-service/src/interfaces/grpc"
+	"email-service/src/interfaces/render"
 	"errors"
+	"fmt"
 	"reflect"
+	"strings"
+	"time"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"half-nothing.cn/service-core/interfaces/logger"
 )
 
@@ -24,20 +31,33 @@ var (
 
 type EmailServer struct {
 	pb.UnimplementedEmailServer
-	logger  logger.Interface
-	sender  email.SenderInterface
-	manager email.CodeManagerInterface
+	logger   logger.Interface
+	config   *config.EmailConfig
+	sender   email.SenderInterface
+	manager  email.CodeManagerInterface
+	recorder audit.RecorderInterface
+	async    email.AsyncSenderInterface
+	// 沙盒收件箱, 未启用沙盒模式时为nil
+	sandbox email.SandboxInterface
 }
 
 func NewEmailServer(
 	lg logger.Interface,
+	config *config.EmailConfig,
 	sender email.SenderInterface,
 	manager email.CodeManagerInterface,
+	recorder audit.RecorderInterface,
+	async email.AsyncSenderInterface,
+	sandbox email.SandboxInterface,
 ) *EmailServer {
 	return &EmailServer{
-		logger:  logger.NewLoggerAdapter(lg, "grpc-server"),
-		sender:  sender,
-		manager: manager,
+		logger:   logger.NewLoggerAdapter(lg, "grpc-server"),
+		config:   config,
+		sender:   sender,
+		manager:  manager,
+		recorder: recorder,
+		async:    async,
+		sandbox:  sandbox,
 	}
 }
 
@@ -51,6 +71,15 @@ func (e *EmailServer) handleSendError(err error) error {
 	if errors.Is(err, email.ErrEmailNotRegistered) {
 		return status.Error(codes.InvalidArgument, "invalid email type")
 	}
+	if errors.Is(err, email.ErrRecipientInvalid) || errors.Is(err, email.ErrNoRecipient) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, email.ErrEmailSuppressed) {
+		return status.Error(codes.FailedPrecondition, "target email address is suppressed")
+	}
+	if errors.Is(err, email.ErrEmailOptedOut) {
+		return status.Error(codes.FailedPrecondition, "recipient opted out of this type of email")
+	}
 	if errors.Is(err, email.ErrEmailDataInvalid) {
 		return status.Error(codes.Internal, "internal server error")
 	}
@@ -70,65 +99,167 @@ func (e *EmailServer) extractAndValidateFields(msg interface{}) bool {
 	return true
 }
 
-func (e *EmailServer) sendEmailTemplate(emailType config.Email, targetEmail string, data interface{}) (*pb.SendResponse, error) {
-	e.logger.Infof("send %s email to %s with arguments %#v", emailType.Value, targetEmail, data)
-	if err := e.sender.SendEmail(emailType, targetEmail, data); err != nil {
+// timeOf 优先使用时间戳, 兼容旧版本调用方传入的时间文本, 两者都未设置或时间戳超出范围时返回InvalidArgument
+func (e *EmailServer) timeOf(timestamp *timestamppb.Timestamp, legacy *string) (*render.Time, error) {
+	if timestamp != nil {
+		if err := timestamp.CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid timestamp: %s", err.Error())
+		}
+		return render.NewTime(timestamp.AsTime()), nil
+	}
+	if legacy == nil || *legacy == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required argument")
+	}
+	return render.ParseTime(*legacy, e.config.Location), nil
+}
+
+// recipients 合并targetEmail与额外的收件人
+func (e *EmailServer) recipients(targetEmail string, to []string, cc []string, bcc []string) *email.Recipients {
+	return &email.Recipients{
+		To:  append([]string{targetEmail}, to...),
+		Cc:  cc,
+		Bcc: bcc,
+	}
+}
+
+func (e *EmailServer) record(caller string, method string, emailType config.Email, recipients *email.Recipients, data interface{}, err error) {
+	record := &audit.Record{
+		Caller:    caller,
+		Method:    method,
+		EmailType: emailType.Value,
+		To:        recipients.To,
+		Cc:        recipients.Cc,
+		Bcc:       recipients.Bcc,
+		Data:      email.Redact(data),
+		Success:   err == nil,
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	e.recorder.Record(record)
+}
+
+func (e *EmailServer) sendEmailTemplate(ctx context.Context, emailType config.Email, recipients *email.Recipients, data interface{}) (*pb.SendResponse, error) {
+	caller := audit.CallerFromContext(ctx)
+	method, _ := grpc.Method(ctx)
+	e.logger.Infof("[%s] send %s email to %s with arguments %#v", caller, emailType.Value, strings.Join(recipients.To, ","), email.Redact(data))
+	err := e.sender.SendEmail(emailType, recipients, data)
+	e.record(caller, method, emailType, recipients, data, err)
+	if err != nil {
 		return FailedResponse, e.handleSendError(err)
 	}
 	return SuccessResponse, nil
 }
 
-func (e *EmailServer) SendActivityAtcJoin(_ context.Context, d *pb.ActivityAtcJoin) (*pb.SendResponse, error) {
+// enqueueEmailTemplate 将邮件放入异步发送队列, 审计记录在发送结束后写入
+func (e *EmailServer) enqueueEmailTemplate(ctx context.Context, emailType config.Email, recipients *email.Recipients, data interface{}) (*pb.SendResponse, error) {
+	caller := audit.CallerFromContext(ctx)
+	method, _ := grpc.Method(ctx)
+	e.logger.Infof("[%s] enqueue %s email to %s with arguments %#v", caller, emailType.Value, strings.Join(recipients.To, ","), email.Redact(data))
+	messageStatus, err := e.async.Enqueue(emailType, recipients, data, func(err error) {
+		e.record(caller, method, emailType, recipients, data, err)
+	})
+	if errors.Is(err, email.ErrSendQueueFull) {
+		return FailedResponse, status.Error(codes.ResourceExhausted, "send queue is full")
+	}
+	if err != nil {
+		return FailedResponse, status.Error(codes.Internal, "internal server error")
+	}
+	return &pb.SendResponse{Success: true, MessageId: messageStatus.Id}, nil
+}
+
+// enqueueEmailTemplates 为每组收件人分别放入一封邮件, 队列空间不足时一封也不放入
+func (e *EmailServer) enqueueEmailTemplates(ctx context.Context, emailType config.Email, recipients []*email.Recipients, data interface{}) (*pb.SendResponse, error) {
+	caller := audit.CallerFromContext(ctx)
+	method, _ := grpc.Method(ctx)
+	e.logger.Infof("[%s] enqueue %d %s emails with arguments %#v", caller, len(recipients), emailType.Value, email.Redact(data))
+	statuses, err := e.async.EnqueueAll(emailType, recipients, data, func(recipients *email.Recipients, err error) {
+		e.record(caller, method, emailType, recipients, data, err)
+	})
+	if errors.Is(err, email.ErrSendQueueFull) {
+		return FailedResponse, status.Error(codes.ResourceExhausted, "send queue is full")
+	}
+	if err != nil {
+		return FailedResponse, status.Error(codes.Internal, "internal server error")
+	}
+	messageIds := make([]string, 0, len(statuses))
+	for _, messageStatus := range statuses {
+		messageIds = append(messageIds, messageStatus.Id)
+	}
+	return &pb.SendResponse{Success: true, MessageId: messageIds[0], MessageIds: messageIds}, nil
+}
+
+// dispatchEmailTemplate 根据请求选择同步发送或异步发送
+func (e *EmailServer) dispatchEmailTemplate(ctx context.Context, async bool, emailType config.Email, recipients *email.Recipients, data interface{}) (*pb.SendResponse, error) {
+	if async {
+		return e.enqueueEmailTemplate(ctx, emailType, recipients, data)
+	}
+	return e.sendEmailTemplate(ctx, emailType, recipients, data)
+}
+
+func (e *EmailServer) SendActivityAtcJoin(ctx context.Context, d *pb.ActivityAtcJoin) (*pb.SendResponse, error) {
 	if !e.extractAndValidateFields(d) {
 		return nil, status.Error(codes.InvalidArgument, "missing required argument")
 	}
+	activityTime, err := e.timeOf(d.ActivityTimeAt, d.ActivityTime)
+	if err != nil {
+		return nil, err
+	}
 	data := &email.ActivityAtcJoinEmail{
 		Cid:          d.Cid,
+		ActivityId:   d.GetActivityId(),
 		ActivityName: d.ActivityName,
-		ActivityTime: d.ActivityTime,
+		ActivityTime: activityTime,
 		Facility:     d.Facility,
 		Frequency:    d.Frequency,
 	}
-	return e.sendEmailTemplate(config.EmailActivityAtcJoin, d.TargetEmail, data)
+	return e.dispatchEmailTemplate(ctx, d.Async, config.EmailActivityAtcJoin, e.recipients(d.TargetEmail, d.To, d.Cc, d.Bcc), data)
 }
 
-func (e *EmailServer) SendActivityAtcLeave(_ context.Context, d *pb.ActivityAtcLeave) (*pb.SendResponse, error) {
+func (e *EmailServer) SendActivityAtcLeave(ctx context.Context, d *pb.ActivityAtcLeave) (*pb.SendResponse, error) {
 	if !e.extractAndValidateFields(d) {
 		return nil, status.Error(codes.InvalidArgument, "missing required argument")
 	}
 	data := &email.ActivityAtcLeaveEmail{
 		Cid:          d.Cid,
+		ActivityId:   d.GetActivityId(),
 		ActivityName: d.ActivityName,
 	}
-	return e.sendEmailTemplate(config.EmailActivityAtcLeave, d.TargetEmail, data)
+	return e.dispatchEmailTemplate(ctx, d.Async, config.EmailActivityAtcLeave, e.recipients(d.TargetEmail, d.To, d.Cc, d.Bcc), data)
 }
 
-func (e *EmailServer) SendActivityPilotJoin(_ context.Context, d *pb.ActivityPilotJoin) (*pb.SendResponse, error) {
+func (e *EmailServer) SendActivityPilotJoin(ctx context.Context, d *pb.ActivityPilotJoin) (*pb.SendResponse, error) {
 	if !e.extractAndValidateFields(d) {
 		return nil, status.Error(codes.InvalidArgument, "missing required argument")
 	}
+	activityTime, err := e.timeOf(d.ActivityTimeAt, d.ActivityTime)
+	if err != nil {
+		return nil, err
+	}
 	data := &email.ActivityPilotJoinEmail{
 		Cid:          d.Cid,
+		ActivityId:   d.GetActivityId(),
 		ActivityName: d.ActivityName,
-		ActivityTime: d.ActivityTime,
+		ActivityTime: activityTime,
 		Aircraft:     d.Aircraft,
 		Callsign:     d.Callsign,
 	}
-	return e.sendEmailTemplate(config.EmailActivityPilotJoin, d.TargetEmail, data)
+	return e.dispatchEmailTemplate(ctx, d.Async, config.EmailActivityPilotJoin, e.recipients(d.TargetEmail, d.To, d.Cc, d.Bcc), data)
 }
 
-func (e *EmailServer) SendActivityPilotLeave(_ context.Context, d *pb.ActivityPilotLeave) (*pb.SendResponse, error) {
+func (e *EmailServer) SendActivityPilotLeave(ctx context.Context, d *pb.ActivityPilotLeave) (*pb.SendResponse, error) {
 	if !e.extractAndValidateFields(d) {
 		return nil, status.Error(codes.InvalidArgument, "missing required argument")
 	}
 	data := &email.ActivityPilotLeaveEmail{
 		Cid:          d.Cid,
+		ActivityId:   d.GetActivityId(),
 		ActivityName: d.ActivityName,
 	}
-	return e.sendEmailTemplate(config.EmailActivityPilotLeave, d.TargetEmail, data)
+	return e.dispatchEmailTemplate(ctx, d.Async, config.EmailActivityPilotLeave, e.recipients(d.TargetEmail, d.To, d.Cc, d.Bcc), data)
 }
 
-func (e *EmailServer) SendApplicationPassed(_ context.Context, d *pb.ApplicationPassed) (*pb.SendResponse, error) {
+func (e *EmailServer) SendApplicationPassed(ctx context.Context, d *pb.ApplicationPassed) (*pb.SendResponse, error) {
 	if !e.extractAndValidateFields(d) {
 		return nil, status.Error(codes.InvalidArgument, "missing required argument")
 	}
@@ -138,22 +269,26 @@ func (e *EmailServer) SendApplicationPassed(_ context.Context, d *pb.Application
 		Message:  d.Message,
 		Operator: d.Operator,
 	}
-	return e.sendEmailTemplate(config.EmailApplicationPassed, d.TargetEmail, data)
+	return e.dispatchEmailTemplate(ctx, d.Async, config.EmailApplicationPassed, e.recipients(d.TargetEmail, d.To, d.Cc, d.Bcc), data)
 }
 
-func (e *EmailServer) SendApplicationProcessing(_ context.Context, d *pb.ApplicationProcessing) (*pb.SendResponse, error) {
+func (e *EmailServer) SendApplicationProcessing(ctx context.Context, d *pb.ApplicationProcessing) (*pb.SendResponse, error) {
 	if !e.extractAndValidateFields(d) {
 		return nil, status.Error(codes.InvalidArgument, "missing required argument")
 	}
+	at, err := e.timeOf(d.TimeAt, d.Time)
+	if err != nil {
+		return nil, err
+	}
 	data := &email.ApplicationProcessingEmail{
 		Cid:     d.Cid,
 		Contact: d.Contact,
-		Time:    d.Time,
+		Time:    at,
 	}
-	return e.sendEmailTemplate(config.EmailApplicationProcessing, d.TargetEmail, data)
+	return e.dispatchEmailTemplate(ctx, d.Async, config.EmailApplicationProcessing, e.recipients(d.TargetEmail, d.To, d.Cc, d.Bcc), data)
 }
 
-func (e *EmailServer) SendApplicationRejected(_ context.Context, d *pb.ApplicationRejected) (*pb.SendResponse, error) {
+func (e *EmailServer) SendApplicationRejected(ctx context.Context, d *pb.ApplicationRejected) (*pb.SendResponse, error) {
 	if !e.extractAndValidateFields(d) {
 		return nil, status.Error(codes.InvalidArgument, "missing required argument")
 	}
@@ -163,10 +298,10 @@ func (e *EmailServer) SendApplicationRejected(_ context.Context, d *pb.Applicati
 		Operator: d.Operator,
 		Reason:   d.Reason,
 	}
-	return e.sendEmailTemplate(config.EmailApplicationRejected, d.TargetEmail, data)
+	return e.dispatchEmailTemplate(ctx, d.Async, config.EmailApplicationRejected, e.recipients(d.TargetEmail, d.To, d.Cc, d.Bcc), data)
 }
 
-func (e *EmailServer) SendAtcRatingChange(_ context.Context, d *pb.AtcRatingChange) (*pb.SendResponse, error) {
+func (e *EmailServer) SendAtcRatingChange(ctx context.Context, d *pb.AtcRatingChange) (*pb.SendResponse, error) {
 	if !e.extractAndValidateFields(d) {
 		return nil, status.Error(codes.InvalidArgument, "missing required argument")
 	}
@@ -177,24 +312,28 @@ func (e *EmailServer) SendAtcRatingChange(_ context.Context, d *pb.AtcRatingChan
 		OldValue: d.OldValue,
 		Operator: d.Operator,
 	}
-	return e.sendEmailTemplate(config.EmailRatingChange, d.TargetEmail, data)
+	return e.dispatchEmailTemplate(ctx, d.Async, config.EmailRatingChange, e.recipients(d.TargetEmail, d.To, d.Cc, d.Bcc), data)
 }
 
-func (e *EmailServer) SendBanned(_ context.Context, d *pb.Banned) (*pb.SendResponse, error) {
+func (e *EmailServer) SendBanned(ctx context.Context, d *pb.Banned) (*pb.SendResponse, error) {
 	if !e.extractAndValidateFields(d) {
 		return nil, status.Error(codes.InvalidArgument, "missing required argument")
 	}
+	at, err := e.timeOf(d.TimeAt, d.Time)
+	if err != nil {
+		return nil, err
+	}
 	data := &email.BannedEmail{
 		Cid:      d.Cid,
 		Contact:  d.Contact,
 		Operator: d.Operator,
 		Reason:   d.Reason,
-		Time:     d.Time,
+		Time:     at,
 	}
-	return e.sendEmailTemplate(config.EmailBanned, d.TargetEmail, data)
+	return e.dispatchEmailTemplate(ctx, d.Async, config.EmailBanned, e.recipients(d.TargetEmail, d.To, d.Cc, d.Bcc), data)
 }
 
-func (e *EmailServer) SendUnbanned(_ context.Context, d *pb.Unbanned) (*pb.SendResponse, error) {
+func (e *EmailServer) SendUnbanned(ctx context.Context, d *pb.Unbanned) (*pb.SendResponse, error) {
 	if !e.extractAndValidateFields(d) {
 		return nil, status.Error(codes.InvalidArgument, "missing required argument")
 	}
@@ -203,10 +342,10 @@ func (e *EmailServer) SendUnbanned(_ context.Context, d *pb.Unbanned) (*pb.SendR
 		Contact:  d.Contact,
 		Operator: d.Operator,
 	}
-	return e.sendEmailTemplate(config.EmailUnbanned, d.TargetEmail, data)
+	return e.dispatchEmailTemplate(ctx, d.Async, config.EmailUnbanned, e.recipients(d.TargetEmail, d.To, d.Cc, d.Bcc), data)
 }
 
-func (e *EmailServer) SendInstructorChange(_ context.Context, d *pb.InstructorChange) (*pb.SendResponse, error) {
+func (e *EmailServer) SendInstructorChange(ctx context.Context, d *pb.InstructorChange) (*pb.SendResponse, error) {
 	if !e.extractAndValidateFields(d) {
 		return nil, status.Error(codes.InvalidArgument, "missing required argument")
 	}
@@ -217,50 +356,62 @@ func (e *EmailServer) SendInstructorChange(_ context.Context, d *pb.InstructorCh
 		Instructor: d.Instructor,
 		Operator:   d.Operator,
 	}
-	return e.sendEmailTemplate(config.EmailInstructorChange, d.TargetEmail, data)
+	return e.dispatchEmailTemplate(ctx, d.Async, config.EmailInstructorChange, e.recipients(d.TargetEmail, d.To, d.Cc, d.Bcc), data)
 }
 
-func (e *EmailServer) SendKickedFromServer(_ context.Context, d *pb.KickedFromServer) (*pb.SendResponse, error) {
+func (e *EmailServer) SendKickedFromServer(ctx context.Context, d *pb.KickedFromServer) (*pb.SendResponse, error) {
 	if !e.extractAndValidateFields(d) {
 		return nil, status.Error(codes.InvalidArgument, "missing required argument")
 	}
+	at, err := e.timeOf(d.TimeAt, d.Time)
+	if err != nil {
+		return nil, err
+	}
 	data := &email.KickedFromServerEmail{
 		Cid:      d.Cid,
 		Contact:  d.Contact,
 		Operator: d.Operator,
 		Reason:   d.Reason,
-		Time:     d.Time,
+		Time:     at,
 	}
-	return e.sendEmailTemplate(config.EmailKickedFromServer, d.TargetEmail, data)
+	return e.dispatchEmailTemplate(ctx, d.Async, config.EmailKickedFromServer, e.recipients(d.TargetEmail, d.To, d.Cc, d.Bcc), data)
 }
 
-func (e *EmailServer) SendPasswordChange(_ context.Context, d *pb.PasswordChange) (*pb.SendResponse, error) {
+func (e *EmailServer) SendPasswordChange(ctx context.Context, d *pb.PasswordChange) (*pb.SendResponse, error) {
 	if !e.extractAndValidateFields(d) {
 		return nil, status.Error(codes.InvalidArgument, "missing required argument")
 	}
+	at, err := e.timeOf(d.TimeAt, d.Time)
+	if err != nil {
+		return nil, err
+	}
 	data := &email.PasswordChangeEmail{
 		Cid:       d.Cid,
-		Time:      d.Time,
+		Time:      at,
 		IP:        d.Ip,
 		UserAgent: d.UserAgent,
 	}
-	return e.sendEmailTemplate(config.EmailPasswordChange, d.TargetEmail, data)
+	return e.dispatchEmailTemplate(ctx, d.Async, config.EmailPasswordChange, e.recipients(d.TargetEmail, d.To, d.Cc, d.Bcc), data)
 }
 
-func (e *EmailServer) SendPasswordReset(_ context.Context, d *pb.PasswordReset) (*pb.SendResponse, error) {
+func (e *EmailServer) SendPasswordReset(ctx context.Context, d *pb.PasswordReset) (*pb.SendResponse, error) {
 	if !e.extractAndValidateFields(d) {
 		return nil, status.Error(codes.InvalidArgument, "missing required argument")
 	}
+	at, err := e.timeOf(d.TimeAt, d.Time)
+	if err != nil {
+		return nil, err
+	}
 	data := &email.PasswordResetEmail{
 		Cid:       d.Cid,
-		Time:      d.Time,
+		Time:      at,
 		IP:        d.Ip,
 		UserAgent: d.UserAgent,
 	}
-	return e.sendEmailTemplate(config.EmailPasswordReset, d.TargetEmail, data)
+	return e.dispatchEmailTemplate(ctx, d.Async, config.EmailPasswordReset, e.recipients(d.TargetEmail, d.To, d.Cc, d.Bcc), data)
 }
 
-func (e *EmailServer) SendPermissionChange(_ context.Context, d *pb.PermissionChange) (*pb.SendResponse, error) {
+func (e *EmailServer) SendPermissionChange(ctx context.Context, d *pb.PermissionChange) (*pb.SendResponse, error) {
 	if !e.extractAndValidateFields(d) {
 		return nil, status.Error(codes.InvalidArgument, "missing required argument")
 	}
@@ -270,11 +421,11 @@ func (e *EmailServer) SendPermissionChange(_ context.Context, d *pb.PermissionCh
 		Operator:    d.Operator,
 		Contact:     d.Contact,
 	}
-	return e.sendEmailTemplate(config.EmailPermissionChange, d.TargetEmail, data)
+	return e.dispatchEmailTemplate(ctx, d.Async, config.EmailPermissionChange, e.recipients(d.TargetEmail, d.To, d.Cc, d.Bcc), data)
 }
 
-func (e *EmailServer) SendRoleChange(_ context.Context, d *pb.RoleChange) (*pb.SendResponse, error) {
-	if !e.extractAndValidateFields(d) {
+func (e *EmailServer) SendRoleChange(ctx context.Context, d *pb.RoleChange) (*pb.SendResponse, error) {
+	if !e.extractAndValidateFields(d) || len(d.TargetEmail) == 0 {
 		return nil, status.Error(codes.InvalidArgument, "missing required argument")
 	}
 	data := &email.RoleChangeEmail{
@@ -283,49 +434,67 @@ func (e *EmailServer) SendRoleChange(_ context.Context, d *pb.RoleChange) (*pb.S
 		Operator: d.Operator,
 		Contact:  d.Contact,
 	}
-	for _, dest := range d.TargetEmail {
-		res, err := e.sendEmailTemplate(config.EmailRoleChange, dest, data)
-		if err != nil {
+	// 每个收件人单独发送, 避免互相看到邮箱地址, 抄送与密送只随第一封邮件发送
+	recipients := make([]*email.Recipients, 0, len(d.TargetEmail))
+	for index, dest := range d.TargetEmail {
+		target := email.NewRecipients(dest)
+		if index == 0 {
+			target.Cc = d.Cc
+			target.Bcc = d.Bcc
+		}
+		recipients = append(recipients, target)
+	}
+	if d.Async {
+		// 一次放入所有邮件, 队列空间不足时整体拒绝, 调用方重试不会重复发送已入队的邮件
+		return e.enqueueEmailTemplates(ctx, config.EmailRoleChange, recipients, data)
+	}
+	for _, target := range recipients {
+		if res, err := e.sendEmailTemplate(ctx, config.EmailRoleChange, target, data); err != nil {
 			return res, err
 		}
 	}
 	return SuccessResponse, nil
 }
 
-func (e *EmailServer) SendTicketReply(_ context.Context, d *pb.TicketReply) (*pb.SendResponse, error) {
+func (e *EmailServer) SendTicketReply(ctx context.Context, d *pb.TicketReply) (*pb.SendResponse, error) {
 	if !e.extractAndValidateFields(d) {
 		return nil, status.Error(codes.InvalidArgument, "missing required argument")
 	}
 	data := &email.TicketReplyEmail{
-		Cid:   d.Cid,
-		Reply: d.Reply,
-		Title: d.Title,
+		Cid:      d.Cid,
+		Reply:    d.Reply,
+		Title:    d.Title,
+		TicketId: d.GetTicketId(),
 	}
-	return e.sendEmailTemplate(config.EmailTicketReply, d.TargetEmail, data)
+	return e.dispatchEmailTemplate(ctx, d.Async, config.EmailTicketReply, e.recipients(d.TargetEmail, d.To, d.Cc, d.Bcc), data)
 }
 
-func (e *EmailServer) SendWelcome(_ context.Context, d *pb.Welcome) (*pb.SendResponse, error) {
+func (e *EmailServer) SendWelcome(ctx context.Context, d *pb.Welcome) (*pb.SendResponse, error) {
 	if !e.extractAndValidateFields(d) {
 		return nil, status.Error(codes.InvalidArgument, "missing required argument")
 	}
 	data := &email.WelcomeEmail{
 		Cid: d.Cid,
 	}
-	return e.sendEmailTemplate(config.EmailWelcome, d.TargetEmail, data)
+	return e.dispatchEmailTemplate(ctx, d.Async, config.EmailWelcome, e.recipients(d.TargetEmail, d.To, d.Cc, d.Bcc), data)
 }
 
-func (e *EmailServer) SendEmailChange(_ context.Context, d *pb.EmailChange) (*pb.SendResponse, error) {
+func (e *EmailServer) SendEmailChange(ctx context.Context, d *pb.EmailChange) (*pb.SendResponse, error) {
 	if !e.extractAndValidateFields(d) {
 		return nil, status.Error(codes.InvalidArgument, "missing required argument")
 	}
+	at, err := e.timeOf(d.TimeAt, d.Time)
+	if err != nil {
+		return nil, err
+	}
 	data := &email.ChangeEmail{
 		Cid:       d.Cid,
 		Email:     d.Email,
-		Time:      d.Time,
+		Time:      at,
 		IP:        d.Ip,
 		UserAgent: d.UserAgent,
 	}
-	return e.sendEmailTemplate(config.EmailEmailChange, d.TargetEmail, data)
+	return e.dispatchEmailTemplate(ctx, d.Async, config.EmailEmailChange, e.recipients(d.TargetEmail, d.To, d.Cc, d.Bcc), data)
 }
 
 const (
@@ -335,7 +504,7 @@ const (
 	VerifyUnknown
 )
 
-func (e *EmailServer) VerifyEmailCode(_ context.Context, d *pb.VerifyCode) (*pb.VerifyResponse, error) {
+func (e *EmailServer) VerifyEmailCode(ctx context.Context, d *pb.VerifyCode) (*pb.VerifyResponse, error) {
 	if !e.extractAndValidateFields(d) {
 		return nil, status.Error(codes.InvalidArgument, "missing required argument")
 	}
@@ -352,7 +521,7 @@ func (e *EmailServer) VerifyEmailCode(_ context.Context, d *pb.VerifyCode) (*pb.
 	return &pb.VerifyResponse{Success: false, Code: VerifyUnknown}, status.Error(codes.Internal, "failed to verify email d")
 }
 
-func (e *EmailServer) RemoveEmailCode(_ context.Context, d *pb.RemoveVerifyCode) (*pb.RemoveVerifyCodeResponse, error) {
+func (e *EmailServer) RemoveEmailCode(ctx context.Context, d *pb.RemoveVerifyCode) (*pb.RemoveVerifyCodeResponse, error) {
 	if !e.extractAndValidateFields(d) {
 		return nil, status.Error(codes.InvalidArgument, "missing required argument")
 	}
@@ -360,3 +529,242 @@ func (e *EmailServer) RemoveEmailCode(_ context.Context, d *pb.RemoveVerifyCode)
 	e.manager.RemoveEmailCode(d.Email)
 	return &pb.RemoveVerifyCodeResponse{Success: true}, nil
 }
+
+func (e *EmailServer) StartEmailChange(ctx context.Context, d *pb.StartEmailChangeRequest) (*pb.StartEmailChangeResponse, error) {
+	if !e.extractAndValidateFields(d) {
+		return nil, status.Error(codes.InvalidArgument, "missing required argument")
+	}
+	if strings.EqualFold(d.OldEmail, d.NewEmail) {
+		return nil, status.Error(codes.InvalidArgument, "new email is the same as old email")
+	}
+	request, cooldown, err := e.manager.StartEmailChange(d.Cid, d.OldEmail, d.NewEmail)
+	if errors.Is(err, email.ErrEmailCodeCooldown) {
+		return nil, status.Errorf(codes.ResourceExhausted, "email code already sent, retry after %.0f seconds", cooldown.Seconds())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	expire := e.config.EmailChange.CodeExpireDuration
+	verifyData := &email.EmailChangeVerifyEmail{
+		Cid:       request.Cid,
+		Email:     request.NewEmail,
+		Code:      request.Code,
+		Expired:   fmt.Sprintf("%.0f", expire.Minutes()),
+		ExpiredAt: request.ExpireAt.Format(time.RFC3339),
+	}
+	if _, err := e.sendEmailTemplate(ctx, config.EmailEmailChangeVerify, email.NewRecipients(request.NewEmail), verifyData); err != nil {
+		_, _ = e.manager.CancelEmailChange(request.CancelToken)
+		return &pb.StartEmailChangeResponse{Success: false}, err
+	}
+	noticeData := &email.ChangeEmail{
+		Cid:       request.Cid,
+		Email:     request.NewEmail,
+		Time:      render.NewTime(time.Now()),
+		IP:        d.Ip,
+		UserAgent: d.UserAgent,
+		Pending:   true,
+		CancelUrl: e.config.EmailChange.BuildCancelUrl(request.CancelToken),
+		Deadline:  render.NewTime(request.ExpireAt),
+	}
+	if _, err := e.sendEmailTemplate(ctx, config.EmailEmailChange, email.NewRecipients(request.OldEmail), noticeData); err != nil {
+		e.logger.Errorf("fail to notify old address of %s email change, %v", request.Cid, err)
+	}
+	return &pb.StartEmailChangeResponse{Success: true, ExpireAt: request.ExpireAt.UnixMilli()}, nil
+}
+
+func (e *EmailServer) ConfirmEmailChange(ctx context.Context, d *pb.ConfirmEmailChangeRequest) (*pb.ConfirmEmailChangeResponse, error) {
+	if !e.extractAndValidateFields(d) {
+		return nil, status.Error(codes.InvalidArgument, "missing required argument")
+	}
+	request, err := e.manager.ConfirmEmailChange(d.Cid, d.Code)
+	if err != nil {
+		if errors.Is(err, email.ErrEmailChangeNotFound) {
+			return &pb.ConfirmEmailChangeResponse{Success: false}, status.Error(codes.NotFound, "email change request not found or expired")
+		}
+		if errors.Is(err, email.ErrEmailChangeTooManyTries) {
+			return &pb.ConfirmEmailChangeResponse{Success: false}, status.Error(codes.ResourceExhausted, "too many invalid attempts, email change cancelled")
+		}
+		if errors.Is(err, email.ErrEmailCodeInvalid) {
+			return &pb.ConfirmEmailChangeResponse{Success: false}, status.Error(codes.InvalidArgument, "invalid code")
+		}
+		return &pb.ConfirmEmailChangeResponse{Success: false}, status.Error(codes.Internal, "internal server error")
+	}
+
+	// 变更已生效, 旧邮箱通知发送失败不影响结果, 失败记录在审计日志中
+	noticeData := &email.ChangeEmail{
+		Cid:       request.Cid,
+		Email:     request.NewEmail,
+		Time:      render.NewTime(time.Now()),
+		CancelUrl: e.config.EmailChange.BuildCancelUrl(request.CancelToken),
+		Deadline:  render.NewTime(request.RevertDeadline),
+	}
+	if _, err := e.sendEmailTemplate(ctx, config.EmailEmailChange, email.NewRecipients(request.OldEmail), noticeData); err != nil {
+		e.logger.Errorf("fail to notify old address of %s email change, %v", request.Cid, err)
+	}
+	return &pb.ConfirmEmailChangeResponse{
+		Success:        true,
+		OldEmail:       request.OldEmail,
+		NewEmail:       request.NewEmail,
+		RevertDeadline: request.RevertDeadline.UnixMilli(),
+	}, nil
+}
+
+func (e *EmailServer) CancelEmailChange(_ context.Context, d *pb.CancelEmailChangeRequest) (*pb.CancelEmailChangeResponse, error) {
+	if !e.extractAndValidateFields(d) {
+		return nil, status.Error(codes.InvalidArgument, "missing required argument")
+	}
+	request, err := e.manager.CancelEmailChange(d.Token)
+	if err != nil {
+		if errors.Is(err, email.ErrEmailChangeNotFound) {
+			return &pb.CancelEmailChangeResponse{Success: false}, status.Error(codes.NotFound, "email change request not found or expired")
+		}
+		if errors.Is(err, email.ErrEmailChangeRevertExpired) {
+			return &pb.CancelEmailChangeResponse{Success: false}, status.Error(codes.FailedPrecondition, "email change can no longer be reverted")
+		}
+		return &pb.CancelEmailChangeResponse{Success: false}, status.Error(codes.Internal, "internal server error")
+	}
+	return &pb.CancelEmailChangeResponse{
+		Success:  true,
+		Reverted: request.Confirmed,
+		Cid:      request.Cid,
+		OldEmail: request.OldEmail,
+		NewEmail: request.NewEmail,
+	}, nil
+}
+
+func (e *EmailServer) handleResetTokenError(err error) error {
+	if errors.Is(err, email.ErrResetTokenNotFound) {
+		return status.Error(codes.NotFound, "reset token not found or expired")
+	}
+	if errors.Is(err, email.ErrResetTokenInvalid) {
+		return status.Error(codes.InvalidArgument, "invalid reset token")
+	}
+	if errors.Is(err, email.ErrResetTokenLocked) {
+		return status.Error(codes.ResourceExhausted, "too many invalid attempts, reset token revoked")
+	}
+	return status.Error(codes.Internal, "internal server error")
+}
+
+func (e *EmailServer) IssueResetToken(ctx context.Context, d *pb.IssueResetTokenRequest) (*pb.IssueResetTokenResponse, error) {
+	if !e.extractAndValidateFields(d) {
+		return nil, status.Error(codes.InvalidArgument, "missing required argument")
+	}
+	token, resetToken, cooldown, err := e.manager.IssueResetToken(d.Cid, d.Email)
+	if errors.Is(err, email.ErrEmailCodeCooldown) {
+		return nil, status.Errorf(codes.ResourceExhausted, "reset email already sent, retry after %.0f seconds", cooldown.Seconds())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+	data := &email.PasswordResetEmail{
+		Cid:       d.Cid,
+		Time:      render.NewTime(time.Now()),
+		IP:        d.Ip,
+		UserAgent: d.UserAgent,
+		ResetUrl:  e.config.PasswordReset.BuildResetUrl(d.Cid, token),
+		Expired:   fmt.Sprintf("%.0f", e.config.PasswordReset.TokenExpireDuration.Minutes()),
+		ExpiredAt: render.NewTime(resetToken.ExpireAt),
+	}
+	if _, err := e.sendEmailTemplate(ctx, config.EmailPasswordReset, email.NewRecipients(resetToken.Email), data); err != nil {
+		// 用户收不到重置链接, 令牌不应继续有效
+		e.manager.RevokeResetToken(d.Cid)
+		return &pb.IssueResetTokenResponse{Success: false}, err
+	}
+	return &pb.IssueResetTokenResponse{Success: true, ExpireAt: resetToken.ExpireAt.UnixMilli()}, nil
+}
+
+func (e *EmailServer) ValidateResetToken(_ context.Context, d *pb.ResetTokenRequest) (*pb.ResetTokenResponse, error) {
+	if !e.extractAndValidateFields(d) {
+		return nil, status.Error(codes.InvalidArgument, "missing required argument")
+	}
+	resetToken, err := e.manager.ValidateResetToken(d.Cid, d.Email, d.Token)
+	if err != nil {
+		return &pb.ResetTokenResponse{Valid: false}, e.handleResetTokenError(err)
+	}
+	return &pb.ResetTokenResponse{Valid: true, Email: resetToken.Email, ExpireAt: resetToken.ExpireAt.UnixMilli()}, nil
+}
+
+func (e *EmailServer) ConsumeResetToken(_ context.Context, d *pb.ResetTokenRequest) (*pb.ResetTokenResponse, error) {
+	if !e.extractAndValidateFields(d) {
+		return nil, status.Error(codes.InvalidArgument, "missing required argument")
+	}
+	resetToken, err := e.manager.ConsumeResetToken(d.Cid, d.Email, d.Token)
+	if err != nil {
+		return &pb.ResetTokenResponse{Valid: false}, e.handleResetTokenError(err)
+	}
+	return &pb.ResetTokenResponse{Valid: true, Email: resetToken.Email, ExpireAt: resetToken.ExpireAt.UnixMilli()}, nil
+}
+
+var messageStates = map[email.MessageState]pb.MessageState{
+	email.MessageQueued:  pb.MessageState_MESSAGE_STATE_QUEUED,
+	email.MessageSending: pb.MessageState_MESSAGE_STATE_SENDING,
+	email.MessageSent:    pb.MessageState_MESSAGE_STATE_SENT,
+	email.MessageFailed:  pb.MessageState_MESSAGE_STATE_FAILED,
+}
+
+func (e *EmailServer) messageStatus(s *email.MessageStatus) *pb.MessageStatus {
+	return &pb.MessageStatus{
+		MessageId: s.Id,
+		EmailType: s.EmailType,
+		State:     messageStates[s.State],
+		Error:     s.Error,
+		CreatedAt: s.CreatedAt.UnixMilli(),
+		UpdatedAt: s.UpdatedAt.UnixMilli(),
+	}
+}
+
+func (e *EmailServer) GetMessageStatus(_ context.Context, d *pb.MessageStatusRequest) (*pb.MessageStatus, error) {
+	if !e.extractAndValidateFields(d) {
+		return nil, status.Error(codes.InvalidArgument, "missing required argument")
+	}
+	messageStatus, ok := e.async.Status(d.MessageId)
+	if !ok {
+		return nil, status.Error(codes.NotFound, "message not found or expired")
+	}
+	return e.messageStatus(messageStatus), nil
+}
+
+func (e *EmailServer) WatchMessageStatus(d *pb.MessageStatusRequest, stream grpc.ServerStreamingServer[pb.MessageStatus]) error {
+	if !e.extractAndValidateFields(d) {
+		return status.Error(codes.InvalidArgument, "missing required argument")
+	}
+	updates, ok := e.async.Watch(stream.Context(), d.MessageId)
+	if !ok {
+		return status.Error(codes.NotFound, "message not found or expired")
+	}
+	for messageStatus := range updates {
+		if err := stream.Send(e.messageStatus(messageStatus)); err != nil {
+			return err
+		}
+	}
+	return stream.Context().Err()
+}
+
+func (e *EmailServer) ListCapturedEmails(_ context.Context, d *pb.ListCapturedEmailsRequest) (*pb.ListCapturedEmailsResponse, error) {
+	if e.sandbox == nil {
+		return nil, status.Error(codes.FailedPrecondition, "sandbox mode is not enabled")
+	}
+	if d.Limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit cannot be less than 0")
+	}
+	captured := e.sandbox.List(d.TargetEmail, int(d.Limit))
+	response := &pb.ListCapturedEmailsResponse{Emails: make([]*pb.CapturedEmail, 0, len(captured))}
+	for _, c := range captured {
+		headers := make(map[string]string, len(c.Headers))
+		for key, values := range c.Headers {
+			headers[key] = strings.Join(values, ", ")
+		}
+		response.Emails = append(response.Emails, &pb.CapturedEmail{
+			Id:      c.Id,
+			Time:    c.Time.UnixMilli(),
+			From:    c.From,
+			To:      c.To,
+			Subject: c.Subject,
+			Html:    c.Html,
+			Text:    c.Text,
+			Headers: headers,
+		})
+	}
+	return response, nil
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package health
+package health
+
+import (
+	"context"
+	"crypto/tls"
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/email"
+	"email-service/src/interfaces/health"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	CheckSmtp      = "smtp"
+	CheckTemplates = "templates"
+	CheckQueue     = "queue"
+)
+
+type Checker struct {
+	emailConfig  *config.EmailConfig
+	healthConfig *config.HealthConfig
+	sender       email.SenderInterface
+	asyncSender  email.AsyncSenderInterface
+	transports   []email.TransportInterface
+	// 上次SMTP检查的时间与结果, 检查只在监控协程中执行
+	smtpCheckedAt time.Time
+	smtpErr       error
+}
+
+func NewChecker(
+	emailConfig *config.EmailConfig,
+	healthConfig *config.HealthConfig,
+	sender email.SenderInterface,
+	asyncSender email.AsyncSenderInterface,
+	transports ...email.TransportInterface,
+) *Checker {
+	return &Checker{
+		emailConfig:  emailConfig,
+		healthConfig: healthConfig,
+		sender:       sender,
+		asyncSender:  asyncSender,
+		transports:   transports,
+	}
+}
+
+func (c *Checker) Check(ctx context.Context) *health.Report {
+	ctx, cancel := context.WithTimeout(ctx, c.healthConfig.TimeoutDuration)
+	defer cancel()
+	backlog := c.backlog()
+	report := &health.Report{
+		Healthy:      true,
+		Time:         time.Now(),
+		QueueBacklog: backlog,
+		Checks: map[string]*health.CheckResult{
+			CheckSmtp:      toResult(errors.Join(c.checkTransports(), c.cachedCheckSmtp(ctx))),
+			CheckTemplates: toResult(c.checkTemplates()),
+			CheckQueue:     toResult(c.checkQueue(backlog)),
+		},
+	}
+	for _, result := range report.Checks {
+		report.Healthy = report.Healthy && result.Healthy
+	}
+	return report
+}
+
+func toResult(err error) *health.CheckResult {
+	if err != nil {
+		return &health.CheckResult{Healthy: false, Message: err.Error()}
+	}
+	return &health.CheckResult{Healthy: true}
+}
+
+// checkTransports 检查发送通道中共享连接的状态, 新建连接探测成功不代表共享连接可用
+func (c *Checker) checkTransports() error {
+	errs := make([]error, 0, len(c.transports))
+	for _, transport := range c.transports {
+		if checker, ok := transport.(email.ConnectionCheckerInterface); ok {
+			errs = append(errs, checker.CheckConnection())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// cachedCheckSmtp 距上次SMTP检查不足配置的间隔时返回上次的结果, 避免每次检查都与SMTP服务器建立新的连接
+func (c *Checker) cachedCheckSmtp(ctx context.Context) error {
+	if !c.smtpCheckedAt.IsZero() && time.Since(c.smtpCheckedAt) < c.healthConfig.SmtpIntervalDuration {
+		return c.smtpErr
+	}
+	c.smtpErr = c.checkSmtp(ctx)
+	c.smtpCheckedAt = time.Now()
+	return c.smtpErr
+}
+
+// checkSmtp 建立一条新的SMTP连接并发送NOOP命令, 确认SMTP服务器可用
+func (c *Checker) checkSmtp(ctx context.Context) error {
+	if c.emailConfig.Server == nil {
+		// 沙盒模式或未使用SMTP发送通道
+		return nil
+	}
+	dialer := c.emailConfig.Server
+	address := net.JoinHostPort(dialer.Host, strconv.Itoa(dialer.Port))
+	tlsConfig := dialer.TLSConfig
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{ServerName: dialer.Host}
+	}
+
+	netDialer := &net.Dialer{}
+	conn, err := netDialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return fmt.Errorf("dial smtp server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	if dialer.SSL {
+		conn = tls.Client(conn, tlsConfig)
+	}
+	client, err := smtp.NewClient(conn, dialer.Host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("greet smtp server: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+	if err := client.Noop(); err != nil {
+		return fmt.Errorf("smtp noop: %w", err)
+	}
+	return client.Quit()
+}
+
+func (c *Checker) checkTemplates() error {
+	missing := make([]string, 0)
+	for _, emailType := range config.Emails {
+		if emailType.Data.Enabled() && emailType.Data.Template() == nil {
+			missing = append(missing, emailType.Value)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("templates not loaded: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// backlog 返回异步队列中等待的邮件与正在发送的邮件之和
+func (c *Checker) backlog() int {
+	return c.asyncSender.Pending() + c.sender.Pending()
+}
+
+func (c *Checker) checkQueue(backlog int) error {
+	if c.healthConfig.MaxBacklog == 0 {
+		return nil
+	}
+	if backlog > c.healthConfig.MaxBacklog {
+		return fmt.Errorf("queue backlog %d exceeds %d", backlog, c.healthConfig.MaxBacklog)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package health
+package health
+
+import (
+	"context"
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/email"
+	"email-service/src/interfaces/health"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gopkg.in/gomail.v2"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+type nopLogger struct{ logger.Interface }
+
+func (nopLogger) Debug(string)          {}
+func (nopLogger) Info(string)           {}
+func (nopLogger) Warn(string)           {}
+func (nopLogger) Error(string)          {}
+func (nopLogger) Fatal(string)          {}
+func (nopLogger) Debugf(string, ...any) {}
+func (nopLogger) Infof(string, ...any)  {}
+func (nopLogger) Warnf(string, ...any)  {}
+func (nopLogger) Errorf(string, ...any) {}
+func (nopLogger) Fatalf(string, ...any) {}
+
+type idleSender struct{}
+
+func (idleSender) SendEmail(config.Email, *email.Recipients, interface{}) error { return nil }
+func (idleSender) Pending() int                                                 { return 0 }
+
+// queuedSender 异步队列中有pending封邮件等待发送
+type queuedSender struct {
+	email.AsyncSenderInterface
+	pending int
+}
+
+func (s queuedSender) Pending() int { return s.pending }
+
+func healthConfig(t *testing.T) *config.HealthConfig {
+	t.Helper()
+	c := &config.HealthConfig{}
+	c.InitDefaults()
+	if ok, err := c.Verify(); !ok {
+		t.Fatalf("verify config: %v", err)
+	}
+	return c
+}
+
+func TestCheckerReusesSmtpResultWithinInterval(t *testing.T) {
+	// 接受连接后立即关闭, 每次SMTP检查都会失败并计数
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer func() { _ = listener.Close() }()
+	var dials atomic.Int32
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			dials.Add(1)
+			_ = conn.Close()
+		}
+	}()
+
+	address := listener.Addr().(*net.TCPAddr)
+	emailConfig := &config.EmailConfig{Server: gomail.NewDialer("127.0.0.1", address.Port, "", "")}
+	checker := NewChecker(emailConfig, healthConfig(t), idleSender{}, queuedSender{})
+
+	for i := 0; i < 3; i++ {
+		if report := checker.Check(context.Background()); report.Checks[CheckSmtp].Healthy {
+			t.Fatalf("check %d: smtp healthy, want failure", i)
+		}
+	}
+	if got := dials.Load(); got != 1 {
+		t.Errorf("dialed smtp server %d times, want 1", got)
+	}
+
+	checker.smtpCheckedAt = time.Now().Add(-checker.healthConfig.SmtpIntervalDuration)
+	checker.Check(context.Background())
+	if got := dials.Load(); got != 2 {
+		t.Errorf("dialed smtp server %d times after interval, want 2", got)
+	}
+}
+
+func TestCheckerCountsAsyncBacklog(t *testing.T) {
+	c := healthConfig(t)
+	c.MaxBacklog = 10
+	// 邮件积压在异步队列中, 尚未开始发送
+	checker := NewChecker(&config.EmailConfig{}, c, idleSender{}, queuedSender{pending: 11})
+	report := checker.Check(context.Background())
+	if report.QueueBacklog != 11 || report.Checks[CheckQueue].Healthy {
+		t.Errorf("backlog = %d, queue healthy = %v, want 11 and unhealthy", report.QueueBacklog, report.Checks[CheckQueue].Healthy)
+	}
+}
+
+// brokenTransport 共享连接已断开且无法重新连接
+type brokenTransport struct{ email.TransportInterface }
+
+func (brokenTransport) CheckConnection() error { return errors.New("reconnect to smtp server fail") }
+
+func TestCheckerReportsBrokenSharedConnection(t *testing.T) {
+	// 未配置SMTP服务器时不新建连接探测, 只检查共享连接
+	checker := NewChecker(&config.EmailConfig{}, healthConfig(t), idleSender{}, queuedSender{}, brokenTransport{})
+	if report := checker.Check(context.Background()); report.Healthy || report.Checks[CheckSmtp].Healthy {
+		t.Errorf("report = %+v, want smtp unhealthy", report.Checks[CheckSmtp])
+	}
+}
+
+type staticChecker struct{ healthy bool }
+
+func (c *staticChecker) Check(context.Context) *health.Report {
+	return &health.Report{Healthy: c.healthy, Time: time.Now(), Checks: map[string]*health.CheckResult{}}
+}
+
+// blockingRegistrar 注销请求阻塞到unblock关闭
+type blockingRegistrar struct {
+	started chan struct{}
+	unblock chan struct{}
+}
+
+func (r *blockingRegistrar) RegisterServer() error { return nil }
+
+func (r *blockingRegistrar) UnregisterServer(context.Context) error {
+	close(r.started)
+	<-r.unblock
+	return nil
+}
+
+func TestMonitorReportNotBlockedByRegistrar(t *testing.T) {
+	c := healthConfig(t)
+	c.FailureThreshold = 1
+	monitor := NewMonitor(nopLogger{}, c, &staticChecker{healthy: false})
+	registrar := &blockingRegistrar{started: make(chan struct{}), unblock: make(chan struct{})}
+	monitor.SetRegistrar(registrar)
+
+	done := make(chan struct{})
+	go func() {
+		monitor.check(context.Background())
+		close(done)
+	}()
+	<-registrar.started
+
+	reported := make(chan *health.Report)
+	go func() { reported <- monitor.Report() }()
+	select {
+	case report := <-reported:
+		if report == nil || report.Healthy {
+			t.Errorf("report = %+v, want unhealthy report", report)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Report blocked while deregistering")
+	}
+
+	close(registrar.unblock)
+	<-done
+	if !monitor.deregistered {
+		t.Error("monitor not marked as deregistered")
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package health
+package health
+
+import (
+	"context"
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/health"
+	"sync"
+	"time"
+
+	grpcHealth "google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// Registrar 服务注册中心, 用于在实例不健康时注销服务
+type Registrar interface {
+	RegisterServer() error
+	UnregisterServer(ctx context.Context) error
+}
+
+type Monitor struct {
+	logger    logger.Interface
+	config    *config.HealthConfig
+	checker   health.CheckerInterface
+	server    *grpcHealth.Server
+	services  []string
+	lock      sync.RWMutex
+	report    *health.Report
+	registrar Registrar
+	// failures与deregistered只在监控协程中访问, 不需要加锁
+	failures int
+	// 是否已从注册中心注销
+	deregistered bool
+}
+
+func NewMonitor(
+	lg logger.Interface,
+	c *config.HealthConfig,
+	checker health.CheckerInterface,
+	services ...string,
+) *Monitor {
+	return &Monitor{
+		logger:   logger.NewLoggerAdapter(lg, "health-monitor"),
+		config:   c,
+		checker:  checker,
+		server:   grpcHealth.NewServer(),
+		services: append([]string{""}, services...),
+	}
+}
+
+// HealthServer 返回gRPC健康检查服务实现
+func (m *Monitor) HealthServer() grpc_health_v1.HealthServer { return m.server }
+
+// SetRegistrar 设置服务注册中心, 服务注册完成后调用
+func (m *Monitor) SetRegistrar(registrar Registrar) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.registrar = registrar
+}
+
+func (m *Monitor) Report() *health.Report {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.report
+}
+
+// Start 立即执行一次检查, 然后按配置的间隔周期性检查, 直到ctx结束
+func (m *Monitor) Start(ctx context.Context) {
+	m.check(ctx)
+	ticker := time.NewTicker(m.config.IntervalDuration)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				m.server.Shutdown()
+				return
+			case <-ticker.C:
+				m.check(ctx)
+			}
+		}
+	}()
+}
+
+func (m *Monitor) check(ctx context.Context) {
+	report := m.checker.Check(ctx)
+
+	status := grpc_health_v1.HealthCheckResponse_SERVING
+	if !report.Healthy {
+		status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+		for name, result := range report.Checks {
+			if !result.Healthy {
+				m.logger.Warnf("health check %s failed: %s", name, result.Message)
+			}
+		}
+	}
+	for _, service := range m.services {
+		m.server.SetServingStatus(service, status)
+	}
+
+	// 只在替换检查结果时加锁, 注册中心的网络请求不阻塞Report
+	m.lock.Lock()
+	m.report = report
+	registrar := m.registrar
+	m.lock.Unlock()
+
+	if report.Healthy {
+		m.failures = 0
+	} else {
+		m.failures++
+	}
+	m.syncRegistration(ctx, registrar, report.Healthy)
+}
+
+// syncRegistration 连续失败达到阈值后从注册中心注销, 恢复健康后重新注册
+func (m *Monitor) syncRegistration(ctx context.Context, registrar Registrar, healthy bool) {
+	if registrar == nil || !m.config.DeregisterOnFailure {
+		return
+	}
+	if !healthy && !m.deregistered && m.failures >= m.config.FailureThreshold {
+		m.logger.Warnf("health check failed %d times in a row, deregistering service", m.failures)
+		if err := registrar.UnregisterServer(ctx); err != nil {
+			m.logger.Errorf("fail to deregister service: %v", err)
+			return
+		}
+		m.deregistered = true
+		return
+	}
+	if healthy && m.deregistered {
+		m.logger.Info("service recovered, registering service again")
+		if err := registrar.RegisterServer(); err != nil {
+			m.logger.Errorf("fail to register service: %v", err)
+			return
+		}
+		m.deregistered = false
+	}
+}
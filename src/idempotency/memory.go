@@ -0,0 +1,80 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package idempotency
+package idempotency
+
+import (
+	"context"
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/idempotency"
+	"sync"
+
+	"half-nothing.cn/service-core/cache"
+	c "half-nothing.cn/service-core/interfaces/cache"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+type entry struct {
+	fingerprint string
+	// 请求完成后关闭
+	done   chan struct{}
+	result any
+	ok     bool
+}
+
+// MemoryStore 基于内存缓存的幂等键存储
+type MemoryStore struct {
+	logger logger.Interface
+	config *config.IdempotencyConfig
+	cache  c.Interface[string, *entry]
+	// 保证相同key的检查与占位是原子的
+	lock sync.Mutex
+}
+
+func NewMemoryStore(
+	lg logger.Interface,
+	config *config.IdempotencyConfig,
+) *MemoryStore {
+	return &MemoryStore{
+		logger: logger.NewLoggerAdapter(lg, "idempotency"),
+		config: config,
+		cache:  cache.NewMemoryCache[string, *entry](config.TtlDuration),
+	}
+}
+
+func (m *MemoryStore) Do(ctx context.Context, key string, fingerprint string, fn func() (any, bool)) (any, bool, error) {
+	m.lock.Lock()
+	if existing, ok := m.cache.Get(key); ok {
+		m.lock.Unlock()
+		if existing.fingerprint != fingerprint {
+			m.logger.Warnf("idempotency key %s reused with different request", key)
+			return nil, false, idempotency.ErrKeyReused
+		}
+		select {
+		case <-existing.done:
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+		m.logger.Debugf("replay result of idempotency key %s", key)
+		return existing.result, true, nil
+	}
+	current := &entry{fingerprint: fingerprint, done: make(chan struct{})}
+	m.cache.SetWithTTL(key, current, m.config.TtlDuration)
+	m.lock.Unlock()
+
+	current.result, current.ok = fn()
+	m.lock.Lock()
+	if current.ok {
+		m.cache.SetWithTTL(key, current, m.config.TtlDuration)
+	} else {
+		m.cache.Del(key)
+	}
+	m.lock.Unlock()
+	close(current.done)
+	return current.result, false, nil
+}
+
+func (m *MemoryStore) Close() {
+	m.cache.Close()
+}
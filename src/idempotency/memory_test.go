@@ -0,0 +1,85 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package idempotency
+package idempotency
+
+import (
+	"context"
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/idempotency"
+	"errors"
+	"testing"
+	"time"
+
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+type nopLogger struct{ logger.Interface }
+
+func (nopLogger) Debug(string)          {}
+func (nopLogger) Info(string)           {}
+func (nopLogger) Warn(string)           {}
+func (nopLogger) Error(string)          {}
+func (nopLogger) Fatal(string)          {}
+func (nopLogger) Debugf(string, ...any) {}
+func (nopLogger) Infof(string, ...any)  {}
+func (nopLogger) Warnf(string, ...any)  {}
+func (nopLogger) Errorf(string, ...any) {}
+func (nopLogger) Fatalf(string, ...any) {}
+
+func newTestStore() *MemoryStore {
+	return NewMemoryStore(nopLogger{}, &config.IdempotencyConfig{Enable: true, TtlDuration: time.Minute})
+}
+
+func TestMemoryStoreReplaysResult(t *testing.T) {
+	store := newTestStore()
+	calls := 0
+	fn := func() (any, bool) {
+		calls++
+		return "sent", true
+	}
+	for i := 0; i < 2; i++ {
+		result, replayed, err := store.Do(context.Background(), "key", "a@example.com", fn)
+		if err != nil || result != "sent" || replayed != (i > 0) {
+			t.Errorf("call %d: result = %v, replayed = %t, err = %v", i, result, replayed, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("fn called %d times, want 1", calls)
+	}
+	if _, _, err := store.Do(context.Background(), "key", "b@example.com", fn); !errors.Is(err, idempotency.ErrKeyReused) {
+		t.Errorf("reuse error = %v, want %v", err, idempotency.ErrKeyReused)
+	}
+}
+
+func TestMemoryStoreWaitHonorsContext(t *testing.T) {
+	store := newTestStore()
+	started, unblock := make(chan struct{}), make(chan struct{})
+	go func() {
+		_, _, _ = store.Do(context.Background(), "key", "a@example.com", func() (any, bool) {
+			close(started)
+			<-unblock
+			return "sent", true
+		})
+	}()
+	<-started
+	defer close(unblock)
+
+	// 首次请求尚未完成时, 重复请求在自身ctx结束后返回而不是一直等待
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	done := make(chan error)
+	go func() {
+		_, _, err := store.Do(ctx, "key", "a@example.com", func() (any, bool) { return "duplicate", true })
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("err = %v, want deadline exceeded", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("duplicate request did not return after its context ended")
+	}
+}
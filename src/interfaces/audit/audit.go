@@ -0,0 +1,47 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package audit
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+const AnonymousCaller = "anonymous"
+
+// Record 一次邮件发送的审计记录
+type Record struct {
+	Id        string      `json:"id"`
+	Time      time.Time   `json:"time"`
+	Caller    string      `json:"caller"`
+	Method    string      `json:"method"`
+	EmailType string      `json:"email_type"`
+	To        []string    `json:"to"`
+	Cc        []string    `json:"cc,omitempty"`
+	Bcc       []string    `json:"bcc,omitempty"`
+	Data      interface{} `json:"data"`
+	Success   bool        `json:"success"`
+	Error     string      `json:"error,omitempty"`
+}
+
+type RecorderInterface interface {
+	Record(record *Record)
+	// List 按时间倒序返回审计记录
+	List(offset int, limit int) ([]*Record, int)
+	Get(id string) (*Record, bool)
+}
+
+type callerKey struct{}
+
+func WithCaller(ctx context.Context, caller string) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+func CallerFromContext(ctx context.Context) string {
+	if caller, ok := ctx.Value(callerKey{}).(string); ok {
+		return caller
+	}
+	return AnonymousCaller
+}
@@ -0,0 +1,27 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package broker
+package broker
+
+type Message struct {
+	// Id 消息的唯一标识, 重新投递时保持不变, 中间件不支持重新投递时为空
+	Id      string
+	Subject string
+	Data    []byte
+}
+
+// Handler 处理一条消息, 返回错误时支持确认机制的中间件会重新投递该消息
+type Handler func(message *Message) error
+
+// SubscriberInterface 消息中间件的订阅端
+type SubscriberInterface interface {
+	// Subscribe 订阅主题, 多个实例之间负载均衡, 每条消息只会被一个实例处理
+	Subscribe(subject string, handler Handler) error
+	Close() error
+}
+
+// PublisherInterface 消息中间件的发布端, 目前只有进程内实现使用
+type PublisherInterface interface {
+	Publish(subject string, data []byte) error
+}
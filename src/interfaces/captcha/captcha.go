@@ -0,0 +1,18 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package captcha
+package captcha
+
+import "errors"
+
+var (
+	ErrCaptchaRequired    = errors.New("captcha token required")
+	ErrCaptchaInvalid     = errors.New("captcha token invalid")
+	ErrCaptchaUnavailable = errors.New("captcha service unavailable")
+)
+
+type VerifierInterface interface {
+	// Verify 校验客户端提交的验证码令牌, 校验未通过时返回 ErrCaptchaInvalid
+	Verify(token string, remoteIp string) error
+}
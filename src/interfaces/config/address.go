@@ -0,0 +1,76 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+type AddressCheckConfig struct {
+	CheckMx       bool   `yaml:"check_mx"`
+	MxTimeout     string `yaml:"mx_timeout"`
+	BlocklistFile string `yaml:"blocklist_file"`
+	AllowlistFile string `yaml:"allowlist_file"`
+	// 内部字段
+	MxTimeoutDuration time.Duration       `yaml:"-"`
+	Blocklist         map[string]struct{} `yaml:"-"`
+	Allowlist         map[string]struct{} `yaml:"-"`
+}
+
+func (a *AddressCheckConfig) InitDefaults() {
+	a.CheckMx = true
+	a.MxTimeout = "3s"
+	a.BlocklistFile = ""
+	a.AllowlistFile = ""
+}
+
+// readDomainList 读取域名列表文件, 每行一个域名, 以#开头的行为注释
+func readDomainList(filePath string) (map[string]struct{}, error) {
+	domains := make(map[string]struct{})
+	if filePath == "" {
+		return domains, nil
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read domain list %s: %v", filePath, err)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		domains[strings.TrimSuffix(strings.ToLower(line), ".")] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse domain list %s: %v", filePath, err)
+	}
+	return domains, nil
+}
+
+//goland:noinspection GoRedundantElseInIf
+func (a *AddressCheckConfig) Verify() (bool, error) {
+	if duration, err := time.ParseDuration(a.MxTimeout); err != nil {
+		return false, err
+	} else {
+		a.MxTimeoutDuration = duration
+	}
+	if domains, err := readDomainList(a.BlocklistFile); err != nil {
+		return false, err
+	} else {
+		a.Blocklist = domains
+	}
+	if domains, err := readDomainList(a.AllowlistFile); err != nil {
+		return false, err
+	} else {
+		a.Allowlist = domains
+	}
+	return true, nil
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+type AdminTokenConfig struct {
+	Name  string `yaml:"name"`
+	Token string `yaml:"token"`
+}
+
+type AdminConfig struct {
+	Enable bool                `yaml:"enable"`
+	Tokens []*AdminTokenConfig `yaml:"tokens"`
+}
+
+func (a *AdminConfig) InitDefaults() {
+	a.Enable = false
+	a.Tokens = make([]*AdminTokenConfig, 0)
+}
+
+func (a *AdminConfig) Verify() (bool, error) {
+	if !a.Enable {
+		return true, nil
+	}
+	if len(a.Tokens) == 0 {
+		return false, errors.New("admin tokens cannot be empty when admin api is enabled")
+	}
+	for _, token := range a.Tokens {
+		if token.Name == "" {
+			return false, errors.New("admin token name cannot be empty")
+		}
+		if len(token.Token) < 16 {
+			return false, fmt.Errorf("admin token of %s must be at least 16 characters", token.Name)
+		}
+	}
+	return true, nil
+}
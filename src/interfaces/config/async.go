@@ -0,0 +1,40 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+type AsyncConfig struct {
+	Workers   int    `yaml:"workers"`
+	QueueSize int    `yaml:"queue_size"`
+	StatusTtl string `yaml:"status_ttl"`
+	// 内部字段
+	StatusTtlDuration time.Duration `yaml:"-"`
+}
+
+func (a *AsyncConfig) InitDefaults() {
+	a.Workers = 4
+	a.QueueSize = 1000
+	a.StatusTtl = "24h"
+}
+
+//goland:noinspection GoRedundantElseInIf
+func (a *AsyncConfig) Verify() (bool, error) {
+	if a.Workers <= 0 {
+		return false, errors.New("async workers must be greater than 0")
+	}
+	if a.QueueSize <= 0 {
+		return false, errors.New("async queue size must be greater than 0")
+	}
+	if duration, err := time.ParseDuration(a.StatusTtl); err != nil {
+		return false, err
+	} else {
+		a.StatusTtlDuration = duration
+	}
+	return true, nil
+}
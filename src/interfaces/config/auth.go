@@ -0,0 +1,94 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// GrpcCallerConfig 允许调用gRPC接口的服务
+type GrpcCallerConfig struct {
+	Name    string   `yaml:"name"`
+	ApiKey  string   `yaml:"api_key"`
+	Methods []string `yaml:"methods"`
+}
+
+type GrpcJwtConfig struct {
+	Enable   bool   `yaml:"enable"`
+	Secret   string `yaml:"secret"`
+	Issuer   string `yaml:"issuer"`
+	Audience string `yaml:"audience"`
+}
+
+func (j *GrpcJwtConfig) InitDefaults() {
+	j.Enable = false
+	j.Secret = ""
+	j.Issuer = ""
+	j.Audience = "email-service"
+}
+
+func (j *GrpcJwtConfig) Verify() (bool, error) {
+	if j.Enable && j.Secret == "" {
+		return false, errors.New("grpc jwt secret cannot be empty")
+	}
+	return true, nil
+}
+
+type GrpcAuthConfig struct {
+	Enable  bool                `yaml:"enable"`
+	Callers []*GrpcCallerConfig `yaml:"callers"`
+	Jwt     *GrpcJwtConfig      `yaml:"jwt"`
+}
+
+func (g *GrpcAuthConfig) InitDefaults() {
+	g.Enable = false
+	g.Callers = make([]*GrpcCallerConfig, 0)
+	g.Jwt = &GrpcJwtConfig{}
+	g.Jwt.InitDefaults()
+}
+
+func (g *GrpcAuthConfig) Verify() (bool, error) {
+	if !g.Enable {
+		return true, nil
+	}
+	names := make(map[string]struct{}, len(g.Callers))
+	keys := make(map[string]struct{}, len(g.Callers))
+	for _, caller := range g.Callers {
+		if caller.Name == "" {
+			return false, errors.New("grpc caller name cannot be empty")
+		}
+		if _, ok := names[caller.Name]; ok {
+			return false, fmt.Errorf("duplicate grpc caller %s", caller.Name)
+		}
+		names[caller.Name] = struct{}{}
+		if caller.ApiKey == "" {
+			if !g.Jwt.Enable {
+				return false, fmt.Errorf("api key of grpc caller %s cannot be empty", caller.Name)
+			}
+			continue
+		}
+		if _, ok := keys[caller.ApiKey]; ok {
+			return false, fmt.Errorf("duplicate api key of grpc caller %s", caller.Name)
+		}
+		keys[caller.ApiKey] = struct{}{}
+	}
+	return g.Jwt.Verify()
+}
+
+type AuditConfig struct {
+	Capacity int `yaml:"capacity"`
+}
+
+func (a *AuditConfig) InitDefaults() {
+	a.Capacity = 1000
+}
+
+func (a *AuditConfig) Verify() (bool, error) {
+	if a.Capacity <= 0 {
+		return false, errors.New("audit capacity must be greater than 0")
+	}
+	return true, nil
+}
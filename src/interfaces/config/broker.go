@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	BrokerNats = "nats"
+)
+
+type NatsBrokerConfig struct {
+	Url   string `yaml:"url"`
+	Name  string `yaml:"name"`
+	Queue string `yaml:"queue"`
+	Token string `yaml:"token"`
+	// JetStream流名称, 为空时使用core NATS, 服务不可用期间发布的事件会丢失
+	Stream     string `yaml:"stream"`
+	MaxDeliver int    `yaml:"max_deliver"`
+}
+
+func (n *NatsBrokerConfig) InitDefaults() {
+	n.Url = "nats://127.0.0.1:4222"
+	n.Name = "email-service"
+	n.Queue = "email-service"
+	n.Token = ""
+	n.Stream = ""
+	n.MaxDeliver = 5
+}
+
+func (n *NatsBrokerConfig) Verify() (bool, error) {
+	if n.Url == "" {
+		return false, errors.New("nats url cannot be empty")
+	}
+	if n.Stream != "" && n.MaxDeliver <= 0 {
+		return false, errors.New("nats max deliver must be greater than 0")
+	}
+	return true, nil
+}
+
+// BrokerSubscriptionConfig 将主题上的事件映射为一种邮件
+// Fields的键为邮件模板参数名(如Cid、Reason), 值为事件JSON中的字段路径, 使用.分隔嵌套字段
+type BrokerSubscriptionConfig struct {
+	Subject   string            `yaml:"subject"`
+	EmailType string            `yaml:"email_type"`
+	Filter    map[string]string `yaml:"filter"`
+	Recipient string            `yaml:"recipient"`
+	Cc        string            `yaml:"cc"`
+	Bcc       string            `yaml:"bcc"`
+	Fields    map[string]string `yaml:"fields"`
+	// 内部字段
+	Email Email `yaml:"-"`
+}
+
+type BrokerConfig struct {
+	Enable        bool                        `yaml:"enable"`
+	Type          string                      `yaml:"type"`
+	Nats          *NatsBrokerConfig           `yaml:"nats"`
+	Subscriptions []*BrokerSubscriptionConfig `yaml:"subscriptions"`
+}
+
+func (b *BrokerConfig) InitDefaults() {
+	b.Enable = false
+	b.Type = BrokerNats
+	b.Nats = &NatsBrokerConfig{}
+	b.Nats.InitDefaults()
+	b.Subscriptions = make([]*BrokerSubscriptionConfig, 0)
+}
+
+func (b *BrokerConfig) Verify() (bool, error) {
+	if !b.Enable {
+		return true, nil
+	}
+	switch b.Type {
+	case BrokerNats:
+		if ok, err := b.Nats.Verify(); !ok {
+			return ok, err
+		}
+	default:
+		return false, fmt.Errorf("unknown broker type %s", b.Type)
+	}
+	for _, subscription := range b.Subscriptions {
+		if subscription.Subject == "" {
+			return false, errors.New("broker subscription subject cannot be empty")
+		}
+		email, ok := FindEmail(subscription.EmailType)
+		if !ok {
+			return false, fmt.Errorf("unknown email type %s of broker subject %s", subscription.EmailType, subscription.Subject)
+		}
+		subscription.Email = email
+		if subscription.Recipient == "" {
+			return false, fmt.Errorf("recipient of broker subject %s cannot be empty", subscription.Subject)
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// CalendarConfig 活动报名邮件附带的日历邀请配置
+type CalendarConfig struct {
+	Enable      bool   `yaml:"enable"`
+	EventLength string `yaml:"event_length"`
+	// 内部字段
+	EventLengthDuration time.Duration `yaml:"-"`
+}
+
+func (c *CalendarConfig) InitDefaults() {
+	c.Enable = true
+	c.EventLength = "2h"
+}
+
+//goland:noinspection GoRedundantElseInIf
+func (c *CalendarConfig) Verify() (bool, error) {
+	if !c.Enable {
+		return true, nil
+	}
+	if duration, err := time.ParseDuration(c.EventLength); err != nil {
+		return false, err
+	} else {
+		c.EventLengthDuration = duration
+	}
+	if c.EventLengthDuration <= 0 {
+		return false, errors.New("calendar event length must be greater than 0")
+	}
+	return true, nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	CaptchaProviderHCaptcha  = "hcaptcha"
+	CaptchaProviderTurnstile = "turnstile"
+	CaptchaProviderReCaptcha = "recaptcha"
+	CaptchaProviderSlider    = "slider"
+	CaptchaProviderFake      = "fake"
+
+	CaptchaModeAlways     = "always"
+	CaptchaModeSuspicious = "suspicious"
+)
+
+type CaptchaConfig struct {
+	Enable              bool    `yaml:"enable"`
+	Provider            string  `yaml:"provider"`
+	Secret              string  `yaml:"secret"`
+	VerifyUrl           string  `yaml:"verify_url"`
+	MinScore            float64 `yaml:"min_score"`
+	Timeout             string  `yaml:"timeout"`
+	Mode                string  `yaml:"mode"`
+	SuspiciousThreshold int     `yaml:"suspicious_threshold"`
+	// 内部字段
+	TimeoutDuration time.Duration `yaml:"-"`
+}
+
+func (c *CaptchaConfig) InitDefaults() {
+	c.Enable = false
+	c.Provider = CaptchaProviderTurnstile
+	c.Secret = ""
+	c.VerifyUrl = ""
+	c.MinScore = 0.5
+	c.Timeout = "5s"
+	c.Mode = CaptchaModeAlways
+	c.SuspiciousThreshold = 3
+}
+
+//goland:noinspection GoRedundantElseInIf
+func (c *CaptchaConfig) Verify() (bool, error) {
+	if !c.Enable {
+		return true, nil
+	}
+	switch c.Provider {
+	case CaptchaProviderHCaptcha, CaptchaProviderTurnstile, CaptchaProviderReCaptcha, CaptchaProviderFake:
+	case CaptchaProviderSlider:
+		if c.VerifyUrl == "" {
+			return false, errors.New("captcha verify url cannot be empty when using slider provider")
+		}
+	default:
+		return false, fmt.Errorf("unknown captcha provider %s", c.Provider)
+	}
+	if c.Secret == "" {
+		return false, errors.New("captcha secret cannot be empty")
+	}
+	switch c.Mode {
+	case CaptchaModeAlways:
+	case CaptchaModeSuspicious:
+		if c.SuspiciousThreshold <= 0 {
+			return false, errors.New("captcha suspicious threshold must be greater than 0")
+		}
+	default:
+		return false, fmt.Errorf("unknown captcha mode %s", c.Mode)
+	}
+	if duration, err := time.ParseDuration(c.Timeout); err != nil {
+		return false, err
+	} else {
+		c.TimeoutDuration = duration
+	}
+	return true, nil
+}
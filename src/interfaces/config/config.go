@@ -7,10 +7,19 @@ package config
 import "half-nothing.cn/service-core/interfaces/config"
 
 type Config struct {
-	GlobalConfig    *GlobalConfig           `yaml:"global"`
-	EmailConfig     *EmailConfig            `yaml:"email"`
-	ServerConfig    *config.ServerConfig    `yaml:"server"`
-	TelemetryConfig *config.TelemetryConfig `yaml:"telemetry"`
+	GlobalConfig      *GlobalConfig           `yaml:"global"`
+	EmailConfig       *EmailConfig            `yaml:"email"`
+	GrpcAuthConfig    *GrpcAuthConfig         `yaml:"grpc_auth"`
+	AuditConfig       *AuditConfig            `yaml:"audit"`
+	AdminConfig       *AdminConfig            `yaml:"admin"`
+	MetricsConfig     *MetricsConfig          `yaml:"metrics"`
+	HealthConfig      *HealthConfig           `yaml:"health"`
+	IdempotencyConfig *IdempotencyConfig      `yaml:"idempotency"`
+	WebhookConfig     *WebhookConfig          `yaml:"webhook"`
+	BrokerConfig      *BrokerConfig           `yaml:"broker"`
+	PreferenceConfig  *PreferenceConfig       `yaml:"preference"`
+	ServerConfig      *config.ServerConfig    `yaml:"server"`
+	TelemetryConfig   *config.TelemetryConfig `yaml:"telemetry"`
 }
 
 func (c *Config) InitDefaults() {
@@ -18,6 +27,24 @@ func (c *Config) InitDefaults() {
 	c.GlobalConfig.InitDefaults()
 	c.EmailConfig = &EmailConfig{}
 	c.EmailConfig.InitDefaults()
+	c.GrpcAuthConfig = &GrpcAuthConfig{}
+	c.GrpcAuthConfig.InitDefaults()
+	c.AuditConfig = &AuditConfig{}
+	c.AuditConfig.InitDefaults()
+	c.AdminConfig = &AdminConfig{}
+	c.AdminConfig.InitDefaults()
+	c.MetricsConfig = &MetricsConfig{}
+	c.MetricsConfig.InitDefaults()
+	c.HealthConfig = &HealthConfig{}
+	c.HealthConfig.InitDefaults()
+	c.IdempotencyConfig = &IdempotencyConfig{}
+	c.IdempotencyConfig.InitDefaults()
+	c.WebhookConfig = &WebhookConfig{}
+	c.WebhookConfig.InitDefaults()
+	c.BrokerConfig = &BrokerConfig{}
+	c.BrokerConfig.InitDefaults()
+	c.PreferenceConfig = &PreferenceConfig{}
+	c.PreferenceConfig.InitDefaults()
 	c.ServerConfig = &config.ServerConfig{}
 	c.ServerConfig.InitDefaults()
 	c.TelemetryConfig = &config.TelemetryConfig{}
@@ -31,6 +58,33 @@ func (c *Config) Verify() (bool, error) {
 	if ok, err := c.EmailConfig.Verify(); !ok {
 		return ok, err
 	}
+	if ok, err := c.GrpcAuthConfig.Verify(); !ok {
+		return ok, err
+	}
+	if ok, err := c.AuditConfig.Verify(); !ok {
+		return ok, err
+	}
+	if ok, err := c.AdminConfig.Verify(); !ok {
+		return ok, err
+	}
+	if ok, err := c.MetricsConfig.Verify(); !ok {
+		return ok, err
+	}
+	if ok, err := c.HealthConfig.Verify(); !ok {
+		return ok, err
+	}
+	if ok, err := c.IdempotencyConfig.Verify(); !ok {
+		return ok, err
+	}
+	if ok, err := c.WebhookConfig.Verify(); !ok {
+		return ok, err
+	}
+	if ok, err := c.BrokerConfig.Verify(); !ok {
+		return ok, err
+	}
+	if ok, err := c.PreferenceConfig.Verify(); !ok {
+		return ok, err
+	}
 	if ok, err := c.ServerConfig.Verify(); !ok {
 		return ok, err
 	}
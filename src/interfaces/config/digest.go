@@ -0,0 +1,44 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// DigestConfig 汇总邮件配置, 模板中delivery为digest的邮件会按收件人暂存, 窗口结束后合并为一封汇总邮件发送
+type DigestConfig struct {
+	Enable   bool   `yaml:"enable"`
+	Window   string `yaml:"window"`
+	MaxItems int    `yaml:"max_items"`
+	// 内部字段
+	WindowDuration time.Duration `yaml:"-"`
+}
+
+func (d *DigestConfig) InitDefaults() {
+	d.Enable = false
+	d.Window = "1h"
+	d.MaxItems = 20
+}
+
+//goland:noinspection GoRedundantElseInIf
+func (d *DigestConfig) Verify() (bool, error) {
+	if !d.Enable {
+		return true, nil
+	}
+	if duration, err := time.ParseDuration(d.Window); err != nil {
+		return false, err
+	} else {
+		d.WindowDuration = duration
+	}
+	if d.WindowDuration <= 0 {
+		return false, errors.New("digest window must be greater than 0")
+	}
+	if d.MaxItems <= 0 {
+		return false, errors.New("digest max items must be greater than 0")
+	}
+	return true, nil
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+const (
+	DkimCanonicalizationSimple  = "simple"
+	DkimCanonicalizationRelaxed = "relaxed"
+)
+
+type DkimConfig struct {
+	Enable           bool     `yaml:"enable"`
+	Domain           string   `yaml:"domain"`
+	Selector         string   `yaml:"selector"`
+	KeyPath          string   `yaml:"key_path"`
+	Canonicalization string   `yaml:"canonicalization"`
+	Headers          []string `yaml:"headers"`
+	// 内部字段
+	Signer crypto.Signer `yaml:"-"`
+}
+
+func (d *DkimConfig) InitDefaults() {
+	d.Enable = false
+	d.Domain = ""
+	d.Selector = "default"
+	d.KeyPath = "data/dkim.pem"
+	d.Canonicalization = DkimCanonicalizationRelaxed
+	d.Headers = []string{"From", "Reply-To", "To", "Cc", "Subject", "Date", "Message-ID",
+		"In-Reply-To", "References", "MIME-Version", "Content-Type", "Content-Transfer-Encoding"}
+}
+
+func (d *DkimConfig) Verify() (bool, error) {
+	if !d.Enable {
+		return true, nil
+	}
+	if d.Domain == "" {
+		return false, errors.New("dkim domain cannot be empty")
+	}
+	if d.Selector == "" {
+		return false, errors.New("dkim selector cannot be empty")
+	}
+	switch d.Canonicalization {
+	case DkimCanonicalizationSimple, DkimCanonicalizationRelaxed:
+	default:
+		return false, fmt.Errorf("unknown dkim canonicalization %s", d.Canonicalization)
+	}
+	if !slices.ContainsFunc(d.Headers, func(header string) bool { return strings.EqualFold(header, "From") }) {
+		return false, errors.New("dkim headers must contain From")
+	}
+	signer, err := readDkimKey(d.KeyPath)
+	if err != nil {
+		return false, fmt.Errorf("fail to load dkim key, %v", err)
+	}
+	d.Signer = signer
+	return true, nil
+}
+
+// readDkimKey 读取PEM格式的RSA(PKCS#1或PKCS#8)或Ed25519(PKCS#8)私钥
+func readDkimKey(path string) (crypto.Signer, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errors.New("no pem block found")
+	}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		switch key := key.(type) {
+		case *rsa.PrivateKey:
+			return key, nil
+		case ed25519.PrivateKey:
+			return key, nil
+		default:
+			return nil, fmt.Errorf("unsupported key type %T", key)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported pem block %s", block.Type)
+	}
+}
@@ -6,11 +6,16 @@ package config
 
 import (
 	"email-service/src/interfaces/global"
+	"email-service/src/interfaces/render"
 	"errors"
 	"fmt"
 	"html/template"
 	"net/url"
 	"path"
+	"reflect"
+	"slices"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/sync/errgroup"
@@ -19,37 +24,124 @@ import (
 	"half-nothing.cn/service-core/utils"
 )
 
+const (
+	DeliveryImmediate = "immediate"
+	DeliveryDigest    = "digest"
+)
+
 type Template struct {
-	Enable   bool   `yaml:"enable"`
-	FileName string `yaml:"file_name"`
-	Subject  string `yaml:"subject"`
+	Enable   bool              `yaml:"enable"`
+	FileName string            `yaml:"file_name"`
+	Subject  string            `yaml:"subject"`
+	FromName string            `yaml:"from_name"`
+	ReplyTo  string            `yaml:"reply_to"`
+	Cc       []string          `yaml:"cc"`
+	Bcc      []string          `yaml:"bcc"`
+	Headers  map[string]string `yaml:"headers"`
+	Delivery string            `yaml:"delivery"`
+	Priority string            `yaml:"priority"`
+	Markdown []string          `yaml:"markdown"`
 	// 内部字段
-	LocalPath string `yaml:"-"`
-	Type      Email  `yaml:"-"`
+	LocalPath string           `yaml:"-"`
+	Funcs     template.FuncMap `yaml:"-"`
+	Type      Email            `yaml:"-"`
 }
 
 func (t *Template) Verify() (bool, error) {
+	if t.ReplyTo != "" {
+		if err := verifyAddresses("reply to", t.ReplyTo); err != nil {
+			return false, err
+		}
+	}
+	if err := verifyAddresses("cc", t.Cc...); err != nil {
+		return false, err
+	}
+	if err := verifyAddresses("bcc", t.Bcc...); err != nil {
+		return false, err
+	}
+	if err := verifyHeaders(t.Headers); err != nil {
+		return false, err
+	}
+	switch t.Delivery {
+	case "":
+		t.Delivery = DeliveryImmediate
+	case DeliveryImmediate:
+	case DeliveryDigest:
+		if t.Type == EmailDigest || slices.Contains(MandatoryEmails, t.Type) {
+			return false, fmt.Errorf("%s email cannot be delivered in digest", t.Type.Value)
+		}
+	default:
+		return false, fmt.Errorf("unknown delivery %s of %s email", t.Delivery, t.Type.Value)
+	}
+	if t.Priority == "" {
+		t.Priority = PriorityNormal
+	}
+	if !slices.Contains(Priorities, t.Priority) {
+		return false, fmt.Errorf("unknown priority %s of %s email", t.Priority, t.Type.Value)
+	}
+	if err := t.verifyMarkdown(); err != nil {
+		return false, err
+	}
+	t.Type.Data.Subject = t.Subject
+	t.Type.Data.FromName = t.FromName
+	t.Type.Data.ReplyTo = t.ReplyTo
+	t.Type.Data.Cc = t.Cc
+	t.Type.Data.Bcc = t.Bcc
+	t.Type.Data.Headers = t.Headers
+	t.Type.Data.Delivery = t.Delivery
+	t.Type.Data.Priority = t.Priority
+	t.Type.Data.Markdown = t.Markdown
 	if !t.Enable {
-		t.Type.Data.Enable = false
+		t.Type.Data.SetEnable(false)
 		return true, nil
 	}
+	if err := t.Load(); err != nil {
+		return false, err
+	}
+	t.Type.Data.SetEnable(true)
+	return true, nil
+}
+
+// verifyMarkdown 检查配置的markdown字段是否为模板参数中的字符串字段
+func (t *Template) verifyMarkdown() error {
+	dataType := t.Type.Data.DataType
+	for _, name := range t.Markdown {
+		if dataType == nil {
+			return fmt.Errorf("%s email does not support markdown fields", t.Type.Value)
+		}
+		field, ok := dataType.FieldByName(name)
+		if !ok || !field.IsExported() || field.Type.Kind() != reflect.String {
+			return fmt.Errorf("markdown field %s is not a text field of %s email", name, t.Type.Value)
+		}
+	}
+	return nil
+}
+
+// Load 读取并解析模板文件, 解析失败时保留原有模板
+func (t *Template) Load() error {
+	parsedTemplate, err := t.parse()
+	if err != nil {
+		return err
+	}
+	t.Type.Data.SetTemplate(parsedTemplate)
+	return nil
+}
+
+func (t *Template) parse() (*template.Template, error) {
 	remoteFileUrl, err := url.JoinPath(*global.DownloadPrefix, t.Type.Data.RemotePath)
 	if err != nil {
-		return false, fmt.Errorf("failed to get remote path: %v", err)
+		return nil, fmt.Errorf("failed to get remote path: %v", err)
 	}
 	localFilePath := path.Join(t.LocalPath, t.FileName)
 	data, err := config.ReadOrDownloadFile(localFilePath, remoteFileUrl)
 	if err != nil {
-		return false, fmt.Errorf("failed to read or download file: %v", err)
+		return nil, fmt.Errorf("failed to read or download file: %v", err)
 	}
-	parsedTemplate, err := template.New(t.Type.Value).Parse(string(data))
+	parsedTemplate, err := template.New(t.Type.Value).Funcs(t.Funcs).Parse(string(data))
 	if err != nil {
-		return false, fmt.Errorf("failed to parse template: %v", err)
+		return nil, fmt.Errorf("failed to parse template: %v", err)
 	}
-	t.Type.Data.Template = parsedTemplate
-	t.Type.Data.Subject = t.Subject
-	t.Type.Data.Enable = true
-	return true, nil
+	return parsedTemplate, nil
 }
 
 type TemplateConfig struct {
@@ -73,44 +165,86 @@ type TemplateConfig struct {
 	RoleChangeEmail            *Template `yaml:"role_change_email"`
 	PermissionChangeEmail      *Template `yaml:"permission_change_email"`
 	EmailChangeEmail           *Template `yaml:"email_change_email"`
+	EmailChangeVerifyEmail     *Template `yaml:"email_change_verify_email"`
+	DigestEmail                *Template `yaml:"digest_email"`
 	// 内部字段
-	LocalPath string `yaml:"-"`
+	LocalPath string           `yaml:"-"`
+	Funcs     template.FuncMap `yaml:"-"`
 }
 
 func (t *TemplateConfig) InitDefaults() {
-	t.VerifyCodeEmail = &Template{Enable: true, FileName: "verify_code.template", Subject: "邮箱验证码", Type: EmailVerifyCode}
+	t.VerifyCodeEmail = &Template{Enable: true, FileName: "verify_code.template", Subject: "邮箱验证码", Type: EmailVerifyCode, Priority: PriorityCritical}
 	t.WelcomeEmail = &Template{Enable: true, FileName: "welcome.template", Subject: "欢迎注册", Type: EmailWelcome}
 	t.RatingChangeEmail = &Template{Enable: true, FileName: "atc_rating_change.template", Subject: "管制权限变更通知", Type: EmailRatingChange}
 	t.KickedFromServerEmail = &Template{Enable: true, FileName: "kicked_from_server.template", Subject: "您已被踢出服务器", Type: EmailKickedFromServer}
 	t.PasswordChangeEmail = &Template{Enable: true, FileName: "password_change.template", Subject: "飞控密码更改通知", Type: EmailPasswordChange}
-	t.PasswordResetEmail = &Template{Enable: true, FileName: "password_reset.template", Subject: "飞控密码重置通知", Type: EmailPasswordReset}
-	t.ApplicationPassedEmail = &Template{Enable: true, FileName: "application_passed.template", Subject: "管制员申请通过", Type: EmailApplicationPassed}
-	t.ApplicationRejectedEmail = &Template{Enable: true, FileName: "application_rejected.template", Subject: "管制员申请被拒", Type: EmailApplicationRejected}
-	t.ApplicationProcessingEmail = &Template{Enable: true, FileName: "application_processing.template", Subject: "管制面试通知", Type: EmailApplicationProcessing}
+	t.PasswordResetEmail = &Template{Enable: true, FileName: "password_reset.template", Subject: "飞控密码重置通知", Type: EmailPasswordReset, Priority: PriorityCritical}
+	t.ApplicationPassedEmail = &Template{Enable: true, FileName: "application_passed.template", Subject: "管制员申请通过", Type: EmailApplicationPassed, Markdown: []string{"Message"}}
 	t.ApplicationRejectedEmail = &Template{Enable: true, FileName: "application_rejected.template", Subject: "管制员申请被拒", Type: EmailApplicationRejected}
 	t.ApplicationProcessingEmail = &Template{Enable: true, FileName: "application_processing.template", Subject: "管制面试通知", Type: EmailApplicationProcessing}
-	t.TicketReplyEmail = &Template{Enable: true, FileName: "ticket_reply.template", Subject: "工单回复通知", Type: EmailTicketReply}
-	t.TicketReplyEmail = &Template{Enable: true, FileName: "ticket_reply.template", Subject: "工单回复通知", Type: EmailTicketReply}
-	t.ActivityPilotJoinEmail = &Template{Enable: true, FileName: "activity_pilot_join.template", Subject: "活动报名成功", Type: EmailActivityPilotJoin}
-	t.ActivityPilotLeaveEmail = &Template{Enable: true, FileName: "activity_pilot_leave.template", Subject: "退出活动成功", Type: EmailActivityPilotLeave}
-	t.ActivityAtcJoinEmail = &Template{Enable: true, FileName: "activity_atc_join.template", Subject: "活动报名成功", Type: EmailActivityAtcJoin}
-	t.ActivityAtcLeaveEmail = &Template{Enable: true, FileName: "activity_atc_leave.template", Subject: "退出活动成功", Type: EmailActivityAtcLeave}
+	t.TicketReplyEmail = &Template{Enable: true, FileName: "ticket_reply.template", Subject: "工单回复通知", Type: EmailTicketReply, Markdown: []string{"Reply"}}
+	t.ActivityPilotJoinEmail = &Template{Enable: true, FileName: "activity_pilot_join.template", Subject: "活动报名成功", Type: EmailActivityPilotJoin, Priority: PriorityBulk}
+	t.ActivityPilotLeaveEmail = &Template{Enable: true, FileName: "activity_pilot_leave.template", Subject: "退出活动成功", Type: EmailActivityPilotLeave, Priority: PriorityBulk}
+	t.ActivityAtcJoinEmail = &Template{Enable: true, FileName: "activity_atc_join.template", Subject: "活动报名成功", Type: EmailActivityAtcJoin, Priority: PriorityBulk}
+	t.ActivityAtcLeaveEmail = &Template{Enable: true, FileName: "activity_atc_leave.template", Subject: "退出活动成功", Type: EmailActivityAtcLeave, Priority: PriorityBulk}
 	t.InstructorChangeEmail = &Template{Enable: true, FileName: "instructor_change.template", Subject: "教员变更通知", Type: EmailInstructorChange}
-	t.BannedEmail = &Template{Enable: true, FileName: "banned.template", Subject: "您已被封禁", Type: EmailBanned}
+	t.BannedEmail = &Template{Enable: true, FileName: "banned.template", Subject: "您已被封禁", Type: EmailBanned, Markdown: []string{"Reason"}}
 	t.UnbannedEmail = &Template{Enable: true, FileName: "unbanned.template", Subject: "您已被解封", Type: EmailUnbanned}
 	t.RoleChangeEmail = &Template{Enable: true, FileName: "role_change.template", Subject: "飞控角色变更通知", Type: EmailRoleChange}
 	t.PermissionChangeEmail = &Template{Enable: true, FileName: "permission_change.template", Subject: "飞控权限变更通知", Type: EmailPermissionChange}
 	t.EmailChangeEmail = &Template{Enable: true, FileName: "email_change.template", Subject: "邮箱变更通知", Type: EmailEmailChange}
+	t.EmailChangeVerifyEmail = &Template{Enable: true, FileName: "email_change_verify.template", Subject: "新邮箱验证码", Type: EmailEmailChangeVerify}
+	t.DigestEmail = &Template{Enable: true, FileName: "digest.template", Subject: "通知汇总", Type: EmailDigest, Priority: PriorityBulk}
 }
 
-func (t *TemplateConfig) Verify() (bool, error) {
-	fields := []*Template{t.VerifyCodeEmail, t.WelcomeEmail, t.RatingChangeEmail, t.KickedFromServerEmail,
+func (t *TemplateConfig) Fields() []*Template {
+	return []*Template{t.VerifyCodeEmail, t.WelcomeEmail, t.RatingChangeEmail, t.KickedFromServerEmail,
 		t.PasswordChangeEmail, t.PasswordResetEmail, t.ApplicationPassedEmail, t.ApplicationRejectedEmail,
 		t.ApplicationProcessingEmail, t.TicketReplyEmail, t.ActivityPilotJoinEmail, t.ActivityPilotLeaveEmail,
 		t.ActivityAtcJoinEmail, t.ActivityAtcLeaveEmail, t.InstructorChangeEmail, t.BannedEmail, t.UnbannedEmail,
-		t.RoleChangeEmail, t.PermissionChangeEmail}
+		t.RoleChangeEmail, t.PermissionChangeEmail, t.EmailChangeEmail, t.EmailChangeVerifyEmail, t.DigestEmail}
+}
+
+// Find 查找邮件类型对应的模板配置
+func (t *TemplateConfig) Find(emailType Email) *Template {
+	for _, field := range t.Fields() {
+		if field.Type == emailType {
+			return field
+		}
+	}
+	return nil
+}
+
+// Reload 重新加载所有已启用的模板, 全部解析成功后才替换, 任一模板加载失败时保留原有模板并返回错误
+func (t *TemplateConfig) Reload() error {
+	fields := make([]*Template, 0, len(Emails))
+	for _, field := range t.Fields() {
+		if field.Type.Data.Enabled() {
+			fields = append(fields, field)
+		}
+	}
+	parsed := make([]*template.Template, len(fields))
+	eg := errgroup.Group{}
+	for i, field := range fields {
+		eg.Go(func() (err error) {
+			parsed[i], err = field.parse()
+			return
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+	for i, field := range fields {
+		field.Type.Data.SetTemplate(parsed[i])
+	}
+	return nil
+}
+
+func (t *TemplateConfig) Verify() (bool, error) {
+	fields := t.Fields()
 	utils.ForEach(fields, func(_ int, field *Template) {
 		field.LocalPath = t.LocalPath
+		field.Funcs = t.Funcs
 	})
 	eg := errgroup.Group{}
 	for _, field := range fields {
@@ -130,33 +264,58 @@ func (t *TemplateConfig) Verify() (bool, error) {
 
 type TemplatesConfig struct {
 	LocalPath string          `yaml:"local_path"`
+	SiteUrl   string          `yaml:"site_url"`
 	Templates *TemplateConfig `yaml:"templates"`
 }
 
 func (t *TemplatesConfig) InitDefaults() {
 	t.LocalPath = "data/templates"
+	t.SiteUrl = ""
 	t.Templates = &TemplateConfig{}
 	t.Templates.InitDefaults()
 }
 
 func (t *TemplatesConfig) Verify() (bool, error) {
+	if t.SiteUrl != "" {
+		if siteUrl, err := url.Parse(t.SiteUrl); err != nil || !siteUrl.IsAbs() {
+			return false, fmt.Errorf("invalid site url %s", t.SiteUrl)
+		}
+	}
 	t.Templates.LocalPath = t.LocalPath
+	t.Templates.Funcs = render.FuncMap(t.SiteUrl)
 	return t.Templates.Verify()
 }
 
 type EmailConfig struct {
-	Host           string           `yaml:"host"`
-	Port           int              `yaml:"port"`
-	Username       string           `yaml:"username"`
-	Password       string           `yaml:"password"`
-	VerifyExpire   string           `yaml:"verify_expire"`
-	VerifyInterval string           `yaml:"verify_interval"`
-	Template       *TemplatesConfig `yaml:"template"`
+	Host           string               `yaml:"host"`
+	Port           int                  `yaml:"port"`
+	Username       string               `yaml:"username"`
+	Password       string               `yaml:"password"`
+	From           string               `yaml:"from"`
+	FromName       string               `yaml:"from_name"`
+	ReplyTo        string               `yaml:"reply_to"`
+	Headers        map[string]string    `yaml:"headers"`
+	VerifyExpire   string               `yaml:"verify_expire"`
+	VerifyInterval string               `yaml:"verify_interval"`
+	Timezone       string               `yaml:"timezone"`
+	CodeLimit      *CodeLimitConfig     `yaml:"code_limit"`
+	Captcha        *CaptchaConfig       `yaml:"captcha"`
+	AddressCheck   *AddressCheckConfig  `yaml:"address_check"`
+	Transport      *TransportConfig     `yaml:"transport"`
+	Dkim           *DkimConfig          `yaml:"dkim"`
+	EmailChange    *EmailChangeConfig   `yaml:"email_change"`
+	PasswordReset  *PasswordResetConfig `yaml:"password_reset"`
+	Sandbox        *SandboxConfig       `yaml:"sandbox"`
+	Async          *AsyncConfig         `yaml:"async"`
+	Digest         *DigestConfig        `yaml:"digest"`
+	Priority       *PriorityConfig      `yaml:"priority"`
+	Calendar       *CalendarConfig      `yaml:"calendar"`
+	Template       *TemplatesConfig     `yaml:"template"`
 	// 内部字段
-	VerifyExpireDuration   time.Duration     `yaml:"-"`
-	VerifyIntervalDuration time.Duration     `yaml:"-"`
-	Server                 *gomail.Dialer    `json:"-"`
-	Closer                 gomail.SendCloser `json:"-"`
+	VerifyExpireDuration   time.Duration  `yaml:"-"`
+	VerifyIntervalDuration time.Duration  `yaml:"-"`
+	Location               *time.Location `yaml:"-"`
+	Server                 *gomail.Dialer `json:"-"`
 }
 
 func (e *EmailConfig) InitDefaults() {
@@ -164,36 +323,94 @@ func (e *EmailConfig) InitDefaults() {
 	e.Port = 587
 	e.Username = ""
 	e.Password = ""
+	e.From = ""
+	e.FromName = ""
+	e.ReplyTo = ""
+	e.Headers = map[string]string{}
 	e.VerifyExpire = "5m"
 	e.VerifyInterval = "1m"
+	e.Timezone = "Asia/Shanghai"
+	e.CodeLimit = &CodeLimitConfig{}
+	e.CodeLimit.InitDefaults()
+	e.Captcha = &CaptchaConfig{}
+	e.Captcha.InitDefaults()
+	e.AddressCheck = &AddressCheckConfig{}
+	e.AddressCheck.InitDefaults()
+	e.Transport = &TransportConfig{}
+	e.Transport.InitDefaults()
+	e.Dkim = &DkimConfig{}
+	e.Dkim.InitDefaults()
+	e.EmailChange = &EmailChangeConfig{}
+	e.EmailChange.InitDefaults()
+	e.PasswordReset = &PasswordResetConfig{}
+	e.PasswordReset.InitDefaults()
+	e.Sandbox = &SandboxConfig{}
+	e.Sandbox.InitDefaults()
+	e.Async = &AsyncConfig{}
+	e.Async.InitDefaults()
+	e.Digest = &DigestConfig{}
+	e.Digest.InitDefaults()
+	e.Priority = &PriorityConfig{}
+	e.Priority.InitDefaults()
+	e.Calendar = &CalendarConfig{}
+	e.Calendar.InitDefaults()
 	e.Template = &TemplatesConfig{}
 	e.Template.InitDefaults()
 }
 
 //goland:noinspection GoRedundantElseInIf
 func (e *EmailConfig) Verify() (bool, error) {
-	if e.Host == "" {
-		return false, errors.New("smtp server host cannot be empty")
+	if e.Username == "" {
+		return false, errors.New("smtp server username cannot be empty")
 	}
-	if e.Port <= 0 {
-		return false, errors.New("smtp server port cannot be less than or equal to 0")
+	// 未单独配置发件地址时使用SMTP用户名, 用户名不是邮箱地址时必须配置发件地址
+	if e.From == "" {
+		if !strings.Contains(e.Username, "@") {
+			return false, errors.New("from address must be set when smtp username is not an email address")
+		}
+		e.From = e.Username
 	}
-	if e.Port >= 65535 {
-		return false, errors.New("smtp server port cannot be greater than 65535")
+	if err := verifyAddresses("from", e.From); err != nil {
+		return false, err
 	}
-	if e.Username == "" {
-		return false, errors.New("smtp server username cannot be empty")
+	if e.ReplyTo != "" {
+		if err := verifyAddresses("reply to", e.ReplyTo); err != nil {
+			return false, err
+		}
 	}
-	if e.Password == "" {
-		return false, errors.New("smtp server password cannot be empty")
+	if err := verifyHeaders(e.Headers); err != nil {
+		return false, err
 	}
-
-	e.Server = gomail.NewDialer(e.Host, e.Port, e.Username, e.Password)
-	dial, err := e.Server.Dial()
-	if err != nil {
-		return false, fmt.Errorf("connect to smtp server fail, %v", err)
+	if location, err := time.LoadLocation(e.Timezone); err != nil {
+		return false, err
+	} else {
+		e.Location = location
+	}
+	if ok, err := e.Sandbox.Verify(); !ok {
+		return ok, err
+	}
+	if ok, err := e.Transport.Verify(); !ok {
+		return ok, err
+	}
+	if ok, err := e.Dkim.Verify(); !ok {
+		return ok, err
+	}
+	// 沙盒模式下不使用发送通道, 由沙盒收件箱接管发送
+	if !e.Sandbox.Enable && e.Transport.Type == TransportSmtp {
+		if e.Host == "" {
+			return false, errors.New("smtp server host cannot be empty")
+		}
+		if e.Port <= 0 {
+			return false, errors.New("smtp server port cannot be less than or equal to 0")
+		}
+		if e.Port >= 65535 {
+			return false, errors.New("smtp server port cannot be greater than 65535")
+		}
+		if e.Password == "" {
+			return false, errors.New("smtp server password cannot be empty")
+		}
+		e.Server = gomail.NewDialer(e.Host, e.Port, e.Username, e.Password)
 	}
-	e.Closer = dial
 
 	if e.VerifyExpire == "" {
 		return false, errors.New("verify expire cannot be empty")
@@ -211,37 +428,111 @@ func (e *EmailConfig) Verify() (bool, error) {
 	} else {
 		e.VerifyIntervalDuration = duration
 	}
+	if ok, err := e.CodeLimit.Verify(); !ok {
+		return ok, err
+	}
+	if ok, err := e.Captcha.Verify(); !ok {
+		return ok, err
+	}
+	if ok, err := e.AddressCheck.Verify(); !ok {
+		return ok, err
+	}
+	if ok, err := e.EmailChange.Verify(); !ok {
+		return ok, err
+	}
+	if ok, err := e.PasswordReset.Verify(); !ok {
+		return ok, err
+	}
+	if ok, err := e.Async.Verify(); !ok {
+		return ok, err
+	}
+	if ok, err := e.Digest.Verify(); !ok {
+		return ok, err
+	}
+	if ok, err := e.Priority.Verify(); !ok {
+		return ok, err
+	}
+	if ok, err := e.Calendar.Verify(); !ok {
+		return ok, err
+	}
+	if e.Digest.Enable && !e.Template.Templates.DigestEmail.Enable {
+		return false, errors.New("digest email template must be enabled when digest is enabled")
+	}
 	return e.Template.Verify()
 }
 
+// EmailData 邮件类型的配置, 除启用状态与模板外的字段只在配置校验时写入;
+// 启用状态与模板可能在运行时被管理接口修改, 需通过方法并发安全地读写
 type EmailData struct {
-	Enable     bool
-	Template   *template.Template
 	RemotePath string
 	Subject    string
+	FromName   string
+	ReplyTo    string
+	Cc         []string
+	Bcc        []string
+	Headers    map[string]string
+	Delivery   string
+	Priority   string
+	Markdown   []string
+	// 模板参数的结构体类型, 由email包注册, 用于校验markdown字段
+	DataType reflect.Type
+	enable   atomic.Bool
+	template atomic.Pointer[template.Template]
 }
 
+func newEmailData(remotePath string) *EmailData {
+	data := &EmailData{RemotePath: remotePath}
+	data.enable.Store(true)
+	return data
+}
+
+func (d *EmailData) Enabled() bool { return d.enable.Load() }
+
+func (d *EmailData) SetEnable(enable bool) { d.enable.Store(enable) }
+
+// Template 返回当前生效的模板, 尚未加载时返回nil
+func (d *EmailData) Template() *template.Template { return d.template.Load() }
+
+func (d *EmailData) SetTemplate(t *template.Template) { d.template.Store(t) }
+
 type Email *utils.Enum[string, *EmailData]
 
 var (
-	EmailVerifyCode            = utils.NewEnum("verify_code", &EmailData{Enable: true, RemotePath: "/docker/data/templates/verify_code.template"})
-	EmailWelcome               = utils.NewEnum("welcome", &EmailData{Enable: true, RemotePath: "/docker/data/templates/welcome.template"})
-	EmailRatingChange          = utils.NewEnum("rating_change", &EmailData{Enable: true, RemotePath: "/docker/data/templates/atc_rating_change.template"})
-	EmailKickedFromServer      = utils.NewEnum("kicked_from_server", &EmailData{Enable: true, RemotePath: "/docker/data/templates/kicked_from_server.template"})
-	EmailPasswordChange        = utils.NewEnum("password_change", &EmailData{Enable: true, RemotePath: "/docker/data/templates/password_change.template"})
-	EmailPasswordReset         = utils.NewEnum("password_reset", &EmailData{Enable: true, RemotePath: "/docker/data/templates/password_reset.template"})
-	EmailApplicationPassed     = utils.NewEnum("application_passed", &EmailData{Enable: true, RemotePath: "/docker/data/templates/application_passed.template"})
-	EmailApplicationRejected   = utils.NewEnum("application_rejected", &EmailData{Enable: true, RemotePath: "/docker/data/templates/application_rejected.template"})
-	EmailApplicationProcessing = utils.NewEnum("application_processing", &EmailData{Enable: true, RemotePath: "/docker/data/templates/application_processing.template"})
-	EmailTicketReply           = utils.NewEnum("ticket_reply", &EmailData{Enable: true, RemotePath: "/docker/data/templates/ticket_reply.template"})
-	EmailActivityPilotJoin     = utils.NewEnum("activity_pilot_join", &EmailData{Enable: true, RemotePath: "/docker/data/templates/activity_pilot_join.template"})
-	EmailActivityPilotLeave    = utils.NewEnum("activity_pilot_leave", &EmailData{Enable: true, RemotePath: "/docker/data/templates/activity_pilot_leave.template"})
-	EmailActivityAtcJoin       = utils.NewEnum("activity_atc_join", &EmailData{Enable: true, RemotePath: "/docker/data/templates/activity_atc_join.template"})
-	EmailActivityAtcLeave      = utils.NewEnum("activity_atc_leave", &EmailData{Enable: true, RemotePath: "/docker/data/templates/activity_atc_leave.template"})
-	EmailInstructorChange      = utils.NewEnum("instructor_change", &EmailData{Enable: true, RemotePath: "/docker/data/templates/instructor_change.template"})
-	EmailBanned                = utils.NewEnum("banned", &EmailData{Enable: true, RemotePath: "/docker/data/templates/banned.template"})
-	EmailUnbanned              = utils.NewEnum("unbanned", &EmailData{Enable: true, RemotePath: "/docker/data/templates/unbanned.template"})
-	EmailRoleChange            = utils.NewEnum("role_change", &EmailData{Enable: true, RemotePath: "/docker/data/templates/role_change.template"})
-	EmailPermissionChange      = utils.NewEnum("permission_change", &EmailData{Enable: true, RemotePath: "/docker/data/templates/permission_change.template"})
-	EmailEmailChange           = utils.NewEnum("email_change", &EmailData{Enable: true, RemotePath: "/docker/data/templates/email_change.template"})
+	EmailVerifyCode            = utils.NewEnum("verify_code", newEmailData("/docker/data/templates/verify_code.template"))
+	EmailWelcome               = utils.NewEnum("welcome", newEmailData("/docker/data/templates/welcome.template"))
+	EmailRatingChange          = utils.NewEnum("rating_change", newEmailData("/docker/data/templates/atc_rating_change.template"))
+	EmailKickedFromServer      = utils.NewEnum("kicked_from_server", newEmailData("/docker/data/templates/kicked_from_server.template"))
+	EmailPasswordChange        = utils.NewEnum("password_change", newEmailData("/docker/data/templates/password_change.template"))
+	EmailPasswordReset         = utils.NewEnum("password_reset", newEmailData("/docker/data/templates/password_reset.template"))
+	EmailApplicationPassed     = utils.NewEnum("application_passed", newEmailData("/docker/data/templates/application_passed.template"))
+	EmailApplicationRejected   = utils.NewEnum("application_rejected", newEmailData("/docker/data/templates/application_rejected.template"))
+	EmailApplicationProcessing = utils.NewEnum("application_processing", newEmailData("/docker/data/templates/application_processing.template"))
+	EmailTicketReply           = utils.NewEnum("ticket_reply", newEmailData("/docker/data/templates/ticket_reply.template"))
+	EmailActivityPilotJoin     = utils.NewEnum("activity_pilot_join", newEmailData("/docker/data/templates/activity_pilot_join.template"))
+	EmailActivityPilotLeave    = utils.NewEnum("activity_pilot_leave", newEmailData("/docker/data/templates/activity_pilot_leave.template"))
+	EmailActivityAtcJoin       = utils.NewEnum("activity_atc_join", newEmailData("/docker/data/templates/activity_atc_join.template"))
+	EmailActivityAtcLeave      = utils.NewEnum("activity_atc_leave", newEmailData("/docker/data/templates/activity_atc_leave.template"))
+	EmailInstructorChange      = utils.NewEnum("instructor_change", newEmailData("/docker/data/templates/instructor_change.template"))
+	EmailBanned                = utils.NewEnum("banned", newEmailData("/docker/data/templates/banned.template"))
+	EmailUnbanned              = utils.NewEnum("unbanned", newEmailData("/docker/data/templates/unbanned.template"))
+	EmailRoleChange            = utils.NewEnum("role_change", newEmailData("/docker/data/templates/role_change.template"))
+	EmailPermissionChange      = utils.NewEnum("permission_change", newEmailData("/docker/data/templates/permission_change.template"))
+	EmailEmailChange           = utils.NewEnum("email_change", newEmailData("/docker/data/templates/email_change.template"))
+	EmailEmailChangeVerify     = utils.NewEnum("email_change_verify", newEmailData("/docker/data/templates/email_change_verify.template"))
+	EmailDigest                = utils.NewEnum("digest", newEmailData("/docker/data/templates/digest.template"))
 )
+
+var Emails = []Email{EmailVerifyCode, EmailWelcome, EmailRatingChange, EmailKickedFromServer, EmailPasswordChange,
+	EmailPasswordReset, EmailApplicationPassed, EmailApplicationRejected, EmailApplicationProcessing, EmailTicketReply,
+	EmailActivityPilotJoin, EmailActivityPilotLeave, EmailActivityAtcJoin, EmailActivityAtcLeave, EmailInstructorChange,
+	EmailBanned, EmailUnbanned, EmailRoleChange, EmailPermissionChange, EmailEmailChange, EmailEmailChangeVerify,
+	EmailDigest}
+
+func FindEmail(value string) (Email, bool) {
+	for _, emailType := range Emails {
+		if emailType.Value == value {
+			return emailType, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// EmailChangeTokenPlaceholder 取消链接中的令牌占位符
+const EmailChangeTokenPlaceholder = "{token}"
+
+type EmailChangeConfig struct {
+	CodeExpire   string `yaml:"code_expire"`
+	RevertWindow string `yaml:"revert_window"`
+	MaxAttempts  int    `yaml:"max_attempts"`
+	CancelUrl    string `yaml:"cancel_url"`
+	// 内部字段
+	CodeExpireDuration   time.Duration `yaml:"-"`
+	RevertWindowDuration time.Duration `yaml:"-"`
+}
+
+func (e *EmailChangeConfig) InitDefaults() {
+	e.CodeExpire = "30m"
+	e.RevertWindow = "72h"
+	e.MaxAttempts = 5
+	e.CancelUrl = "https://example.com/email/change/cancel?token=" + EmailChangeTokenPlaceholder
+}
+
+//goland:noinspection GoRedundantElseInIf
+func (e *EmailChangeConfig) Verify() (bool, error) {
+	if duration, err := time.ParseDuration(e.CodeExpire); err != nil {
+		return false, err
+	} else {
+		e.CodeExpireDuration = duration
+	}
+	if duration, err := time.ParseDuration(e.RevertWindow); err != nil {
+		return false, err
+	} else {
+		e.RevertWindowDuration = duration
+	}
+	if e.MaxAttempts <= 0 {
+		return false, errors.New("email change max attempts must be greater than 0")
+	}
+	if !strings.Contains(e.CancelUrl, EmailChangeTokenPlaceholder) {
+		return false, errors.New("email change cancel url must contain " + EmailChangeTokenPlaceholder)
+	}
+	return true, nil
+}
+
+// BuildCancelUrl 生成带有令牌的取消链接
+func (e *EmailChangeConfig) BuildCancelUrl(token string) string {
+	return strings.ReplaceAll(e.CancelUrl, EmailChangeTokenPlaceholder, token)
+}
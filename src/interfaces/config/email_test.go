@@ -0,0 +1,78 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config_test
+package config_test
+
+import (
+	"email-service/src/interfaces/config"
+	_ "email-service/src/interfaces/email"
+	"testing"
+)
+
+func TestTemplateVerifyMarkdownFields(t *testing.T) {
+	tests := []struct {
+		name      string
+		emailType config.Email
+		markdown  []string
+		wantErr   bool
+	}{
+		{"text field", config.EmailTicketReply, []string{"Reply"}, false},
+		{"several text fields", config.EmailBanned, []string{"Reason", "Contact"}, false},
+		{"unknown field", config.EmailTicketReply, []string{"Body"}, true},
+		{"case mismatch", config.EmailTicketReply, []string{"reply"}, true},
+		{"non text field", config.EmailApplicationProcessing, []string{"Time"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 未启用的模板只校验配置, 不会读取模板文件
+			template := &config.Template{Type: tt.emailType, Markdown: tt.markdown}
+			ok, err := template.Verify()
+			if ok == tt.wantErr || (err != nil) != tt.wantErr {
+				t.Errorf("Verify() = %t, %v, wantErr %t", ok, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEmailConfigVerifyFromAddress(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		from     string
+		want     string
+		wantErr  bool
+	}{
+		{"username is address", "noreply@example.com", "", "noreply@example.com", false},
+		{"separate from", "AKIAEXAMPLE", "noreply@example.com", "noreply@example.com", false},
+		{"from overrides address username", "smtp@example.com", "noreply@example.com", "noreply@example.com", false},
+		{"username is not address", "AKIAEXAMPLE", "", "", true},
+		{"invalid from", "AKIAEXAMPLE", "noreply", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &config.EmailConfig{}
+			c.InitDefaults()
+			c.Username = tt.username
+			c.From = tt.from
+			c.Password = "secret"
+			// 关闭模板, 只校验发件配置
+			for _, field := range c.Template.Templates.Fields() {
+				field.Enable = false
+			}
+			ok, err := c.Verify()
+			if tt.wantErr {
+				if ok || err == nil {
+					t.Fatalf("Verify() = %t, %v, want error", ok, err)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if c.From != tt.want {
+				t.Errorf("from = %q, want %q", c.From, tt.want)
+			}
+		})
+	}
+}
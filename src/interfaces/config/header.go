@@ -0,0 +1,41 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import (
+	"fmt"
+	"net/mail"
+	"net/textproto"
+	"slices"
+	"strings"
+)
+
+// reservedHeaders 由发送器自行设置的邮件头, 不允许通过自定义邮件头覆盖
+var reservedHeaders = []string{"From", "To", "Cc", "Bcc", "Subject", "Reply-To", "Date", "Message-Id",
+	"Mime-Version", "Content-Type", "Content-Transfer-Encoding", "In-Reply-To", "References", "Dkim-Signature"}
+
+func verifyAddresses(field string, addresses ...string) error {
+	for _, address := range addresses {
+		if _, err := mail.ParseAddress(address); err != nil {
+			return fmt.Errorf("invalid %s address %s, %v", field, address, err)
+		}
+	}
+	return nil
+}
+
+func verifyHeaders(headers map[string]string) error {
+	for key, value := range headers {
+		if key == "" || strings.ContainsAny(key, " :\r\n") {
+			return fmt.Errorf("invalid header name %q", key)
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			return fmt.Errorf("header %s cannot contain line breaks", key)
+		}
+		if slices.Contains(reservedHeaders, textproto.CanonicalMIMEHeaderKey(key)) {
+			return fmt.Errorf("header %s cannot be overridden", key)
+		}
+	}
+	return nil
+}
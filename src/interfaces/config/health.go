@@ -0,0 +1,59 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+type HealthConfig struct {
+	Interval            string `yaml:"interval"`
+	Timeout             string `yaml:"timeout"`
+	SmtpInterval        string `yaml:"smtp_interval"`
+	FailureThreshold    int    `yaml:"failure_threshold"`
+	MaxBacklog          int    `yaml:"max_backlog"`
+	DeregisterOnFailure bool   `yaml:"deregister_on_failure"`
+	// 内部字段
+	IntervalDuration time.Duration `yaml:"-"`
+	TimeoutDuration  time.Duration `yaml:"-"`
+	// SMTP服务器检查需要建立新的连接, 按单独的间隔执行
+	SmtpIntervalDuration time.Duration `yaml:"-"`
+}
+
+func (h *HealthConfig) InitDefaults() {
+	h.Interval = "30s"
+	h.Timeout = "10s"
+	h.SmtpInterval = "5m"
+	h.FailureThreshold = 3
+	h.MaxBacklog = 100
+	h.DeregisterOnFailure = true
+}
+
+//goland:noinspection GoRedundantElseInIf
+func (h *HealthConfig) Verify() (bool, error) {
+	if duration, err := time.ParseDuration(h.Interval); err != nil {
+		return false, err
+	} else {
+		h.IntervalDuration = duration
+	}
+	if duration, err := time.ParseDuration(h.Timeout); err != nil {
+		return false, err
+	} else {
+		h.TimeoutDuration = duration
+	}
+	if duration, err := time.ParseDuration(h.SmtpInterval); err != nil {
+		return false, err
+	} else {
+		h.SmtpIntervalDuration = duration
+	}
+	if h.FailureThreshold <= 0 {
+		return false, errors.New("health failure threshold must be greater than 0")
+	}
+	if h.MaxBacklog < 0 {
+		return false, errors.New("health max backlog cannot be less than 0")
+	}
+	return true, nil
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+type IdempotencyConfig struct {
+	Enable bool   `yaml:"enable"`
+	Ttl    string `yaml:"ttl"`
+	Header string `yaml:"header"`
+	// 内部字段
+	TtlDuration time.Duration `yaml:"-"`
+}
+
+func (i *IdempotencyConfig) InitDefaults() {
+	i.Enable = true
+	i.Ttl = "24h"
+	i.Header = "Idempotency-Key"
+}
+
+//goland:noinspection GoRedundantElseInIf
+func (i *IdempotencyConfig) Verify() (bool, error) {
+	if !i.Enable {
+		return true, nil
+	}
+	if duration, err := time.ParseDuration(i.Ttl); err != nil {
+		return false, err
+	} else {
+		i.TtlDuration = duration
+	}
+	if i.TtlDuration <= 0 {
+		return false, errors.New("idempotency ttl must be greater than 0")
+	}
+	if i.Header == "" {
+		return false, errors.New("idempotency header cannot be empty")
+	}
+	return true, nil
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import "errors"
+
+// CodeLimitConfig 验证码接口的每小时配额, 0表示不限制
+type CodeLimitConfig struct {
+	IpHourlyLimit          int    `yaml:"ip_hourly_limit"`
+	FingerprintHourlyLimit int    `yaml:"fingerprint_hourly_limit"`
+	FingerprintHeader      string `yaml:"fingerprint_header"`
+	GlobalHourlyLimit      int    `yaml:"global_hourly_limit"`
+}
+
+func (c *CodeLimitConfig) InitDefaults() {
+	c.IpHourlyLimit = 10
+	c.FingerprintHourlyLimit = 10
+	c.FingerprintHeader = ""
+	c.GlobalHourlyLimit = 1000
+}
+
+func (c *CodeLimitConfig) Verify() (bool, error) {
+	if c.IpHourlyLimit < 0 {
+		return false, errors.New("ip hourly limit cannot be less than 0")
+	}
+	if c.FingerprintHourlyLimit < 0 {
+		return false, errors.New("fingerprint hourly limit cannot be less than 0")
+	}
+	if c.GlobalHourlyLimit < 0 {
+		return false, errors.New("global hourly limit cannot be less than 0")
+	}
+	return true, nil
+}
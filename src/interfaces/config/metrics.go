@@ -0,0 +1,27 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import (
+	"errors"
+	"strings"
+)
+
+type MetricsConfig struct {
+	Enable bool   `yaml:"enable"`
+	Path   string `yaml:"path"`
+}
+
+func (m *MetricsConfig) InitDefaults() {
+	m.Enable = true
+	m.Path = "/metrics"
+}
+
+func (m *MetricsConfig) Verify() (bool, error) {
+	if m.Enable && !strings.HasPrefix(m.Path, "/") {
+		return false, errors.New("metrics path must start with /")
+	}
+	return true, nil
+}
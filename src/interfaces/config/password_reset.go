@@ -0,0 +1,51 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	PasswordResetCidPlaceholder   = "{cid}"
+	PasswordResetTokenPlaceholder = "{token}"
+)
+
+type PasswordResetConfig struct {
+	TokenExpire string `yaml:"token_expire"`
+	MaxAttempts int    `yaml:"max_attempts"`
+	ResetUrl    string `yaml:"reset_url"`
+	// 内部字段
+	TokenExpireDuration time.Duration `yaml:"-"`
+}
+
+func (p *PasswordResetConfig) InitDefaults() {
+	p.TokenExpire = "30m"
+	p.MaxAttempts = 5
+	p.ResetUrl = "https://example.com/password/reset?cid=" + PasswordResetCidPlaceholder + "&token=" + PasswordResetTokenPlaceholder
+}
+
+//goland:noinspection GoRedundantElseInIf
+func (p *PasswordResetConfig) Verify() (bool, error) {
+	if duration, err := time.ParseDuration(p.TokenExpire); err != nil {
+		return false, err
+	} else {
+		p.TokenExpireDuration = duration
+	}
+	if p.MaxAttempts <= 0 {
+		return false, errors.New("password reset max attempts must be greater than 0")
+	}
+	if !strings.Contains(p.ResetUrl, PasswordResetTokenPlaceholder) {
+		return false, errors.New("password reset url must contain " + PasswordResetTokenPlaceholder)
+	}
+	return true, nil
+}
+
+// BuildResetUrl 生成带有CID与令牌的重置链接
+func (p *PasswordResetConfig) BuildResetUrl(cid string, token string) string {
+	return strings.NewReplacer(PasswordResetCidPlaceholder, cid, PasswordResetTokenPlaceholder, token).Replace(p.ResetUrl)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// MandatoryEmails 涉及账户安全与处罚的邮件, 用户不能退订
+var MandatoryEmails = []Email{EmailVerifyCode, EmailPasswordChange, EmailPasswordReset, EmailBanned, EmailUnbanned,
+	EmailKickedFromServer, EmailEmailChange, EmailEmailChangeVerify}
+
+const (
+	PreferenceStoreMemory = "memory"
+	PreferenceStoreFile   = "file"
+)
+
+// PreferenceConfig 用户通知偏好配置, 用户可以按分类退订邮件
+type PreferenceConfig struct {
+	Enable     bool                `yaml:"enable"`
+	Token      string              `yaml:"token"`
+	Store      string              `yaml:"store"`
+	Path       string              `yaml:"path"`
+	Categories map[string][]string `yaml:"categories"`
+	// 内部字段
+	EmailCategories map[string]string `yaml:"-"`
+}
+
+func (p *PreferenceConfig) InitDefaults() {
+	p.Enable = false
+	p.Token = ""
+	p.Store = PreferenceStoreFile
+	p.Path = "data/preferences"
+	p.Categories = map[string][]string{
+		"activity": {EmailActivityAtcJoin.Value, EmailActivityAtcLeave.Value, EmailActivityPilotJoin.Value, EmailActivityPilotLeave.Value},
+		"ticket":   {EmailTicketReply.Value},
+	}
+}
+
+func (p *PreferenceConfig) Verify() (bool, error) {
+	if !p.Enable {
+		return true, nil
+	}
+	if len(p.Token) < 16 {
+		return false, errors.New("preference token must be at least 16 characters")
+	}
+	switch p.Store {
+	case PreferenceStoreMemory:
+	case PreferenceStoreFile:
+		if p.Path == "" {
+			return false, errors.New("preference path cannot be empty")
+		}
+	default:
+		return false, fmt.Errorf("unknown preference store %s", p.Store)
+	}
+	p.EmailCategories = make(map[string]string)
+	for category, emails := range p.Categories {
+		if category == "" {
+			return false, errors.New("preference category name cannot be empty")
+		}
+		for _, value := range emails {
+			emailType, ok := FindEmail(value)
+			if !ok {
+				return false, fmt.Errorf("unknown email type %s in preference category %s", value, category)
+			}
+			if slices.Contains(MandatoryEmails, emailType) {
+				return false, fmt.Errorf("email type %s cannot be opted out", value)
+			}
+			if existing, ok := p.EmailCategories[value]; ok {
+				return false, fmt.Errorf("email type %s belongs to both %s and %s", value, existing, category)
+			}
+			p.EmailCategories[value] = category
+		}
+	}
+	return true, nil
+}
+
+// Category 返回邮件所属的分类, 不属于任何分类的邮件不能退订
+func (p *PreferenceConfig) Category(emailType Email) (string, bool) {
+	category, ok := p.EmailCategories[emailType.Value]
+	return category, ok
+}
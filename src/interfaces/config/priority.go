@@ -0,0 +1,68 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// 邮件优先级, 每个优先级对应一条独立限制并发的发送通道
+const (
+	PriorityCritical = "critical"
+	PriorityNormal   = "normal"
+	PriorityBulk     = "bulk"
+)
+
+var Priorities = []string{PriorityCritical, PriorityNormal, PriorityBulk}
+
+// PriorityConfig 各优先级通道允许同时发送的邮件数量
+type PriorityConfig struct {
+	Critical int `yaml:"critical"`
+	Normal   int `yaml:"normal"`
+	Bulk     int `yaml:"bulk"`
+	// 等待通道空闲位置的最长时间
+	Timeout string `yaml:"timeout"`
+	// 关键邮件是否使用独立的SMTP连接, 不与其他邮件排队等待共享连接
+	DedicatedCritical bool `yaml:"dedicated_critical"`
+	// 内部字段
+	TimeoutDuration time.Duration `yaml:"-"`
+}
+
+func (p *PriorityConfig) InitDefaults() {
+	p.Critical = 4
+	p.Normal = 2
+	p.Bulk = 1
+	p.Timeout = "30s"
+	p.DedicatedCritical = true
+}
+
+//goland:noinspection GoRedundantElseInIf
+func (p *PriorityConfig) Verify() (bool, error) {
+	if p.Critical <= 0 || p.Normal <= 0 || p.Bulk <= 0 {
+		return false, errors.New("priority concurrency must be greater than 0")
+	}
+	if duration, err := time.ParseDuration(p.Timeout); err != nil {
+		return false, err
+	} else {
+		p.TimeoutDuration = duration
+	}
+	if p.TimeoutDuration <= 0 {
+		return false, errors.New("priority timeout must be greater than 0")
+	}
+	return true, nil
+}
+
+// Concurrency 返回指定优先级通道的并发数
+func (p *PriorityConfig) Concurrency(priority string) int {
+	switch priority {
+	case PriorityCritical:
+		return p.Critical
+	case PriorityBulk:
+		return p.Bulk
+	default:
+		return p.Normal
+	}
+}
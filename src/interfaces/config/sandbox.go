@@ -0,0 +1,54 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	SandboxSinkMemory  = "memory"
+	SandboxSinkMaildir = "maildir"
+)
+
+type SandboxConfig struct {
+	Enable      bool   `yaml:"enable"`
+	Sink        string `yaml:"sink"`
+	Capacity    int    `yaml:"capacity"`
+	MaildirPath string `yaml:"maildir_path"`
+	InboxPath   string `yaml:"inbox_path"`
+}
+
+func (s *SandboxConfig) InitDefaults() {
+	s.Enable = false
+	s.Sink = SandboxSinkMemory
+	s.Capacity = 100
+	s.MaildirPath = "data/maildir"
+	s.InboxPath = "/sandbox"
+}
+
+func (s *SandboxConfig) Verify() (bool, error) {
+	if !s.Enable {
+		return true, nil
+	}
+	switch s.Sink {
+	case SandboxSinkMemory:
+	case SandboxSinkMaildir:
+		if s.MaildirPath == "" {
+			return false, errors.New("sandbox maildir path cannot be empty")
+		}
+	default:
+		return false, fmt.Errorf("unknown sandbox sink %s", s.Sink)
+	}
+	if s.Capacity <= 0 {
+		return false, errors.New("sandbox capacity must be greater than 0")
+	}
+	if !strings.HasPrefix(s.InboxPath, "/") {
+		return false, errors.New("sandbox inbox path must start with /")
+	}
+	return true, nil
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const (
+	TransportSmtp     = "smtp"
+	TransportSendmail = "sendmail"
+	TransportFile     = "file"
+	TransportHttp     = "http"
+)
+
+type SendmailTransportConfig struct {
+	Path string   `yaml:"path"`
+	Args []string `yaml:"args"`
+}
+
+func (s *SendmailTransportConfig) InitDefaults() {
+	s.Path = "/usr/sbin/sendmail"
+	s.Args = []string{"-i"}
+}
+
+func (s *SendmailTransportConfig) Verify() (bool, error) {
+	if s.Path == "" {
+		return false, errors.New("sendmail path cannot be empty")
+	}
+	if _, err := os.Stat(s.Path); err != nil {
+		return false, fmt.Errorf("sendmail binary not found, %v", err)
+	}
+	return true, nil
+}
+
+type FileTransportConfig struct {
+	Path string `yaml:"path"`
+}
+
+func (f *FileTransportConfig) InitDefaults() {
+	f.Path = "data/outbox"
+}
+
+func (f *FileTransportConfig) Verify() (bool, error) {
+	if f.Path == "" {
+		return false, errors.New("file transport path cannot be empty")
+	}
+	return true, nil
+}
+
+// HttpTransportConfig 通用HTTP API发送方式
+// Payload为text/template模板, 可用字段: .From .To .Cc .Bcc .ReplyTo .Subject .Html .Text
+// .Headers(解码后的邮件头) .Raw(base64编码的完整邮件, 不包含密送地址)
+// 可用函数: json(将值编码为JSON)
+type HttpTransportConfig struct {
+	Url     string            `yaml:"url"`
+	Method  string            `yaml:"method"`
+	Headers map[string]string `yaml:"headers"`
+	Payload string            `yaml:"payload"`
+	Timeout string            `yaml:"timeout"`
+	// 内部字段
+	PayloadTemplate *template.Template `yaml:"-"`
+	TimeoutDuration time.Duration      `yaml:"-"`
+}
+
+func (h *HttpTransportConfig) InitDefaults() {
+	h.Url = ""
+	h.Method = http.MethodPost
+	h.Headers = map[string]string{"Content-Type": "application/json"}
+	h.Payload = `{"from":{{json .From}},"to":{{json .To}},"cc":{{json .Cc}},"bcc":{{json .Bcc}},"reply_to":{{json .ReplyTo}},"subject":{{json .Subject}},"html":{{json .Html}},"text":{{json .Text}}}`
+	h.Timeout = "10s"
+}
+
+//goland:noinspection GoRedundantElseInIf
+func (h *HttpTransportConfig) Verify() (bool, error) {
+	if h.Url == "" {
+		return false, errors.New("http transport url cannot be empty")
+	}
+	h.Method = strings.ToUpper(h.Method)
+	if h.Payload == "" {
+		return false, errors.New("http transport payload cannot be empty")
+	}
+	funcs := template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+	}
+	if payload, err := template.New("payload").Funcs(funcs).Parse(h.Payload); err != nil {
+		return false, fmt.Errorf("parse http transport payload fail, %v", err)
+	} else {
+		h.PayloadTemplate = payload
+	}
+	if duration, err := time.ParseDuration(h.Timeout); err != nil {
+		return false, err
+	} else {
+		h.TimeoutDuration = duration
+	}
+	return true, nil
+}
+
+type TransportConfig struct {
+	Type     string                   `yaml:"type"`
+	Sendmail *SendmailTransportConfig `yaml:"sendmail"`
+	File     *FileTransportConfig     `yaml:"file"`
+	Http     *HttpTransportConfig     `yaml:"http"`
+}
+
+func (t *TransportConfig) InitDefaults() {
+	t.Type = TransportSmtp
+	t.Sendmail = &SendmailTransportConfig{}
+	t.Sendmail.InitDefaults()
+	t.File = &FileTransportConfig{}
+	t.File.InitDefaults()
+	t.Http = &HttpTransportConfig{}
+	t.Http.InitDefaults()
+}
+
+func (t *TransportConfig) Verify() (bool, error) {
+	switch t.Type {
+	case TransportSmtp:
+		return true, nil
+	case TransportSendmail:
+		return t.Sendmail.Verify()
+	case TransportFile:
+		return t.File.Verify()
+	case TransportHttp:
+		return t.Http.Verify()
+	default:
+		return false, fmt.Errorf("unknown transport type %s", t.Type)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+)
+
+const (
+	WebhookEventSent         = "email.sent"
+	WebhookEventFailed       = "email.failed"
+	WebhookEventBounced      = "email.bounced"
+	WebhookEventSuppressed   = "email.suppressed"
+	WebhookEventCodeVerified = "code.verified"
+
+	WebhookAllEvents = "*"
+)
+
+var WebhookEvents = []string{WebhookEventSent, WebhookEventFailed, WebhookEventBounced,
+	WebhookEventSuppressed, WebhookEventCodeVerified}
+
+// WebhookEndpointConfig 接收事件的地址
+type WebhookEndpointConfig struct {
+	Name    string            `yaml:"name"`
+	Url     string            `yaml:"url"`
+	Secret  string            `yaml:"secret"`
+	Events  []string          `yaml:"events"`
+	Headers map[string]string `yaml:"headers"`
+}
+
+// Subscribed 是否订阅了该事件
+func (w *WebhookEndpointConfig) Subscribed(event string) bool {
+	return slices.Contains(w.Events, WebhookAllEvents) || slices.Contains(w.Events, event)
+}
+
+type WebhookConfig struct {
+	Enable        bool                     `yaml:"enable"`
+	Workers       int                      `yaml:"workers"`
+	QueueSize     int                      `yaml:"queue_size"`
+	Timeout       string                   `yaml:"timeout"`
+	MaxRetries    int                      `yaml:"max_retries"`
+	RetryInterval string                   `yaml:"retry_interval"`
+	Endpoints     []*WebhookEndpointConfig `yaml:"endpoints"`
+	// 内部字段
+	TimeoutDuration       time.Duration `yaml:"-"`
+	RetryIntervalDuration time.Duration `yaml:"-"`
+}
+
+func (w *WebhookConfig) InitDefaults() {
+	w.Enable = false
+	w.Workers = 2
+	w.QueueSize = 1000
+	w.Timeout = "10s"
+	w.MaxRetries = 3
+	w.RetryInterval = "5s"
+	w.Endpoints = make([]*WebhookEndpointConfig, 0)
+}
+
+//goland:noinspection GoRedundantElseInIf
+func (w *WebhookConfig) Verify() (bool, error) {
+	if !w.Enable {
+		return true, nil
+	}
+	if w.Workers <= 0 {
+		return false, errors.New("webhook workers must be greater than 0")
+	}
+	if w.QueueSize <= 0 {
+		return false, errors.New("webhook queue size must be greater than 0")
+	}
+	if duration, err := time.ParseDuration(w.Timeout); err != nil {
+		return false, err
+	} else {
+		w.TimeoutDuration = duration
+	}
+	if w.MaxRetries < 0 {
+		return false, errors.New("webhook max retries cannot be less than 0")
+	}
+	if duration, err := time.ParseDuration(w.RetryInterval); err != nil {
+		return false, err
+	} else {
+		w.RetryIntervalDuration = duration
+	}
+	names := make(map[string]struct{}, len(w.Endpoints))
+	for _, endpoint := range w.Endpoints {
+		if endpoint.Name == "" {
+			return false, errors.New("webhook endpoint name cannot be empty")
+		}
+		if _, ok := names[endpoint.Name]; ok {
+			return false, fmt.Errorf("duplicate webhook endpoint %s", endpoint.Name)
+		}
+		names[endpoint.Name] = struct{}{}
+		if u, err := url.Parse(endpoint.Url); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return false, fmt.Errorf("invalid url of webhook endpoint %s", endpoint.Name)
+		}
+		if endpoint.Secret == "" {
+			return false, fmt.Errorf("secret of webhook endpoint %s cannot be empty", endpoint.Name)
+		}
+		if len(endpoint.Events) == 0 {
+			return false, fmt.Errorf("events of webhook endpoint %s cannot be empty", endpoint.Name)
+		}
+		for _, event := range endpoint.Events {
+			if event != WebhookAllEvents && !slices.Contains(WebhookEvents, event) {
+				return false, fmt.Errorf("unknown event %s of webhook endpoint %s", event, endpoint.Name)
+			}
+		}
+	}
+	return true, nil
+}
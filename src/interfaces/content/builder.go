@@ -5,8 +5,13 @@
 package content
 
 import (
+	"email-service/src/interfaces/audit"
+	"email-service/src/interfaces/captcha"
 	c "email-service/src/interfaces/config"
 	"email-service/src/interfaces/email"
+	"email-service/src/interfaces/health"
+	"email-service/src/interfaces/idempotency"
+	"email-service/src/interfaces/metrics"
 
 	"half-nothing.cn/service-core/interfaces/cleaner"
 	"half-nothing.cn/service-core/interfaces/config"
@@ -43,10 +48,65 @@ func (builder *ApplicationContentBuilder) SetEmailSender(emailSender email.Sende
 	return builder
 }
 
+func (builder *ApplicationContentBuilder) SetAsyncSender(asyncSender email.AsyncSenderInterface) *ApplicationContentBuilder {
+	builder.content.asyncSender = asyncSender
+	return builder
+}
+
 func (builder *ApplicationContentBuilder) SetCodeManager(codeManager email.CodeManagerInterface) *ApplicationContentBuilder {
 	builder.content.codeManager = codeManager
 	return builder
 }
+func (builder *ApplicationContentBuilder) SetCodeLimiter(codeLimiter email.CodeLimiterInterface) *ApplicationContentBuilder {
+	builder.content.codeLimiter = codeLimiter
+	return builder
+}
+
+func (builder *ApplicationContentBuilder) SetAddressChecker(addressChecker email.AddressCheckerInterface) *ApplicationContentBuilder {
+	builder.content.addressChecker = addressChecker
+	return builder
+}
+
+func (builder *ApplicationContentBuilder) SetCaptchaVerifier(captchaVerifier captcha.VerifierInterface) *ApplicationContentBuilder {
+	builder.content.captchaVerifier = captchaVerifier
+	return builder
+}
+
+func (builder *ApplicationContentBuilder) SetAuditRecorder(auditRecorder audit.RecorderInterface) *ApplicationContentBuilder {
+	builder.content.auditRecorder = auditRecorder
+	return builder
+}
+
+func (builder *ApplicationContentBuilder) SetSuppressions(suppressions email.SuppressionInterface) *ApplicationContentBuilder {
+	builder.content.suppressions = suppressions
+	return builder
+}
+
+func (builder *ApplicationContentBuilder) SetPreferences(preferences email.PreferenceInterface) *ApplicationContentBuilder {
+	builder.content.preferences = preferences
+	return builder
+}
+
+func (builder *ApplicationContentBuilder) SetMetrics(metrics metrics.RecorderInterface) *ApplicationContentBuilder {
+	builder.content.metrics = metrics
+	return builder
+}
+
+func (builder *ApplicationContentBuilder) SetHealthMonitor(healthMonitor health.MonitorInterface) *ApplicationContentBuilder {
+	builder.content.healthMonitor = healthMonitor
+	return builder
+}
+
+func (builder *ApplicationContentBuilder) SetSandbox(sandbox email.SandboxInterface) *ApplicationContentBuilder {
+	builder.content.sandbox = sandbox
+	return builder
+}
+
+func (builder *ApplicationContentBuilder) SetIdempotencyStore(store idempotency.StoreInterface) *ApplicationContentBuilder {
+	builder.content.idempotency = store
+	return builder
+}
+
 func (builder *ApplicationContentBuilder) Build() *ApplicationContent {
 	return builder.content
 }
@@ -5,8 +5,13 @@
 package content
 
 import (
+	"email-service/src/interfaces/audit"
+	"email-service/src/interfaces/captcha"
 	c "email-service/src/interfaces/config"
 	"email-service/src/interfaces/email"
+	"email-service/src/interfaces/health"
+	"email-service/src/interfaces/idempotency"
+	"email-service/src/interfaces/metrics"
 
 	"half-nothing.cn/service-core/interfaces/cleaner"
 	"half-nothing.cn/service-core/interfaces/config"
@@ -15,11 +20,22 @@ import (
 
 // ApplicationContent 应用程序上下文结构体，包含所有核心组件的接口
 type ApplicationContent struct {
-	configManager config.ManagerInterface[*c.Config] // 配置管理器
-	cleaner       cleaner.Interface                  // 清理器
-	logger        logger.Interface                   // 日志
-	emailSender   email.SenderInterface              // 邮件发送器
-	codeManager   email.CodeManagerInterface         // 邮件验证码管理器
+	configManager   config.ManagerInterface[*c.Config] // 配置管理器
+	cleaner         cleaner.Interface                  // 清理器
+	logger          logger.Interface                   // 日志
+	emailSender     email.SenderInterface              // 邮件发送器
+	asyncSender     email.AsyncSenderInterface         // 异步发送队列
+	codeManager     email.CodeManagerInterface         // 邮件验证码管理器
+	codeLimiter     email.CodeLimiterInterface         // 验证码配额限制器
+	addressChecker  email.AddressCheckerInterface      // 邮箱地址校验器
+	captchaVerifier captcha.VerifierInterface          // 人机验证器, 未启用时为nil
+	auditRecorder   audit.RecorderInterface            // 审计记录器
+	suppressions    email.SuppressionInterface         // 禁止投递列表
+	preferences     email.PreferenceInterface          // 用户通知偏好, 未启用时为nil
+	metrics         metrics.RecorderInterface          // 指标记录器
+	healthMonitor   health.MonitorInterface            // 健康检查监视器
+	sandbox         email.SandboxInterface             // 沙盒收件箱, 未启用时为nil
+	idempotency     idempotency.StoreInterface         // 幂等键存储, 未启用时为nil
 }
 
 func (app *ApplicationContent) ConfigManager() config.ManagerInterface[*c.Config] {
@@ -32,4 +48,32 @@ func (app *ApplicationContent) Logger() logger.Interface { return app.logger }
 
 func (app *ApplicationContent) EmailSender() email.SenderInterface { return app.emailSender }
 
+func (app *ApplicationContent) AsyncSender() email.AsyncSenderInterface { return app.asyncSender }
+
 func (app *ApplicationContent) CodeManager() email.CodeManagerInterface { return app.codeManager }
+
+func (app *ApplicationContent) CodeLimiter() email.CodeLimiterInterface { return app.codeLimiter }
+
+func (app *ApplicationContent) AddressChecker() email.AddressCheckerInterface {
+	return app.addressChecker
+}
+
+func (app *ApplicationContent) CaptchaVerifier() captcha.VerifierInterface {
+	return app.captchaVerifier
+}
+
+func (app *ApplicationContent) AuditRecorder() audit.RecorderInterface { return app.auditRecorder }
+
+func (app *ApplicationContent) Suppressions() email.SuppressionInterface { return app.suppressions }
+
+func (app *ApplicationContent) Preferences() email.PreferenceInterface { return app.preferences }
+
+func (app *ApplicationContent) Metrics() metrics.RecorderInterface { return app.metrics }
+
+func (app *ApplicationContent) HealthMonitor() health.MonitorInterface { return app.healthMonitor }
+
+func (app *ApplicationContent) Sandbox() email.SandboxInterface { return app.sandbox }
+
+func (app *ApplicationContent) IdempotencyStore() idempotency.StoreInterface {
+	return app.idempotency
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"context"
+	"errors"
+	"net"
+)
+
+var (
+	ErrAddressInvalid   = errors.New("email address invalid")
+	ErrDomainDisposable = errors.New("email domain is disposable")
+	ErrDomainNoMx       = errors.New("email domain cannot receive mail")
+)
+
+type DomainResolverInterface interface {
+	LookupMX(ctx context.Context, domain string) ([]*net.MX, error)
+}
+
+type AddressCheckerInterface interface {
+	// Check 校验邮箱地址, 返回规范化后的地址
+	Check(address string) (string, error)
+}
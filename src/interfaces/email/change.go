@@ -0,0 +1,30 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrEmailChangeNotFound      = errors.New("email change request not found")
+	ErrEmailChangeTooManyTries  = errors.New("email change verified too many times")
+	ErrEmailChangeRevertExpired = errors.New("email change revert window expired")
+)
+
+// EmailChangeRequest 一次邮箱变更请求
+// 新邮箱通过验证码确认, 旧邮箱通过取消令牌在确认前取消或在确认后的时间窗口内撤销
+type EmailChangeRequest struct {
+	Cid            string
+	OldEmail       string
+	NewEmail       string
+	Code           string
+	CancelToken    string
+	ExpireAt       time.Time
+	Attempts       int
+	Confirmed      bool
+	RevertDeadline time.Time
+}
@@ -23,4 +23,18 @@ type CodeManagerInterface interface {
 	GenerateEmailCode(target string) (*VerifyCodeEmail, time.Duration, error)
 	VerifyEmailCode(target string, code string) error
 	RemoveEmailCode(target string)
+	// StartEmailChange 为CID创建邮箱变更请求, 同一CID未确认的旧请求会被替换
+	StartEmailChange(cid string, oldEmail string, newEmail string) (*EmailChangeRequest, time.Duration, error)
+	// ConfirmEmailChange 校验发送到新邮箱的验证码, 成功后进入撤销窗口
+	ConfirmEmailChange(cid string, code string) (*EmailChangeRequest, error)
+	// CancelEmailChange 通过旧邮箱收到的令牌取消未确认的请求或撤销已确认的变更
+	CancelEmailChange(token string) (*EmailChangeRequest, error)
+	// IssueResetToken 为CID签发密码重置令牌, 同一CID之前签发的令牌立即失效
+	IssueResetToken(cid string, email string) (string, *ResetToken, time.Duration, error)
+	// ValidateResetToken 校验令牌但不消耗, target需与签发令牌时绑定的邮箱一致
+	ValidateResetToken(cid string, target string, token string) (*ResetToken, error)
+	// ConsumeResetToken 校验并消耗令牌, 令牌只能使用一次, target需与签发令牌时绑定的邮箱一致
+	ConsumeResetToken(cid string, target string, token string) (*ResetToken, error)
+	// RevokeResetToken 作废CID当前的重置令牌并清除发送冷却, 用于重置邮件发送失败时
+	RevokeResetToken(cid string)
 }
@@ -0,0 +1,23 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import "html/template"
+
+// DigestItem 汇总邮件中的一条通知
+type DigestItem struct {
+	EmailType string
+	ThreadId  string
+	AsyncId   string
+	Subject   string
+	Time      string
+	// 原通知模板渲染后的内容
+	Html template.HTML
+}
+
+type DigestEmail struct {
+	Count int
+	Items []*DigestItem
+}
@@ -6,7 +6,9 @@ package email
 
 import (
 	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/render"
 	"errors"
+	"reflect"
 )
 
 var (
@@ -16,37 +18,135 @@ var (
 )
 
 type SenderInterface interface {
-	SendEmail(emailType config.Email, target string, data interface{}) error
+	SendEmail(emailType config.Email, recipients *Recipients, data interface{}) error
+	// Pending 返回正在等待或正在发送的邮件数量
+	Pending() int
+}
+
+// QueuedSenderInterface 异步队列使用的发送器, 投递结果事件中携带异步消息Id
+type QueuedSenderInterface interface {
+	SenderInterface
+	// SendQueuedEmail 与SendEmail相同, id为异步队列分配的消息Id
+	SendQueuedEmail(id string, emailType config.Email, recipients *Recipients, data interface{}) error
 }
 
 type DataValidator func(data interface{}) bool
 
+// RedactedValue 脱敏后敏感字段的占位内容
+const RedactedValue = "[REDACTED]"
+
+// SensitiveEmail 包含验证码、令牌链接等一次性凭据的邮件, 写入日志与审计记录前需要脱敏
+type SensitiveEmail interface {
+	// Redacted 返回隐去敏感字段的副本, 不修改原始数据
+	Redacted() interface{}
+}
+
+// Redact 返回可以写入日志与审计记录的模板参数
+func Redact(data interface{}) interface{} {
+	if sensitive, ok := data.(SensitiveEmail); ok {
+		return sensitive.Redacted()
+	}
+	return data
+}
+
+// ThreadedEmail 属于同一会话的邮件, 发送时会设置In-Reply-To与References邮件头以便邮件客户端归组
+type ThreadedEmail interface {
+	// ThreadId 返回会话标识, 为空时不设置
+	ThreadId() string
+}
+
+// UserEmail 发给特定用户的邮件, 发送时据此读取用户的通知偏好
+type UserEmail interface {
+	UserCid() string
+}
+
+// CalendarEvent 日历邀请内容, Key相同的邀请视为同一事件, Key应由调用方提供的活动ID生成
+type CalendarEvent struct {
+	Key         string
+	Summary     string
+	Location    string
+	Description string
+	// 活动开始时间, 仅在邀请时设置
+	StartTime *render.Time
+	// 为true时发送取消通知
+	Cancel bool
+}
+
+// CalendarEmail 附带日历邀请的邮件, CalendarEvent返回nil时不附带日历邀请
+type CalendarEmail interface {
+	CalendarEvent() *CalendarEvent
+}
+
 type ActivityAtcJoinEmail struct {
 	Cid          string
+	ActivityId   string
 	ActivityName string
-	ActivityTime string
+	ActivityTime *render.Time
 	Facility     string
 	Frequency    string
 }
 
+func (a *ActivityAtcJoinEmail) CalendarEvent() *CalendarEvent {
+	if a.ActivityId == "" {
+		return nil
+	}
+	return &CalendarEvent{
+		Key:         "activity-atc:" + a.ActivityId,
+		Summary:     a.ActivityName,
+		Location:    a.Facility,
+		Description: "管制席位: " + a.Facility + "\n管制频率: " + a.Frequency + "MHz",
+		StartTime:   a.ActivityTime,
+	}
+}
+
 type ActivityAtcLeaveEmail struct {
 	Cid          string
+	ActivityId   string
 	ActivityName string
 }
 
+func (a *ActivityAtcLeaveEmail) CalendarEvent() *CalendarEvent {
+	if a.ActivityId == "" {
+		return nil
+	}
+	return &CalendarEvent{Key: "activity-atc:" + a.ActivityId, Summary: a.ActivityName, Cancel: true}
+}
+
 type ActivityPilotJoinEmail struct {
 	Cid          string
+	ActivityId   string
 	ActivityName string
-	ActivityTime string
+	ActivityTime *render.Time
 	Callsign     string
 	Aircraft     string
 }
 
+func (a *ActivityPilotJoinEmail) CalendarEvent() *CalendarEvent {
+	if a.ActivityId == "" {
+		return nil
+	}
+	return &CalendarEvent{
+		Key:         "activity-pilot:" + a.ActivityId,
+		Summary:     a.ActivityName,
+		Location:    a.Callsign,
+		Description: "呼号: " + a.Callsign + "\n机型: " + a.Aircraft,
+		StartTime:   a.ActivityTime,
+	}
+}
+
 type ActivityPilotLeaveEmail struct {
 	Cid          string
+	ActivityId   string
 	ActivityName string
 }
 
+func (a *ActivityPilotLeaveEmail) CalendarEvent() *CalendarEvent {
+	if a.ActivityId == "" {
+		return nil
+	}
+	return &CalendarEvent{Key: "activity-pilot:" + a.ActivityId, Summary: a.ActivityName, Cancel: true}
+}
+
 type ApplicationPassedEmail struct {
 	Cid      string
 	Operator string
@@ -56,7 +156,7 @@ type ApplicationPassedEmail struct {
 
 type ApplicationProcessingEmail struct {
 	Cid     string
-	Time    string
+	Time    *render.Time
 	Contact string
 }
 
@@ -78,7 +178,7 @@ type AtcRatingChangeEmail struct {
 type BannedEmail struct {
 	Cid      string
 	Reason   string
-	Time     string
+	Time     *render.Time
 	Operator string
 	Contact  string
 }
@@ -100,23 +200,35 @@ type InstructorChangeEmail struct {
 type KickedFromServerEmail struct {
 	Cid      string
 	Reason   string
-	Time     string
+	Time     *render.Time
 	Operator string
 	Contact  string
 }
 
 type PasswordChangeEmail struct {
 	Cid       string
-	Time      string
+	Time      *render.Time
 	IP        string
 	UserAgent string
 }
 
 type PasswordResetEmail struct {
 	Cid       string
-	Time      string
+	Time      *render.Time
 	IP        string
 	UserAgent string
+	// 以下字段仅在签发重置令牌时设置
+	ResetUrl  string
+	Expired   string
+	ExpiredAt *render.Time
+}
+
+func (p *PasswordResetEmail) Redacted() interface{} {
+	result := *p
+	if result.ResetUrl != "" {
+		result.ResetUrl = RedactedValue
+	}
+	return &result
 }
 
 type PermissionChangeEmail struct {
@@ -134,9 +246,17 @@ type RoleChangeEmail struct {
 }
 
 type TicketReplyEmail struct {
-	Cid   string
-	Title string
-	Reply string
+	Cid      string
+	Title    string
+	Reply    string
+	TicketId string
+}
+
+func (t *TicketReplyEmail) ThreadId() string {
+	if t.TicketId == "" {
+		return ""
+	}
+	return "ticket-" + t.TicketId
 }
 
 type WelcomeEmail struct {
@@ -146,9 +266,35 @@ type WelcomeEmail struct {
 type ChangeEmail struct {
 	Cid       string
 	Email     string
-	Time      string
+	Time      *render.Time
 	IP        string
 	UserAgent string
+	// 以下字段仅在邮箱变更流程中设置
+	Pending   bool
+	CancelUrl string
+	Deadline  *render.Time
+}
+
+func (c *ChangeEmail) Redacted() interface{} {
+	result := *c
+	if result.CancelUrl != "" {
+		result.CancelUrl = RedactedValue
+	}
+	return &result
+}
+
+type EmailChangeVerifyEmail struct {
+	Cid       string
+	Email     string
+	Code      string
+	Expired   string
+	ExpiredAt string
+}
+
+func (e *EmailChangeVerifyEmail) Redacted() interface{} {
+	result := *e
+	result.Code = RedactedValue
+	return &result
 }
 
 type VerifyCodeEmail struct {
@@ -157,6 +303,54 @@ type VerifyCodeEmail struct {
 	Expired   string
 }
 
+func (v *VerifyCodeEmail) Redacted() interface{} {
+	result := *v
+	result.Code = RedactedValue
+	return &result
+}
+
+// 以下邮件发给特定用户, 实现UserEmail
+
+func (a *ActivityAtcJoinEmail) UserCid() string { return a.Cid }
+
+func (a *ActivityAtcLeaveEmail) UserCid() string { return a.Cid }
+
+func (a *ActivityPilotJoinEmail) UserCid() string { return a.Cid }
+
+func (a *ActivityPilotLeaveEmail) UserCid() string { return a.Cid }
+
+func (a *ApplicationPassedEmail) UserCid() string { return a.Cid }
+
+func (a *ApplicationProcessingEmail) UserCid() string { return a.Cid }
+
+func (a *ApplicationRejectedEmail) UserCid() string { return a.Cid }
+
+func (a *AtcRatingChangeEmail) UserCid() string { return a.Cid }
+
+func (b *BannedEmail) UserCid() string { return b.Cid }
+
+func (u *UnbannedEmail) UserCid() string { return u.Cid }
+
+func (i *InstructorChangeEmail) UserCid() string { return i.Cid }
+
+func (k *KickedFromServerEmail) UserCid() string { return k.Cid }
+
+func (p *PasswordChangeEmail) UserCid() string { return p.Cid }
+
+func (p *PasswordResetEmail) UserCid() string { return p.Cid }
+
+func (p *PermissionChangeEmail) UserCid() string { return p.Cid }
+
+func (r *RoleChangeEmail) UserCid() string { return r.Cid }
+
+func (t *TicketReplyEmail) UserCid() string { return t.Cid }
+
+func (w *WelcomeEmail) UserCid() string { return w.Cid }
+
+func (c *ChangeEmail) UserCid() string { return c.Cid }
+
+func (e *EmailChangeVerifyEmail) UserCid() string { return e.Cid }
+
 var Validators = map[config.Email]DataValidator{
 	config.EmailVerifyCode:            func(data interface{}) bool { _, ok := data.(*VerifyCodeEmail); return ok },
 	config.EmailWelcome:               func(data interface{}) bool { _, ok := data.(*WelcomeEmail); return ok },
@@ -178,4 +372,70 @@ var Validators = map[config.Email]DataValidator{
 	config.EmailRoleChange:            func(data interface{}) bool { _, ok := data.(*RoleChangeEmail); return ok },
 	config.EmailPermissionChange:      func(data interface{}) bool { _, ok := data.(*PermissionChangeEmail); return ok },
 	config.EmailEmailChange:           func(data interface{}) bool { _, ok := data.(*ChangeEmail); return ok },
+	config.EmailEmailChangeVerify:     func(data interface{}) bool { _, ok := data.(*EmailChangeVerifyEmail); return ok },
+	config.EmailDigest:                func(data interface{}) bool { _, ok := data.(*DigestEmail); return ok },
+}
+
+// dataTypes 各类邮件的模板参数类型
+var dataTypes = map[config.Email]interface{}{
+	config.EmailVerifyCode:            VerifyCodeEmail{},
+	config.EmailWelcome:               WelcomeEmail{},
+	config.EmailRatingChange:          AtcRatingChangeEmail{},
+	config.EmailKickedFromServer:      KickedFromServerEmail{},
+	config.EmailPasswordChange:        PasswordChangeEmail{},
+	config.EmailPasswordReset:         PasswordResetEmail{},
+	config.EmailApplicationPassed:     ApplicationPassedEmail{},
+	config.EmailApplicationRejected:   ApplicationRejectedEmail{},
+	config.EmailApplicationProcessing: ApplicationProcessingEmail{},
+	config.EmailTicketReply:           TicketReplyEmail{},
+	config.EmailActivityPilotJoin:     ActivityPilotJoinEmail{},
+	config.EmailActivityPilotLeave:    ActivityPilotLeaveEmail{},
+	config.EmailActivityAtcJoin:       ActivityAtcJoinEmail{},
+	config.EmailActivityAtcLeave:      ActivityAtcLeaveEmail{},
+	config.EmailInstructorChange:      InstructorChangeEmail{},
+	config.EmailBanned:                BannedEmail{},
+	config.EmailUnbanned:              UnbannedEmail{},
+	config.EmailRoleChange:            RoleChangeEmail{},
+	config.EmailPermissionChange:      PermissionChangeEmail{},
+	config.EmailEmailChange:           ChangeEmail{},
+	config.EmailEmailChangeVerify:     EmailChangeVerifyEmail{},
+	config.EmailDigest:                DigestEmail{},
+}
+
+// 配置校验时需要模板参数类型, 在加载配置前注册到邮件类型上
+func init() {
+	for emailType, data := range dataTypes {
+		emailType.Data.DataType = reflect.TypeOf(data)
+	}
+}
+
+// Unresendable 包含一次性凭据的邮件类型, 审计记录中的参数已脱敏, 不允许重发
+var Unresendable = map[config.Email]bool{
+	config.EmailVerifyCode:        true,
+	config.EmailEmailChangeVerify: true,
+	config.EmailPasswordReset:     true,
+	config.EmailEmailChange:       true,
+}
+
+// DataFactories 创建各类邮件的空白模板参数, 用于从外部事件构造邮件
+// 验证码与密码重置邮件的凭据只能由本服务签发, 不允许从外部事件构造
+var DataFactories = map[config.Email]func() interface{}{
+	config.EmailWelcome:               func() interface{} { return &WelcomeEmail{} },
+	config.EmailRatingChange:          func() interface{} { return &AtcRatingChangeEmail{} },
+	config.EmailKickedFromServer:      func() interface{} { return &KickedFromServerEmail{} },
+	config.EmailPasswordChange:        func() interface{} { return &PasswordChangeEmail{} },
+	config.EmailApplicationPassed:     func() interface{} { return &ApplicationPassedEmail{} },
+	config.EmailApplicationRejected:   func() interface{} { return &ApplicationRejectedEmail{} },
+	config.EmailApplicationProcessing: func() interface{} { return &ApplicationProcessingEmail{} },
+	config.EmailTicketReply:           func() interface{} { return &TicketReplyEmail{} },
+	config.EmailActivityPilotJoin:     func() interface{} { return &ActivityPilotJoinEmail{} },
+	config.EmailActivityPilotLeave:    func() interface{} { return &ActivityPilotLeaveEmail{} },
+	config.EmailActivityAtcJoin:       func() interface{} { return &ActivityAtcJoinEmail{} },
+	config.EmailActivityAtcLeave:      func() interface{} { return &ActivityAtcLeaveEmail{} },
+	config.EmailInstructorChange:      func() interface{} { return &InstructorChangeEmail{} },
+	config.EmailBanned:                func() interface{} { return &BannedEmail{} },
+	config.EmailUnbanned:              func() interface{} { return &UnbannedEmail{} },
+	config.EmailRoleChange:            func() interface{} { return &RoleChangeEmail{} },
+	config.EmailPermissionChange:      func() interface{} { return &PermissionChangeEmail{} },
+	config.EmailEmailChange:           func() interface{} { return &ChangeEmail{} },
 }
@@ -0,0 +1,23 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrCodeIpLimited          = errors.New("email code ip quota exceeded")
+	ErrCodeFingerprintLimited = errors.New("email code fingerprint quota exceeded")
+	ErrCodeGlobalLimited      = errors.New("email code global quota exceeded")
+)
+
+type CodeLimiterInterface interface {
+	// Acquire 占用一次验证码发送配额, 超出配额时返回对应错误以及距离配额重置的时间
+	Acquire(ip string, fingerprint string) (time.Duration, error)
+	// IpUsage 返回当前小时内该IP请求验证码的次数
+	IpUsage(ip string) int
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"email-service/src/interfaces/config"
+	"errors"
+	"time"
+)
+
+var (
+	ErrEmailOptedOut             = errors.New("recipient opted out of this email")
+	ErrPreferenceCategoryUnknown = errors.New("unknown preference category")
+	ErrPreferenceTimezoneInvalid = errors.New("invalid timezone")
+)
+
+// Preference 用户的通知偏好, 只记录退订的分类与邮件中时间显示使用的时区
+type Preference struct {
+	Cid       string    `json:"cid"`
+	OptOut    []string  `json:"opt_out"`
+	Timezone  string    `json:"timezone,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type PreferenceInterface interface {
+	// Get 返回用户的通知偏好, 未设置过时返回空的偏好
+	Get(cid string) *Preference
+	// Set 更新分类的订阅状态, 未出现在categories中的分类保持不变
+	Set(cid string, categories map[string]bool) (*Preference, error)
+	// SetTimezone 设置邮件中时间显示使用的IANA时区, 为空时恢复默认时区
+	SetTimezone(cid string, timezone string) (*Preference, error)
+	// IsOptedOut 用户是否退订了该类型邮件所属的分类
+	IsOptedOut(cid string, emailType config.Email) bool
+	// Location 返回用户设置的时区, 未设置时返回false
+	Location(cid string) (*time.Location, bool)
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import "errors"
+
+var (
+	ErrRecipientInvalid = errors.New("recipient address invalid")
+	ErrNoRecipient      = errors.New("no recipient")
+)
+
+// Recipients 一封邮件的收件人, 密送地址只出现在SMTP信封中
+type Recipients struct {
+	To  []string
+	Cc  []string
+	Bcc []string
+}
+
+func NewRecipients(to ...string) *Recipients {
+	return &Recipients{To: to}
+}
+
+// All 返回全部收件人
+func (r *Recipients) All() []string {
+	all := make([]string, 0, len(r.To)+len(r.Cc)+len(r.Bcc))
+	all = append(all, r.To...)
+	all = append(all, r.Cc...)
+	return append(all, r.Bcc...)
+}
@@ -0,0 +1,25 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrResetTokenNotFound = errors.New("password reset token not found or expired")
+	ErrResetTokenInvalid  = errors.New("password reset token invalid")
+	ErrResetTokenLocked   = errors.New("password reset token locked after too many attempts")
+)
+
+// ResetToken 与CID和邮箱绑定的一次性密码重置令牌, 只保存令牌的哈希
+type ResetToken struct {
+	Cid       string
+	Email     string
+	TokenHash [32]byte
+	ExpireAt  time.Time
+	Attempts  int
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import "time"
+
+// CapturedEmail 沙盒模式下被截获的邮件
+type CapturedEmail struct {
+	Id      string              `json:"id"`
+	Time    time.Time           `json:"time"`
+	From    string              `json:"from"`
+	To      []string            `json:"to"`
+	Subject string              `json:"subject"`
+	Headers map[string][]string `json:"headers"`
+	Html    string              `json:"html"`
+	Text    string              `json:"text"`
+	Raw     string              `json:"-"`
+}
+
+// SandboxInterface 沙盒收件箱, 替代发送通道保存最近发送的邮件
+type SandboxInterface interface {
+	TransportInterface
+	// List 按时间倒序返回收件人为target的邮件, target为空时返回全部, limit为0时不限制数量
+	List(target string, limit int) []*CapturedEmail
+	Get(id string) (*CapturedEmail, bool)
+	Clear()
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"context"
+	"email-service/src/interfaces/config"
+	"errors"
+	"time"
+)
+
+var ErrSendQueueFull = errors.New("send queue is full")
+
+type MessageState string
+
+const (
+	MessageQueued  MessageState = "queued"
+	MessageSending MessageState = "sending"
+	MessageSent    MessageState = "sent"
+	MessageFailed  MessageState = "failed"
+)
+
+// Finished 消息是否已进入终态
+func (s MessageState) Finished() bool {
+	return s == MessageSent || s == MessageFailed
+}
+
+// MessageStatus 异步发送的邮件状态
+type MessageStatus struct {
+	Id        string       `json:"id"`
+	EmailType string       `json:"email_type"`
+	State     MessageState `json:"state"`
+	Error     string       `json:"error,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+type AsyncSenderInterface interface {
+	// Enqueue 将邮件放入发送队列并立即返回消息状态, done在发送结束后调用, 可以为nil
+	Enqueue(emailType config.Email, recipients *Recipients, data interface{}, done func(err error)) (*MessageStatus, error)
+	// EnqueueAll 为每组收件人分别放入一封邮件, 队列剩余空间不足时一封也不放入
+	// 返回的消息状态与recipients一一对应, done在每封邮件发送结束后以对应的收件人调用, 可以为nil
+	EnqueueAll(emailType config.Email, recipients []*Recipients, data interface{}, done func(recipients *Recipients, err error)) ([]*MessageStatus, error)
+	// Status 返回消息的当前状态, 消息不存在或已过期时返回false
+	Status(id string) (*MessageStatus, bool)
+	// Watch 订阅消息状态变化, 首先推送当前状态, 消息进入终态或ctx结束后通道关闭
+	Watch(ctx context.Context, id string) (<-chan *MessageStatus, bool)
+	// Pending 返回队列中等待发送的邮件数量
+	Pending() int
+}
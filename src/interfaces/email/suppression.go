@@ -0,0 +1,26 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrEmailSuppressed = errors.New("email address suppressed")
+
+// Suppression 被禁止投递的邮箱地址
+type Suppression struct {
+	Email     string    `json:"email"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type SuppressionInterface interface {
+	Add(address string, reason string) *Suppression
+	Remove(address string) bool
+	List() []*Suppression
+	IsSuppressed(address string) bool
+}
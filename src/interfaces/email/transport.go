@@ -0,0 +1,20 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package email
+package email
+
+import "io"
+
+// TransportInterface 邮件发送通道, 与gomail.SendCloser兼容
+type TransportInterface interface {
+	// Send 将msg写出的完整邮件投递给to中的所有收件人
+	Send(from string, to []string, msg io.WriterTo) error
+	Close() error
+}
+
+// ConnectionCheckerInterface 维持长连接的发送通道, 用于健康检查
+type ConnectionCheckerInterface interface {
+	// CheckConnection 检查连接状态, 连接已断开时尝试重新连接, 仍然无法连接时返回错误
+	CheckConnection() error
+}
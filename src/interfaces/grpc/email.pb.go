@@ -12,6 +12,7 @@ package grpc
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -24,16 +25,78 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type MessageState int32
+
+const (
+	MessageState_MESSAGE_STATE_UNKNOWN MessageState = 0
+	MessageState_MESSAGE_STATE_QUEUED  MessageState = 1
+	MessageState_MESSAGE_STATE_SENDING MessageState = 2
+	MessageState_MESSAGE_STATE_SENT    MessageState = 3
+	MessageState_MESSAGE_STATE_FAILED  MessageState = 4
+)
+
+// Enum value maps for MessageState.
+var (
+	MessageState_name = map[int32]string{
+		0: "MESSAGE_STATE_UNKNOWN",
+		1: "MESSAGE_STATE_QUEUED",
+		2: "MESSAGE_STATE_SENDING",
+		3: "MESSAGE_STATE_SENT",
+		4: "MESSAGE_STATE_FAILED",
+	}
+	MessageState_value = map[string]int32{
+		"MESSAGE_STATE_UNKNOWN": 0,
+		"MESSAGE_STATE_QUEUED":  1,
+		"MESSAGE_STATE_SENDING": 2,
+		"MESSAGE_STATE_SENT":    3,
+		"MESSAGE_STATE_FAILED":  4,
+	}
+)
+
+func (x MessageState) Enum() *MessageState {
+	p := new(MessageState)
+	*p = x
+	return p
+}
+
+func (x MessageState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MessageState) Descriptor() protoreflect.EnumDescriptor {
+	return file_email_proto_enumTypes[0].Descriptor()
+}
+
+func (MessageState) Type() protoreflect.EnumType {
+	return &file_email_proto_enumTypes[0]
+}
+
+func (x MessageState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MessageState.Descriptor instead.
+func (MessageState) EnumDescriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{0}
+}
+
 type ActivityAtcJoin struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TargetEmail   string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
-	Cid           string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
-	ActivityName  string                 `protobuf:"bytes,3,opt,name=activityName,proto3" json:"activityName,omitempty"`
-	ActivityTime  string                 `protobuf:"bytes,4,opt,name=activityTime,proto3" json:"activityTime,omitempty"`
-	Facility      string                 `protobuf:"bytes,5,opt,name=facility,proto3" json:"facility,omitempty"`
-	Frequency     string                 `protobuf:"bytes,6,opt,name=frequency,proto3" json:"frequency,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TargetEmail    string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
+	Cid            string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	ActivityName   string                 `protobuf:"bytes,3,opt,name=activityName,proto3" json:"activityName,omitempty"`
+	ActivityTime   *string                `protobuf:"bytes,4,opt,name=activityTime,proto3,oneof" json:"activityTime,omitempty"` // 已废弃, 请使用 activityTimeAt
+	Facility       string                 `protobuf:"bytes,5,opt,name=facility,proto3" json:"facility,omitempty"`
+	Frequency      string                 `protobuf:"bytes,6,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	To             []string               `protobuf:"bytes,7,rep,name=to,proto3" json:"to,omitempty"`
+	Cc             []string               `protobuf:"bytes,8,rep,name=cc,proto3" json:"cc,omitempty"`
+	Bcc            []string               `protobuf:"bytes,9,rep,name=bcc,proto3" json:"bcc,omitempty"`
+	IdempotencyKey *string                `protobuf:"bytes,10,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	Async          bool                   `protobuf:"varint,11,opt,name=async,proto3" json:"async,omitempty"`
+	ActivityTimeAt *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=activityTimeAt,proto3" json:"activityTimeAt,omitempty"`
+	ActivityId     *string                `protobuf:"bytes,13,opt,name=activityId,proto3,oneof" json:"activityId,omitempty"` // 活动ID, 用于生成日历邀请, 为空时不附带日历邀请
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ActivityAtcJoin) Reset() {
@@ -88,8 +151,8 @@ func (x *ActivityAtcJoin) GetActivityName() string {
 }
 
 func (x *ActivityAtcJoin) GetActivityTime() string {
-	if x != nil {
-		return x.ActivityTime
+	if x != nil && x.ActivityTime != nil {
+		return *x.ActivityTime
 	}
 	return ""
 }
@@ -108,13 +171,68 @@ func (x *ActivityAtcJoin) GetFrequency() string {
 	return ""
 }
 
+func (x *ActivityAtcJoin) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ActivityAtcJoin) GetCc() []string {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
+}
+
+func (x *ActivityAtcJoin) GetBcc() []string {
+	if x != nil {
+		return x.Bcc
+	}
+	return nil
+}
+
+func (x *ActivityAtcJoin) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *ActivityAtcJoin) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
+func (x *ActivityAtcJoin) GetActivityTimeAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ActivityTimeAt
+	}
+	return nil
+}
+
+func (x *ActivityAtcJoin) GetActivityId() string {
+	if x != nil && x.ActivityId != nil {
+		return *x.ActivityId
+	}
+	return ""
+}
+
 type ActivityAtcLeave struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TargetEmail   string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
-	Cid           string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
-	ActivityName  string                 `protobuf:"bytes,3,opt,name=activityName,proto3" json:"activityName,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TargetEmail    string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
+	Cid            string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	ActivityName   string                 `protobuf:"bytes,3,opt,name=activityName,proto3" json:"activityName,omitempty"`
+	To             []string               `protobuf:"bytes,4,rep,name=to,proto3" json:"to,omitempty"`
+	Cc             []string               `protobuf:"bytes,5,rep,name=cc,proto3" json:"cc,omitempty"`
+	Bcc            []string               `protobuf:"bytes,6,rep,name=bcc,proto3" json:"bcc,omitempty"`
+	IdempotencyKey *string                `protobuf:"bytes,7,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	Async          bool                   `protobuf:"varint,8,opt,name=async,proto3" json:"async,omitempty"`
+	ActivityId     *string                `protobuf:"bytes,9,opt,name=activityId,proto3,oneof" json:"activityId,omitempty"` // 活动ID, 用于生成日历取消通知, 为空时不附带日历取消通知
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ActivityAtcLeave) Reset() {
@@ -168,16 +286,65 @@ func (x *ActivityAtcLeave) GetActivityName() string {
 	return ""
 }
 
+func (x *ActivityAtcLeave) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ActivityAtcLeave) GetCc() []string {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
+}
+
+func (x *ActivityAtcLeave) GetBcc() []string {
+	if x != nil {
+		return x.Bcc
+	}
+	return nil
+}
+
+func (x *ActivityAtcLeave) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *ActivityAtcLeave) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
+func (x *ActivityAtcLeave) GetActivityId() string {
+	if x != nil && x.ActivityId != nil {
+		return *x.ActivityId
+	}
+	return ""
+}
+
 type ActivityPilotJoin struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TargetEmail   string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
-	Cid           string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
-	ActivityName  string                 `protobuf:"bytes,3,opt,name=activityName,proto3" json:"activityName,omitempty"`
-	ActivityTime  string                 `protobuf:"bytes,4,opt,name=activityTime,proto3" json:"activityTime,omitempty"`
-	Callsign      string                 `protobuf:"bytes,5,opt,name=callsign,proto3" json:"callsign,omitempty"`
-	Aircraft      string                 `protobuf:"bytes,6,opt,name=aircraft,proto3" json:"aircraft,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TargetEmail    string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
+	Cid            string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	ActivityName   string                 `protobuf:"bytes,3,opt,name=activityName,proto3" json:"activityName,omitempty"`
+	ActivityTime   *string                `protobuf:"bytes,4,opt,name=activityTime,proto3,oneof" json:"activityTime,omitempty"` // 已废弃, 请使用 activityTimeAt
+	Callsign       string                 `protobuf:"bytes,5,opt,name=callsign,proto3" json:"callsign,omitempty"`
+	Aircraft       string                 `protobuf:"bytes,6,opt,name=aircraft,proto3" json:"aircraft,omitempty"`
+	To             []string               `protobuf:"bytes,7,rep,name=to,proto3" json:"to,omitempty"`
+	Cc             []string               `protobuf:"bytes,8,rep,name=cc,proto3" json:"cc,omitempty"`
+	Bcc            []string               `protobuf:"bytes,9,rep,name=bcc,proto3" json:"bcc,omitempty"`
+	IdempotencyKey *string                `protobuf:"bytes,10,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	Async          bool                   `protobuf:"varint,11,opt,name=async,proto3" json:"async,omitempty"`
+	ActivityTimeAt *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=activityTimeAt,proto3" json:"activityTimeAt,omitempty"`
+	ActivityId     *string                `protobuf:"bytes,13,opt,name=activityId,proto3,oneof" json:"activityId,omitempty"` // 活动ID, 用于生成日历邀请, 为空时不附带日历邀请
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ActivityPilotJoin) Reset() {
@@ -232,8 +399,8 @@ func (x *ActivityPilotJoin) GetActivityName() string {
 }
 
 func (x *ActivityPilotJoin) GetActivityTime() string {
-	if x != nil {
-		return x.ActivityTime
+	if x != nil && x.ActivityTime != nil {
+		return *x.ActivityTime
 	}
 	return ""
 }
@@ -252,13 +419,68 @@ func (x *ActivityPilotJoin) GetAircraft() string {
 	return ""
 }
 
+func (x *ActivityPilotJoin) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ActivityPilotJoin) GetCc() []string {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
+}
+
+func (x *ActivityPilotJoin) GetBcc() []string {
+	if x != nil {
+		return x.Bcc
+	}
+	return nil
+}
+
+func (x *ActivityPilotJoin) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *ActivityPilotJoin) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
+func (x *ActivityPilotJoin) GetActivityTimeAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ActivityTimeAt
+	}
+	return nil
+}
+
+func (x *ActivityPilotJoin) GetActivityId() string {
+	if x != nil && x.ActivityId != nil {
+		return *x.ActivityId
+	}
+	return ""
+}
+
 type ActivityPilotLeave struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TargetEmail   string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
-	Cid           string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
-	ActivityName  string                 `protobuf:"bytes,3,opt,name=activityName,proto3" json:"activityName,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TargetEmail    string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
+	Cid            string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	ActivityName   string                 `protobuf:"bytes,3,opt,name=activityName,proto3" json:"activityName,omitempty"`
+	To             []string               `protobuf:"bytes,4,rep,name=to,proto3" json:"to,omitempty"`
+	Cc             []string               `protobuf:"bytes,5,rep,name=cc,proto3" json:"cc,omitempty"`
+	Bcc            []string               `protobuf:"bytes,6,rep,name=bcc,proto3" json:"bcc,omitempty"`
+	IdempotencyKey *string                `protobuf:"bytes,7,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	Async          bool                   `protobuf:"varint,8,opt,name=async,proto3" json:"async,omitempty"`
+	ActivityId     *string                `protobuf:"bytes,9,opt,name=activityId,proto3,oneof" json:"activityId,omitempty"` // 活动ID, 用于生成日历取消通知, 为空时不附带日历取消通知
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ActivityPilotLeave) Reset() {
@@ -312,15 +534,62 @@ func (x *ActivityPilotLeave) GetActivityName() string {
 	return ""
 }
 
+func (x *ActivityPilotLeave) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ActivityPilotLeave) GetCc() []string {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
+}
+
+func (x *ActivityPilotLeave) GetBcc() []string {
+	if x != nil {
+		return x.Bcc
+	}
+	return nil
+}
+
+func (x *ActivityPilotLeave) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *ActivityPilotLeave) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
+func (x *ActivityPilotLeave) GetActivityId() string {
+	if x != nil && x.ActivityId != nil {
+		return *x.ActivityId
+	}
+	return ""
+}
+
 type ApplicationPassed struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TargetEmail   string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
-	Cid           string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
-	Operator      string                 `protobuf:"bytes,3,opt,name=operator,proto3" json:"operator,omitempty"`
-	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
-	Contact       string                 `protobuf:"bytes,5,opt,name=contact,proto3" json:"contact,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TargetEmail    string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
+	Cid            string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	Operator       string                 `protobuf:"bytes,3,opt,name=operator,proto3" json:"operator,omitempty"`
+	Message        string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Contact        string                 `protobuf:"bytes,5,opt,name=contact,proto3" json:"contact,omitempty"`
+	To             []string               `protobuf:"bytes,6,rep,name=to,proto3" json:"to,omitempty"`
+	Cc             []string               `protobuf:"bytes,7,rep,name=cc,proto3" json:"cc,omitempty"`
+	Bcc            []string               `protobuf:"bytes,8,rep,name=bcc,proto3" json:"bcc,omitempty"`
+	IdempotencyKey *string                `protobuf:"bytes,9,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	Async          bool                   `protobuf:"varint,10,opt,name=async,proto3" json:"async,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ApplicationPassed) Reset() {
@@ -388,14 +657,55 @@ func (x *ApplicationPassed) GetContact() string {
 	return ""
 }
 
+func (x *ApplicationPassed) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ApplicationPassed) GetCc() []string {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
+}
+
+func (x *ApplicationPassed) GetBcc() []string {
+	if x != nil {
+		return x.Bcc
+	}
+	return nil
+}
+
+func (x *ApplicationPassed) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *ApplicationPassed) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
 type ApplicationProcessing struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TargetEmail   string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
-	Cid           string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
-	Time          string                 `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
-	Contact       string                 `protobuf:"bytes,4,opt,name=contact,proto3" json:"contact,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TargetEmail    string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
+	Cid            string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	Time           *string                `protobuf:"bytes,3,opt,name=time,proto3,oneof" json:"time,omitempty"` // 已废弃, 请使用 timeAt
+	Contact        string                 `protobuf:"bytes,4,opt,name=contact,proto3" json:"contact,omitempty"`
+	To             []string               `protobuf:"bytes,5,rep,name=to,proto3" json:"to,omitempty"`
+	Cc             []string               `protobuf:"bytes,6,rep,name=cc,proto3" json:"cc,omitempty"`
+	Bcc            []string               `protobuf:"bytes,7,rep,name=bcc,proto3" json:"bcc,omitempty"`
+	IdempotencyKey *string                `protobuf:"bytes,8,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	Async          bool                   `protobuf:"varint,9,opt,name=async,proto3" json:"async,omitempty"`
+	TimeAt         *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=timeAt,proto3" json:"timeAt,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ApplicationProcessing) Reset() {
@@ -443,8 +753,8 @@ func (x *ApplicationProcessing) GetCid() string {
 }
 
 func (x *ApplicationProcessing) GetTime() string {
-	if x != nil {
-		return x.Time
+	if x != nil && x.Time != nil {
+		return *x.Time
 	}
 	return ""
 }
@@ -456,15 +766,62 @@ func (x *ApplicationProcessing) GetContact() string {
 	return ""
 }
 
+func (x *ApplicationProcessing) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ApplicationProcessing) GetCc() []string {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
+}
+
+func (x *ApplicationProcessing) GetBcc() []string {
+	if x != nil {
+		return x.Bcc
+	}
+	return nil
+}
+
+func (x *ApplicationProcessing) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *ApplicationProcessing) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
+func (x *ApplicationProcessing) GetTimeAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TimeAt
+	}
+	return nil
+}
+
 type ApplicationRejected struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TargetEmail   string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
-	Cid           string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
-	Operator      string                 `protobuf:"bytes,3,opt,name=operator,proto3" json:"operator,omitempty"`
-	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
-	Contact       string                 `protobuf:"bytes,5,opt,name=contact,proto3" json:"contact,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TargetEmail    string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
+	Cid            string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	Operator       string                 `protobuf:"bytes,3,opt,name=operator,proto3" json:"operator,omitempty"`
+	Reason         string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Contact        string                 `protobuf:"bytes,5,opt,name=contact,proto3" json:"contact,omitempty"`
+	To             []string               `protobuf:"bytes,6,rep,name=to,proto3" json:"to,omitempty"`
+	Cc             []string               `protobuf:"bytes,7,rep,name=cc,proto3" json:"cc,omitempty"`
+	Bcc            []string               `protobuf:"bytes,8,rep,name=bcc,proto3" json:"bcc,omitempty"`
+	IdempotencyKey *string                `protobuf:"bytes,9,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	Async          bool                   `protobuf:"varint,10,opt,name=async,proto3" json:"async,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ApplicationRejected) Reset() {
@@ -532,16 +889,56 @@ func (x *ApplicationRejected) GetContact() string {
 	return ""
 }
 
+func (x *ApplicationRejected) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ApplicationRejected) GetCc() []string {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
+}
+
+func (x *ApplicationRejected) GetBcc() []string {
+	if x != nil {
+		return x.Bcc
+	}
+	return nil
+}
+
+func (x *ApplicationRejected) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *ApplicationRejected) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
 type AtcRatingChange struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TargetEmail   string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
-	Cid           string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
-	NewValue      string                 `protobuf:"bytes,3,opt,name=newValue,proto3" json:"newValue,omitempty"`
-	OldValue      string                 `protobuf:"bytes,4,opt,name=oldValue,proto3" json:"oldValue,omitempty"`
-	Operator      string                 `protobuf:"bytes,5,opt,name=operator,proto3" json:"operator,omitempty"`
-	Contact       string                 `protobuf:"bytes,6,opt,name=contact,proto3" json:"contact,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TargetEmail    string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
+	Cid            string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	NewValue       string                 `protobuf:"bytes,3,opt,name=newValue,proto3" json:"newValue,omitempty"`
+	OldValue       string                 `protobuf:"bytes,4,opt,name=oldValue,proto3" json:"oldValue,omitempty"`
+	Operator       string                 `protobuf:"bytes,5,opt,name=operator,proto3" json:"operator,omitempty"`
+	Contact        string                 `protobuf:"bytes,6,opt,name=contact,proto3" json:"contact,omitempty"`
+	To             []string               `protobuf:"bytes,7,rep,name=to,proto3" json:"to,omitempty"`
+	Cc             []string               `protobuf:"bytes,8,rep,name=cc,proto3" json:"cc,omitempty"`
+	Bcc            []string               `protobuf:"bytes,9,rep,name=bcc,proto3" json:"bcc,omitempty"`
+	IdempotencyKey *string                `protobuf:"bytes,10,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	Async          bool                   `protobuf:"varint,11,opt,name=async,proto3" json:"async,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *AtcRatingChange) Reset() {
@@ -616,16 +1013,57 @@ func (x *AtcRatingChange) GetContact() string {
 	return ""
 }
 
-type Banned struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TargetEmail   string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
-	Cid           string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
-	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
-	Time          string                 `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
-	Operator      string                 `protobuf:"bytes,5,opt,name=operator,proto3" json:"operator,omitempty"`
-	Contact       string                 `protobuf:"bytes,6,opt,name=contact,proto3" json:"contact,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *AtcRatingChange) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *AtcRatingChange) GetCc() []string {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
+}
+
+func (x *AtcRatingChange) GetBcc() []string {
+	if x != nil {
+		return x.Bcc
+	}
+	return nil
+}
+
+func (x *AtcRatingChange) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *AtcRatingChange) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
+type Banned struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TargetEmail    string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
+	Cid            string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	Reason         string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Time           *string                `protobuf:"bytes,4,opt,name=time,proto3,oneof" json:"time,omitempty"` // 已废弃, 请使用 timeAt
+	Operator       string                 `protobuf:"bytes,5,opt,name=operator,proto3" json:"operator,omitempty"`
+	Contact        string                 `protobuf:"bytes,6,opt,name=contact,proto3" json:"contact,omitempty"`
+	To             []string               `protobuf:"bytes,7,rep,name=to,proto3" json:"to,omitempty"`
+	Cc             []string               `protobuf:"bytes,8,rep,name=cc,proto3" json:"cc,omitempty"`
+	Bcc            []string               `protobuf:"bytes,9,rep,name=bcc,proto3" json:"bcc,omitempty"`
+	IdempotencyKey *string                `protobuf:"bytes,10,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	Async          bool                   `protobuf:"varint,11,opt,name=async,proto3" json:"async,omitempty"`
+	TimeAt         *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=timeAt,proto3" json:"timeAt,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *Banned) Reset() {
@@ -680,8 +1118,8 @@ func (x *Banned) GetReason() string {
 }
 
 func (x *Banned) GetTime() string {
-	if x != nil {
-		return x.Time
+	if x != nil && x.Time != nil {
+		return *x.Time
 	}
 	return ""
 }
@@ -700,14 +1138,61 @@ func (x *Banned) GetContact() string {
 	return ""
 }
 
+func (x *Banned) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *Banned) GetCc() []string {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
+}
+
+func (x *Banned) GetBcc() []string {
+	if x != nil {
+		return x.Bcc
+	}
+	return nil
+}
+
+func (x *Banned) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *Banned) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
+func (x *Banned) GetTimeAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TimeAt
+	}
+	return nil
+}
+
 type Unbanned struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TargetEmail   string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
-	Cid           string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
-	Operator      string                 `protobuf:"bytes,3,opt,name=operator,proto3" json:"operator,omitempty"`
-	Contact       string                 `protobuf:"bytes,4,opt,name=contact,proto3" json:"contact,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TargetEmail    string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
+	Cid            string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	Operator       string                 `protobuf:"bytes,3,opt,name=operator,proto3" json:"operator,omitempty"`
+	Contact        string                 `protobuf:"bytes,4,opt,name=contact,proto3" json:"contact,omitempty"`
+	To             []string               `protobuf:"bytes,5,rep,name=to,proto3" json:"to,omitempty"`
+	Cc             []string               `protobuf:"bytes,6,rep,name=cc,proto3" json:"cc,omitempty"`
+	Bcc            []string               `protobuf:"bytes,7,rep,name=bcc,proto3" json:"bcc,omitempty"`
+	IdempotencyKey *string                `protobuf:"bytes,8,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	Async          bool                   `protobuf:"varint,9,opt,name=async,proto3" json:"async,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *Unbanned) Reset() {
@@ -768,16 +1253,56 @@ func (x *Unbanned) GetContact() string {
 	return ""
 }
 
+func (x *Unbanned) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *Unbanned) GetCc() []string {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
+}
+
+func (x *Unbanned) GetBcc() []string {
+	if x != nil {
+		return x.Bcc
+	}
+	return nil
+}
+
+func (x *Unbanned) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *Unbanned) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
 type InstructorChange struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TargetEmail   string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
-	Cid           string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
-	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
-	Instructor    string                 `protobuf:"bytes,4,opt,name=instructor,proto3" json:"instructor,omitempty"`
-	Operator      string                 `protobuf:"bytes,5,opt,name=operator,proto3" json:"operator,omitempty"`
-	Contact       string                 `protobuf:"bytes,6,opt,name=contact,proto3" json:"contact,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TargetEmail    string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
+	Cid            string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	Reason         string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Instructor     string                 `protobuf:"bytes,4,opt,name=instructor,proto3" json:"instructor,omitempty"`
+	Operator       string                 `protobuf:"bytes,5,opt,name=operator,proto3" json:"operator,omitempty"`
+	Contact        string                 `protobuf:"bytes,6,opt,name=contact,proto3" json:"contact,omitempty"`
+	To             []string               `protobuf:"bytes,7,rep,name=to,proto3" json:"to,omitempty"`
+	Cc             []string               `protobuf:"bytes,8,rep,name=cc,proto3" json:"cc,omitempty"`
+	Bcc            []string               `protobuf:"bytes,9,rep,name=bcc,proto3" json:"bcc,omitempty"`
+	IdempotencyKey *string                `protobuf:"bytes,10,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	Async          bool                   `protobuf:"varint,11,opt,name=async,proto3" json:"async,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *InstructorChange) Reset() {
@@ -852,16 +1377,57 @@ func (x *InstructorChange) GetContact() string {
 	return ""
 }
 
+func (x *InstructorChange) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *InstructorChange) GetCc() []string {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
+}
+
+func (x *InstructorChange) GetBcc() []string {
+	if x != nil {
+		return x.Bcc
+	}
+	return nil
+}
+
+func (x *InstructorChange) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *InstructorChange) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
 type KickedFromServer struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TargetEmail   string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
-	Cid           string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
-	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
-	Time          string                 `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
-	Operator      string                 `protobuf:"bytes,5,opt,name=operator,proto3" json:"operator,omitempty"`
-	Contact       string                 `protobuf:"bytes,6,opt,name=contact,proto3" json:"contact,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TargetEmail    string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
+	Cid            string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	Reason         string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Time           *string                `protobuf:"bytes,4,opt,name=time,proto3,oneof" json:"time,omitempty"` // 已废弃, 请使用 timeAt
+	Operator       string                 `protobuf:"bytes,5,opt,name=operator,proto3" json:"operator,omitempty"`
+	Contact        string                 `protobuf:"bytes,6,opt,name=contact,proto3" json:"contact,omitempty"`
+	To             []string               `protobuf:"bytes,7,rep,name=to,proto3" json:"to,omitempty"`
+	Cc             []string               `protobuf:"bytes,8,rep,name=cc,proto3" json:"cc,omitempty"`
+	Bcc            []string               `protobuf:"bytes,9,rep,name=bcc,proto3" json:"bcc,omitempty"`
+	IdempotencyKey *string                `protobuf:"bytes,10,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	Async          bool                   `protobuf:"varint,11,opt,name=async,proto3" json:"async,omitempty"`
+	TimeAt         *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=timeAt,proto3" json:"timeAt,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *KickedFromServer) Reset() {
@@ -916,8 +1482,8 @@ func (x *KickedFromServer) GetReason() string {
 }
 
 func (x *KickedFromServer) GetTime() string {
-	if x != nil {
-		return x.Time
+	if x != nil && x.Time != nil {
+		return *x.Time
 	}
 	return ""
 }
@@ -936,15 +1502,63 @@ func (x *KickedFromServer) GetContact() string {
 	return ""
 }
 
+func (x *KickedFromServer) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *KickedFromServer) GetCc() []string {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
+}
+
+func (x *KickedFromServer) GetBcc() []string {
+	if x != nil {
+		return x.Bcc
+	}
+	return nil
+}
+
+func (x *KickedFromServer) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *KickedFromServer) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
+func (x *KickedFromServer) GetTimeAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TimeAt
+	}
+	return nil
+}
+
 type PasswordChange struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TargetEmail   string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
-	Cid           string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
-	Time          string                 `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
-	Ip            string                 `protobuf:"bytes,4,opt,name=ip,proto3" json:"ip,omitempty"`
-	UserAgent     string                 `protobuf:"bytes,5,opt,name=userAgent,proto3" json:"userAgent,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TargetEmail    string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
+	Cid            string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	Time           *string                `protobuf:"bytes,3,opt,name=time,proto3,oneof" json:"time,omitempty"` // 已废弃, 请使用 timeAt
+	Ip             string                 `protobuf:"bytes,4,opt,name=ip,proto3" json:"ip,omitempty"`
+	UserAgent      string                 `protobuf:"bytes,5,opt,name=userAgent,proto3" json:"userAgent,omitempty"`
+	To             []string               `protobuf:"bytes,6,rep,name=to,proto3" json:"to,omitempty"`
+	Cc             []string               `protobuf:"bytes,7,rep,name=cc,proto3" json:"cc,omitempty"`
+	Bcc            []string               `protobuf:"bytes,8,rep,name=bcc,proto3" json:"bcc,omitempty"`
+	IdempotencyKey *string                `protobuf:"bytes,9,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	Async          bool                   `protobuf:"varint,10,opt,name=async,proto3" json:"async,omitempty"`
+	TimeAt         *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=timeAt,proto3" json:"timeAt,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *PasswordChange) Reset() {
@@ -992,8 +1606,8 @@ func (x *PasswordChange) GetCid() string {
 }
 
 func (x *PasswordChange) GetTime() string {
-	if x != nil {
-		return x.Time
+	if x != nil && x.Time != nil {
+		return *x.Time
 	}
 	return ""
 }
@@ -1012,15 +1626,63 @@ func (x *PasswordChange) GetUserAgent() string {
 	return ""
 }
 
+func (x *PasswordChange) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *PasswordChange) GetCc() []string {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
+}
+
+func (x *PasswordChange) GetBcc() []string {
+	if x != nil {
+		return x.Bcc
+	}
+	return nil
+}
+
+func (x *PasswordChange) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *PasswordChange) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
+func (x *PasswordChange) GetTimeAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TimeAt
+	}
+	return nil
+}
+
 type PasswordReset struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TargetEmail   string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
-	Cid           string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
-	Time          string                 `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
-	Ip            string                 `protobuf:"bytes,4,opt,name=ip,proto3" json:"ip,omitempty"`
-	UserAgent     string                 `protobuf:"bytes,5,opt,name=userAgent,proto3" json:"userAgent,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TargetEmail    string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
+	Cid            string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	Time           *string                `protobuf:"bytes,3,opt,name=time,proto3,oneof" json:"time,omitempty"` // 已废弃, 请使用 timeAt
+	Ip             string                 `protobuf:"bytes,4,opt,name=ip,proto3" json:"ip,omitempty"`
+	UserAgent      string                 `protobuf:"bytes,5,opt,name=userAgent,proto3" json:"userAgent,omitempty"`
+	To             []string               `protobuf:"bytes,6,rep,name=to,proto3" json:"to,omitempty"`
+	Cc             []string               `protobuf:"bytes,7,rep,name=cc,proto3" json:"cc,omitempty"`
+	Bcc            []string               `protobuf:"bytes,8,rep,name=bcc,proto3" json:"bcc,omitempty"`
+	IdempotencyKey *string                `protobuf:"bytes,9,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	Async          bool                   `protobuf:"varint,10,opt,name=async,proto3" json:"async,omitempty"`
+	TimeAt         *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=timeAt,proto3" json:"timeAt,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *PasswordReset) Reset() {
@@ -1068,8 +1730,8 @@ func (x *PasswordReset) GetCid() string {
 }
 
 func (x *PasswordReset) GetTime() string {
-	if x != nil {
-		return x.Time
+	if x != nil && x.Time != nil {
+		return *x.Time
 	}
 	return ""
 }
@@ -1088,15 +1750,62 @@ func (x *PasswordReset) GetUserAgent() string {
 	return ""
 }
 
+func (x *PasswordReset) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *PasswordReset) GetCc() []string {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
+}
+
+func (x *PasswordReset) GetBcc() []string {
+	if x != nil {
+		return x.Bcc
+	}
+	return nil
+}
+
+func (x *PasswordReset) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *PasswordReset) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
+func (x *PasswordReset) GetTimeAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TimeAt
+	}
+	return nil
+}
+
 type PermissionChange struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TargetEmail   string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
-	Cid           string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
-	Permissions   string                 `protobuf:"bytes,3,opt,name=permissions,proto3" json:"permissions,omitempty"`
-	Operator      string                 `protobuf:"bytes,4,opt,name=operator,proto3" json:"operator,omitempty"`
-	Contact       string                 `protobuf:"bytes,5,opt,name=contact,proto3" json:"contact,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TargetEmail    string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
+	Cid            string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	Permissions    string                 `protobuf:"bytes,3,opt,name=permissions,proto3" json:"permissions,omitempty"`
+	Operator       string                 `protobuf:"bytes,4,opt,name=operator,proto3" json:"operator,omitempty"`
+	Contact        string                 `protobuf:"bytes,5,opt,name=contact,proto3" json:"contact,omitempty"`
+	To             []string               `protobuf:"bytes,6,rep,name=to,proto3" json:"to,omitempty"`
+	Cc             []string               `protobuf:"bytes,7,rep,name=cc,proto3" json:"cc,omitempty"`
+	Bcc            []string               `protobuf:"bytes,8,rep,name=bcc,proto3" json:"bcc,omitempty"`
+	IdempotencyKey *string                `protobuf:"bytes,9,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	Async          bool                   `protobuf:"varint,10,opt,name=async,proto3" json:"async,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *PermissionChange) Reset() {
@@ -1164,15 +1873,54 @@ func (x *PermissionChange) GetContact() string {
 	return ""
 }
 
+func (x *PermissionChange) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *PermissionChange) GetCc() []string {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
+}
+
+func (x *PermissionChange) GetBcc() []string {
+	if x != nil {
+		return x.Bcc
+	}
+	return nil
+}
+
+func (x *PermissionChange) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *PermissionChange) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
 type RoleChange struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TargetEmail   []string               `protobuf:"bytes,1,rep,name=targetEmail,proto3" json:"targetEmail,omitempty"`
-	Cid           string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
-	Roles         string                 `protobuf:"bytes,3,opt,name=roles,proto3" json:"roles,omitempty"`
-	Operator      string                 `protobuf:"bytes,4,opt,name=operator,proto3" json:"operator,omitempty"`
-	Contact       string                 `protobuf:"bytes,5,opt,name=contact,proto3" json:"contact,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TargetEmail    []string               `protobuf:"bytes,1,rep,name=targetEmail,proto3" json:"targetEmail,omitempty"`
+	Cid            string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	Roles          string                 `protobuf:"bytes,3,opt,name=roles,proto3" json:"roles,omitempty"`
+	Operator       string                 `protobuf:"bytes,4,opt,name=operator,proto3" json:"operator,omitempty"`
+	Contact        string                 `protobuf:"bytes,5,opt,name=contact,proto3" json:"contact,omitempty"`
+	Cc             []string               `protobuf:"bytes,6,rep,name=cc,proto3" json:"cc,omitempty"` // 每个targetEmail单独发送一封邮件, cc与bcc只随第一封邮件发送
+	Bcc            []string               `protobuf:"bytes,7,rep,name=bcc,proto3" json:"bcc,omitempty"`
+	IdempotencyKey *string                `protobuf:"bytes,8,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	Async          bool                   `protobuf:"varint,9,opt,name=async,proto3" json:"async,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *RoleChange) Reset() {
@@ -1240,22 +1988,56 @@ func (x *RoleChange) GetContact() string {
 	return ""
 }
 
-type TicketReply struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TargetEmail   string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
-	Cid           string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
-	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
-	Reply         string                 `protobuf:"bytes,4,opt,name=reply,proto3" json:"reply,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *RoleChange) GetCc() []string {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
 }
 
-func (x *TicketReply) Reset() {
-	*x = TicketReply{}
-	mi := &file_email_proto_msgTypes[16]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
+func (x *RoleChange) GetBcc() []string {
+	if x != nil {
+		return x.Bcc
+	}
+	return nil
+}
+
+func (x *RoleChange) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *RoleChange) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
+type TicketReply struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TargetEmail    string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
+	Cid            string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	Title          string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Reply          string                 `protobuf:"bytes,4,opt,name=reply,proto3" json:"reply,omitempty"`
+	TicketId       *string                `protobuf:"bytes,5,opt,name=ticketId,proto3,oneof" json:"ticketId,omitempty"` // 同一工单的回复邮件会被邮件客户端归为同一会话
+	To             []string               `protobuf:"bytes,6,rep,name=to,proto3" json:"to,omitempty"`
+	Cc             []string               `protobuf:"bytes,7,rep,name=cc,proto3" json:"cc,omitempty"`
+	Bcc            []string               `protobuf:"bytes,8,rep,name=bcc,proto3" json:"bcc,omitempty"`
+	IdempotencyKey *string                `protobuf:"bytes,9,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	Async          bool                   `protobuf:"varint,10,opt,name=async,proto3" json:"async,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TicketReply) Reset() {
+	*x = TicketReply{}
+	mi := &file_email_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
 func (x *TicketReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
@@ -1308,12 +2090,59 @@ func (x *TicketReply) GetReply() string {
 	return ""
 }
 
+func (x *TicketReply) GetTicketId() string {
+	if x != nil && x.TicketId != nil {
+		return *x.TicketId
+	}
+	return ""
+}
+
+func (x *TicketReply) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *TicketReply) GetCc() []string {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
+}
+
+func (x *TicketReply) GetBcc() []string {
+	if x != nil {
+		return x.Bcc
+	}
+	return nil
+}
+
+func (x *TicketReply) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *TicketReply) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
 type Welcome struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TargetEmail   string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
-	Cid           string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TargetEmail    string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
+	Cid            string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	To             []string               `protobuf:"bytes,3,rep,name=to,proto3" json:"to,omitempty"`
+	Cc             []string               `protobuf:"bytes,4,rep,name=cc,proto3" json:"cc,omitempty"`
+	Bcc            []string               `protobuf:"bytes,5,rep,name=bcc,proto3" json:"bcc,omitempty"`
+	IdempotencyKey *string                `protobuf:"bytes,6,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	Async          bool                   `protobuf:"varint,7,opt,name=async,proto3" json:"async,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *Welcome) Reset() {
@@ -1360,16 +2189,57 @@ func (x *Welcome) GetCid() string {
 	return ""
 }
 
+func (x *Welcome) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *Welcome) GetCc() []string {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
+}
+
+func (x *Welcome) GetBcc() []string {
+	if x != nil {
+		return x.Bcc
+	}
+	return nil
+}
+
+func (x *Welcome) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *Welcome) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
 type EmailChange struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TargetEmail   string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
-	Cid           string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
-	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
-	Time          string                 `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
-	Ip            string                 `protobuf:"bytes,5,opt,name=ip,proto3" json:"ip,omitempty"`
-	UserAgent     string                 `protobuf:"bytes,6,opt,name=userAgent,proto3" json:"userAgent,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TargetEmail    string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"`
+	Cid            string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	Email          string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Time           *string                `protobuf:"bytes,4,opt,name=time,proto3,oneof" json:"time,omitempty"` // 已废弃, 请使用 timeAt
+	Ip             string                 `protobuf:"bytes,5,opt,name=ip,proto3" json:"ip,omitempty"`
+	UserAgent      string                 `protobuf:"bytes,6,opt,name=userAgent,proto3" json:"userAgent,omitempty"`
+	To             []string               `protobuf:"bytes,7,rep,name=to,proto3" json:"to,omitempty"`
+	Cc             []string               `protobuf:"bytes,8,rep,name=cc,proto3" json:"cc,omitempty"`
+	Bcc            []string               `protobuf:"bytes,9,rep,name=bcc,proto3" json:"bcc,omitempty"`
+	IdempotencyKey *string                `protobuf:"bytes,10,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	Async          bool                   `protobuf:"varint,11,opt,name=async,proto3" json:"async,omitempty"`
+	TimeAt         *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=timeAt,proto3" json:"timeAt,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *EmailChange) Reset() {
@@ -1424,8 +2294,8 @@ func (x *EmailChange) GetEmail() string {
 }
 
 func (x *EmailChange) GetTime() string {
-	if x != nil {
-		return x.Time
+	if x != nil && x.Time != nil {
+		return *x.Time
 	}
 	return ""
 }
@@ -1444,9 +2314,53 @@ func (x *EmailChange) GetUserAgent() string {
 	return ""
 }
 
+func (x *EmailChange) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *EmailChange) GetCc() []string {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
+}
+
+func (x *EmailChange) GetBcc() []string {
+	if x != nil {
+		return x.Bcc
+	}
+	return nil
+}
+
+func (x *EmailChange) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *EmailChange) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
+func (x *EmailChange) GetTimeAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TimeAt
+	}
+	return nil
+}
+
 type SendResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=messageId,proto3" json:"messageId,omitempty"`   // 异步发送时返回的消息ID
+	MessageIds    []string               `protobuf:"bytes,3,rep,name=messageIds,proto3" json:"messageIds,omitempty"` // 异步发送多封邮件时(RoleChange)每封邮件的消息ID, 与targetEmail一一对应
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1488,6 +2402,148 @@ func (x *SendResponse) GetSuccess() bool {
 	return false
 }
 
+func (x *SendResponse) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *SendResponse) GetMessageIds() []string {
+	if x != nil {
+		return x.MessageIds
+	}
+	return nil
+}
+
+type MessageStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=messageId,proto3" json:"messageId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageStatusRequest) Reset() {
+	*x = MessageStatusRequest{}
+	mi := &file_email_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageStatusRequest) ProtoMessage() {}
+
+func (x *MessageStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageStatusRequest.ProtoReflect.Descriptor instead.
+func (*MessageStatusRequest) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *MessageStatusRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+type MessageStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=messageId,proto3" json:"messageId,omitempty"`
+	EmailType     string                 `protobuf:"bytes,2,opt,name=emailType,proto3" json:"emailType,omitempty"`
+	State         MessageState           `protobuf:"varint,3,opt,name=state,proto3,enum=fsd_universe.MessageState" json:"state,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`          // 发送失败的原因
+	CreatedAt     int64                  `protobuf:"varint,5,opt,name=createdAt,proto3" json:"createdAt,omitempty"` // unix毫秒时间戳
+	UpdatedAt     int64                  `protobuf:"varint,6,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"` // unix毫秒时间戳
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageStatus) Reset() {
+	*x = MessageStatus{}
+	mi := &file_email_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageStatus) ProtoMessage() {}
+
+func (x *MessageStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageStatus.ProtoReflect.Descriptor instead.
+func (*MessageStatus) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *MessageStatus) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *MessageStatus) GetEmailType() string {
+	if x != nil {
+		return x.EmailType
+	}
+	return ""
+}
+
+func (x *MessageStatus) GetState() MessageState {
+	if x != nil {
+		return x.State
+	}
+	return MessageState_MESSAGE_STATE_UNKNOWN
+}
+
+func (x *MessageStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *MessageStatus) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *MessageStatus) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
 type VerifyCode struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
@@ -1498,7 +2554,7 @@ type VerifyCode struct {
 
 func (x *VerifyCode) Reset() {
 	*x = VerifyCode{}
-	mi := &file_email_proto_msgTypes[20]
+	mi := &file_email_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1510,7 +2566,7 @@ func (x *VerifyCode) String() string {
 func (*VerifyCode) ProtoMessage() {}
 
 func (x *VerifyCode) ProtoReflect() protoreflect.Message {
-	mi := &file_email_proto_msgTypes[20]
+	mi := &file_email_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1523,7 +2579,7 @@ func (x *VerifyCode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerifyCode.ProtoReflect.Descriptor instead.
 func (*VerifyCode) Descriptor() ([]byte, []int) {
-	return file_email_proto_rawDescGZIP(), []int{20}
+	return file_email_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *VerifyCode) GetCode() string {
@@ -1550,7 +2606,7 @@ type VerifyResponse struct {
 
 func (x *VerifyResponse) Reset() {
 	*x = VerifyResponse{}
-	mi := &file_email_proto_msgTypes[21]
+	mi := &file_email_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1562,7 +2618,7 @@ func (x *VerifyResponse) String() string {
 func (*VerifyResponse) ProtoMessage() {}
 
 func (x *VerifyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_email_proto_msgTypes[21]
+	mi := &file_email_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1575,7 +2631,7 @@ func (x *VerifyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerifyResponse.ProtoReflect.Descriptor instead.
 func (*VerifyResponse) Descriptor() ([]byte, []int) {
-	return file_email_proto_rawDescGZIP(), []int{21}
+	return file_email_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *VerifyResponse) GetSuccess() bool {
@@ -1601,7 +2657,7 @@ type RemoveVerifyCode struct {
 
 func (x *RemoveVerifyCode) Reset() {
 	*x = RemoveVerifyCode{}
-	mi := &file_email_proto_msgTypes[22]
+	mi := &file_email_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1613,7 +2669,7 @@ func (x *RemoveVerifyCode) String() string {
 func (*RemoveVerifyCode) ProtoMessage() {}
 
 func (x *RemoveVerifyCode) ProtoReflect() protoreflect.Message {
-	mi := &file_email_proto_msgTypes[22]
+	mi := &file_email_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1626,7 +2682,7 @@ func (x *RemoveVerifyCode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveVerifyCode.ProtoReflect.Descriptor instead.
 func (*RemoveVerifyCode) Descriptor() ([]byte, []int) {
-	return file_email_proto_rawDescGZIP(), []int{22}
+	return file_email_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *RemoveVerifyCode) GetEmail() string {
@@ -1645,7 +2701,7 @@ type RemoveVerifyCodeResponse struct {
 
 func (x *RemoveVerifyCodeResponse) Reset() {
 	*x = RemoveVerifyCodeResponse{}
-	mi := &file_email_proto_msgTypes[23]
+	mi := &file_email_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1657,7 +2713,7 @@ func (x *RemoveVerifyCodeResponse) String() string {
 func (*RemoveVerifyCodeResponse) ProtoMessage() {}
 
 func (x *RemoveVerifyCodeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_email_proto_msgTypes[23]
+	mi := &file_email_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1670,7 +2726,7 @@ func (x *RemoveVerifyCodeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveVerifyCodeResponse.ProtoReflect.Descriptor instead.
 func (*RemoveVerifyCodeResponse) Descriptor() ([]byte, []int) {
-	return file_email_proto_rawDescGZIP(), []int{23}
+	return file_email_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *RemoveVerifyCodeResponse) GetSuccess() bool {
@@ -1680,69 +2736,964 @@ func (x *RemoveVerifyCodeResponse) GetSuccess() bool {
 	return false
 }
 
-var File_email_proto protoreflect.FileDescriptor
+type StartEmailChangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cid           string                 `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	OldEmail      string                 `protobuf:"bytes,2,opt,name=oldEmail,proto3" json:"oldEmail,omitempty"`
+	NewEmail      string                 `protobuf:"bytes,3,opt,name=newEmail,proto3" json:"newEmail,omitempty"`
+	Ip            string                 `protobuf:"bytes,4,opt,name=ip,proto3" json:"ip,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,5,opt,name=userAgent,proto3" json:"userAgent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_email_proto_rawDesc = "" +
-	"\n" +
-	"\vemail.proto\x12\ffsd_universe\"\xc7\x01\n" +
-	"\x0fActivityAtcJoin\x12 \n" +
-	"\vtargetEmail\x18\x01 \x01(\tR\vtargetEmail\x12\x10\n" +
-	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\"\n" +
-	"\factivityName\x18\x03 \x01(\tR\factivityName\x12\"\n" +
-	"\factivityTime\x18\x04 \x01(\tR\factivityTime\x12\x1a\n" +
-	"\bfacility\x18\x05 \x01(\tR\bfacility\x12\x1c\n" +
-	"\tfrequency\x18\x06 \x01(\tR\tfrequency\"j\n" +
-	"\x10ActivityAtcLeave\x12 \n" +
-	"\vtargetEmail\x18\x01 \x01(\tR\vtargetEmail\x12\x10\n" +
-	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\"\n" +
-	"\factivityName\x18\x03 \x01(\tR\factivityName\"\xc7\x01\n" +
-	"\x11ActivityPilotJoin\x12 \n" +
-	"\vtargetEmail\x18\x01 \x01(\tR\vtargetEmail\x12\x10\n" +
-	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\"\n" +
-	"\factivityName\x18\x03 \x01(\tR\factivityName\x12\"\n" +
-	"\factivityTime\x18\x04 \x01(\tR\factivityTime\x12\x1a\n" +
-	"\bcallsign\x18\x05 \x01(\tR\bcallsign\x12\x1a\n" +
-	"\baircraft\x18\x06 \x01(\tR\baircraft\"l\n" +
-	"\x12ActivityPilotLeave\x12 \n" +
-	"\vtargetEmail\x18\x01 \x01(\tR\vtargetEmail\x12\x10\n" +
-	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\"\n" +
-	"\factivityName\x18\x03 \x01(\tR\factivityName\"\x97\x01\n" +
-	"\x11ApplicationPassed\x12 \n" +
-	"\vtargetEmail\x18\x01 \x01(\tR\vtargetEmail\x12\x10\n" +
-	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\x1a\n" +
+func (x *StartEmailChangeRequest) Reset() {
+	*x = StartEmailChangeRequest{}
+	mi := &file_email_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartEmailChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartEmailChangeRequest) ProtoMessage() {}
+
+func (x *StartEmailChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartEmailChangeRequest.ProtoReflect.Descriptor instead.
+func (*StartEmailChangeRequest) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *StartEmailChangeRequest) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *StartEmailChangeRequest) GetOldEmail() string {
+	if x != nil {
+		return x.OldEmail
+	}
+	return ""
+}
+
+func (x *StartEmailChangeRequest) GetNewEmail() string {
+	if x != nil {
+		return x.NewEmail
+	}
+	return ""
+}
+
+func (x *StartEmailChangeRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *StartEmailChangeRequest) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+type StartEmailChangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	ExpireAt      int64                  `protobuf:"varint,2,opt,name=expireAt,proto3" json:"expireAt,omitempty"` // 验证码过期的unix毫秒时间戳
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartEmailChangeResponse) Reset() {
+	*x = StartEmailChangeResponse{}
+	mi := &file_email_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartEmailChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartEmailChangeResponse) ProtoMessage() {}
+
+func (x *StartEmailChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartEmailChangeResponse.ProtoReflect.Descriptor instead.
+func (*StartEmailChangeResponse) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *StartEmailChangeResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StartEmailChangeResponse) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+type ConfirmEmailChangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cid           string                 `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmEmailChangeRequest) Reset() {
+	*x = ConfirmEmailChangeRequest{}
+	mi := &file_email_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmEmailChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmEmailChangeRequest) ProtoMessage() {}
+
+func (x *ConfirmEmailChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmEmailChangeRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmEmailChangeRequest) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ConfirmEmailChangeRequest) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *ConfirmEmailChangeRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ConfirmEmailChangeResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	OldEmail       string                 `protobuf:"bytes,2,opt,name=oldEmail,proto3" json:"oldEmail,omitempty"`
+	NewEmail       string                 `protobuf:"bytes,3,opt,name=newEmail,proto3" json:"newEmail,omitempty"`
+	RevertDeadline int64                  `protobuf:"varint,4,opt,name=revertDeadline,proto3" json:"revertDeadline,omitempty"` // 旧邮箱可撤销变更的截止unix毫秒时间戳
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ConfirmEmailChangeResponse) Reset() {
+	*x = ConfirmEmailChangeResponse{}
+	mi := &file_email_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmEmailChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmEmailChangeResponse) ProtoMessage() {}
+
+func (x *ConfirmEmailChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmEmailChangeResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmEmailChangeResponse) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ConfirmEmailChangeResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ConfirmEmailChangeResponse) GetOldEmail() string {
+	if x != nil {
+		return x.OldEmail
+	}
+	return ""
+}
+
+func (x *ConfirmEmailChangeResponse) GetNewEmail() string {
+	if x != nil {
+		return x.NewEmail
+	}
+	return ""
+}
+
+func (x *ConfirmEmailChangeResponse) GetRevertDeadline() int64 {
+	if x != nil {
+		return x.RevertDeadline
+	}
+	return 0
+}
+
+type CancelEmailChangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelEmailChangeRequest) Reset() {
+	*x = CancelEmailChangeRequest{}
+	mi := &file_email_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelEmailChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelEmailChangeRequest) ProtoMessage() {}
+
+func (x *CancelEmailChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelEmailChangeRequest.ProtoReflect.Descriptor instead.
+func (*CancelEmailChangeRequest) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CancelEmailChangeRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type CancelEmailChangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Reverted      bool                   `protobuf:"varint,2,opt,name=reverted,proto3" json:"reverted,omitempty"` // true = 变更已确认, 调用方需要将邮箱恢复为oldEmail
+	Cid           string                 `protobuf:"bytes,3,opt,name=cid,proto3" json:"cid,omitempty"`
+	OldEmail      string                 `protobuf:"bytes,4,opt,name=oldEmail,proto3" json:"oldEmail,omitempty"`
+	NewEmail      string                 `protobuf:"bytes,5,opt,name=newEmail,proto3" json:"newEmail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelEmailChangeResponse) Reset() {
+	*x = CancelEmailChangeResponse{}
+	mi := &file_email_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelEmailChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelEmailChangeResponse) ProtoMessage() {}
+
+func (x *CancelEmailChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelEmailChangeResponse.ProtoReflect.Descriptor instead.
+func (*CancelEmailChangeResponse) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CancelEmailChangeResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CancelEmailChangeResponse) GetReverted() bool {
+	if x != nil {
+		return x.Reverted
+	}
+	return false
+}
+
+func (x *CancelEmailChangeResponse) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *CancelEmailChangeResponse) GetOldEmail() string {
+	if x != nil {
+		return x.OldEmail
+	}
+	return ""
+}
+
+func (x *CancelEmailChangeResponse) GetNewEmail() string {
+	if x != nil {
+		return x.NewEmail
+	}
+	return ""
+}
+
+type IssueResetTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cid           string                 `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Ip            string                 `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,4,opt,name=userAgent,proto3" json:"userAgent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueResetTokenRequest) Reset() {
+	*x = IssueResetTokenRequest{}
+	mi := &file_email_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueResetTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueResetTokenRequest) ProtoMessage() {}
+
+func (x *IssueResetTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueResetTokenRequest.ProtoReflect.Descriptor instead.
+func (*IssueResetTokenRequest) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *IssueResetTokenRequest) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *IssueResetTokenRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *IssueResetTokenRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *IssueResetTokenRequest) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+type IssueResetTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	ExpireAt      int64                  `protobuf:"varint,2,opt,name=expireAt,proto3" json:"expireAt,omitempty"` // 令牌过期的unix毫秒时间戳
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueResetTokenResponse) Reset() {
+	*x = IssueResetTokenResponse{}
+	mi := &file_email_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueResetTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueResetTokenResponse) ProtoMessage() {}
+
+func (x *IssueResetTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueResetTokenResponse.ProtoReflect.Descriptor instead.
+func (*IssueResetTokenResponse) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *IssueResetTokenResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *IssueResetTokenResponse) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+type ResetTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cid           string                 `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"` // 与签发令牌时绑定的邮箱不一致时视为无效令牌
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetTokenRequest) Reset() {
+	*x = ResetTokenRequest{}
+	mi := &file_email_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetTokenRequest) ProtoMessage() {}
+
+func (x *ResetTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetTokenRequest.ProtoReflect.Descriptor instead.
+func (*ResetTokenRequest) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ResetTokenRequest) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *ResetTokenRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ResetTokenRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type ResetTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"` // 签发令牌时绑定的邮箱
+	ExpireAt      int64                  `protobuf:"varint,3,opt,name=expireAt,proto3" json:"expireAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetTokenResponse) Reset() {
+	*x = ResetTokenResponse{}
+	mi := &file_email_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetTokenResponse) ProtoMessage() {}
+
+func (x *ResetTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetTokenResponse.ProtoReflect.Descriptor instead.
+func (*ResetTokenResponse) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ResetTokenResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ResetTokenResponse) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ResetTokenResponse) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+type ListCapturedEmailsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TargetEmail   string                 `protobuf:"bytes,1,opt,name=targetEmail,proto3" json:"targetEmail,omitempty"` // 为空时返回全部邮件
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`            // 0 = 不限制
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCapturedEmailsRequest) Reset() {
+	*x = ListCapturedEmailsRequest{}
+	mi := &file_email_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCapturedEmailsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCapturedEmailsRequest) ProtoMessage() {}
+
+func (x *ListCapturedEmailsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCapturedEmailsRequest.ProtoReflect.Descriptor instead.
+func (*ListCapturedEmailsRequest) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListCapturedEmailsRequest) GetTargetEmail() string {
+	if x != nil {
+		return x.TargetEmail
+	}
+	return ""
+}
+
+func (x *ListCapturedEmailsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type CapturedEmail struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Time          int64                  `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"` // unix毫秒时间戳
+	From          string                 `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To            []string               `protobuf:"bytes,4,rep,name=to,proto3" json:"to,omitempty"`
+	Subject       string                 `protobuf:"bytes,5,opt,name=subject,proto3" json:"subject,omitempty"`
+	Html          string                 `protobuf:"bytes,6,opt,name=html,proto3" json:"html,omitempty"`
+	Text          string                 `protobuf:"bytes,7,opt,name=text,proto3" json:"text,omitempty"`
+	Headers       map[string]string      `protobuf:"bytes,8,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CapturedEmail) Reset() {
+	*x = CapturedEmail{}
+	mi := &file_email_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CapturedEmail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapturedEmail) ProtoMessage() {}
+
+func (x *CapturedEmail) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapturedEmail.ProtoReflect.Descriptor instead.
+func (*CapturedEmail) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CapturedEmail) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CapturedEmail) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *CapturedEmail) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *CapturedEmail) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *CapturedEmail) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *CapturedEmail) GetHtml() string {
+	if x != nil {
+		return x.Html
+	}
+	return ""
+}
+
+func (x *CapturedEmail) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *CapturedEmail) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+type ListCapturedEmailsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Emails        []*CapturedEmail       `protobuf:"bytes,1,rep,name=emails,proto3" json:"emails,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCapturedEmailsResponse) Reset() {
+	*x = ListCapturedEmailsResponse{}
+	mi := &file_email_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCapturedEmailsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCapturedEmailsResponse) ProtoMessage() {}
+
+func (x *ListCapturedEmailsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCapturedEmailsResponse.ProtoReflect.Descriptor instead.
+func (*ListCapturedEmailsResponse) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListCapturedEmailsResponse) GetEmails() []*CapturedEmail {
+	if x != nil {
+		return x.Emails
+	}
+	return nil
+}
+
+var File_email_proto protoreflect.FileDescriptor
+
+const file_email_proto_rawDesc = "" +
+	"\n" +
+	"\vemail.proto\x12\ffsd_universe\x1a\x1fgoogle/protobuf/timestamp.proto\"\xdd\x03\n" +
+	"\x0fActivityAtcJoin\x12 \n" +
+	"\vtargetEmail\x18\x01 \x01(\tR\vtargetEmail\x12\x10\n" +
+	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\"\n" +
+	"\factivityName\x18\x03 \x01(\tR\factivityName\x12'\n" +
+	"\factivityTime\x18\x04 \x01(\tH\x00R\factivityTime\x88\x01\x01\x12\x1a\n" +
+	"\bfacility\x18\x05 \x01(\tR\bfacility\x12\x1c\n" +
+	"\tfrequency\x18\x06 \x01(\tR\tfrequency\x12\x0e\n" +
+	"\x02to\x18\a \x03(\tR\x02to\x12\x0e\n" +
+	"\x02cc\x18\b \x03(\tR\x02cc\x12\x10\n" +
+	"\x03bcc\x18\t \x03(\tR\x03bcc\x12+\n" +
+	"\x0eidempotencyKey\x18\n" +
+	" \x01(\tH\x01R\x0eidempotencyKey\x88\x01\x01\x12\x14\n" +
+	"\x05async\x18\v \x01(\bR\x05async\x12B\n" +
+	"\x0eactivityTimeAt\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\x0eactivityTimeAt\x12#\n" +
+	"\n" +
+	"activityId\x18\r \x01(\tH\x02R\n" +
+	"activityId\x88\x01\x01B\x0f\n" +
+	"\r_activityTimeB\x11\n" +
+	"\x0f_idempotencyKeyB\r\n" +
+	"\v_activityId\"\xa6\x02\n" +
+	"\x10ActivityAtcLeave\x12 \n" +
+	"\vtargetEmail\x18\x01 \x01(\tR\vtargetEmail\x12\x10\n" +
+	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\"\n" +
+	"\factivityName\x18\x03 \x01(\tR\factivityName\x12\x0e\n" +
+	"\x02to\x18\x04 \x03(\tR\x02to\x12\x0e\n" +
+	"\x02cc\x18\x05 \x03(\tR\x02cc\x12\x10\n" +
+	"\x03bcc\x18\x06 \x03(\tR\x03bcc\x12+\n" +
+	"\x0eidempotencyKey\x18\a \x01(\tH\x00R\x0eidempotencyKey\x88\x01\x01\x12\x14\n" +
+	"\x05async\x18\b \x01(\bR\x05async\x12#\n" +
+	"\n" +
+	"activityId\x18\t \x01(\tH\x01R\n" +
+	"activityId\x88\x01\x01B\x11\n" +
+	"\x0f_idempotencyKeyB\r\n" +
+	"\v_activityId\"\xdd\x03\n" +
+	"\x11ActivityPilotJoin\x12 \n" +
+	"\vtargetEmail\x18\x01 \x01(\tR\vtargetEmail\x12\x10\n" +
+	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\"\n" +
+	"\factivityName\x18\x03 \x01(\tR\factivityName\x12'\n" +
+	"\factivityTime\x18\x04 \x01(\tH\x00R\factivityTime\x88\x01\x01\x12\x1a\n" +
+	"\bcallsign\x18\x05 \x01(\tR\bcallsign\x12\x1a\n" +
+	"\baircraft\x18\x06 \x01(\tR\baircraft\x12\x0e\n" +
+	"\x02to\x18\a \x03(\tR\x02to\x12\x0e\n" +
+	"\x02cc\x18\b \x03(\tR\x02cc\x12\x10\n" +
+	"\x03bcc\x18\t \x03(\tR\x03bcc\x12+\n" +
+	"\x0eidempotencyKey\x18\n" +
+	" \x01(\tH\x01R\x0eidempotencyKey\x88\x01\x01\x12\x14\n" +
+	"\x05async\x18\v \x01(\bR\x05async\x12B\n" +
+	"\x0eactivityTimeAt\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\x0eactivityTimeAt\x12#\n" +
+	"\n" +
+	"activityId\x18\r \x01(\tH\x02R\n" +
+	"activityId\x88\x01\x01B\x0f\n" +
+	"\r_activityTimeB\x11\n" +
+	"\x0f_idempotencyKeyB\r\n" +
+	"\v_activityId\"\xa8\x02\n" +
+	"\x12ActivityPilotLeave\x12 \n" +
+	"\vtargetEmail\x18\x01 \x01(\tR\vtargetEmail\x12\x10\n" +
+	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\"\n" +
+	"\factivityName\x18\x03 \x01(\tR\factivityName\x12\x0e\n" +
+	"\x02to\x18\x04 \x03(\tR\x02to\x12\x0e\n" +
+	"\x02cc\x18\x05 \x03(\tR\x02cc\x12\x10\n" +
+	"\x03bcc\x18\x06 \x03(\tR\x03bcc\x12+\n" +
+	"\x0eidempotencyKey\x18\a \x01(\tH\x00R\x0eidempotencyKey\x88\x01\x01\x12\x14\n" +
+	"\x05async\x18\b \x01(\bR\x05async\x12#\n" +
+	"\n" +
+	"activityId\x18\t \x01(\tH\x01R\n" +
+	"activityId\x88\x01\x01B\x11\n" +
+	"\x0f_idempotencyKeyB\r\n" +
+	"\v_activityId\"\x9f\x02\n" +
+	"\x11ApplicationPassed\x12 \n" +
+	"\vtargetEmail\x18\x01 \x01(\tR\vtargetEmail\x12\x10\n" +
+	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\x1a\n" +
 	"\boperator\x18\x03 \x01(\tR\boperator\x12\x18\n" +
 	"\amessage\x18\x04 \x01(\tR\amessage\x12\x18\n" +
-	"\acontact\x18\x05 \x01(\tR\acontact\"y\n" +
+	"\acontact\x18\x05 \x01(\tR\acontact\x12\x0e\n" +
+	"\x02to\x18\x06 \x03(\tR\x02to\x12\x0e\n" +
+	"\x02cc\x18\a \x03(\tR\x02cc\x12\x10\n" +
+	"\x03bcc\x18\b \x03(\tR\x03bcc\x12+\n" +
+	"\x0eidempotencyKey\x18\t \x01(\tH\x00R\x0eidempotencyKey\x88\x01\x01\x12\x14\n" +
+	"\x05async\x18\n" +
+	" \x01(\bR\x05asyncB\x11\n" +
+	"\x0f_idempotencyKey\"\xc3\x02\n" +
 	"\x15ApplicationProcessing\x12 \n" +
 	"\vtargetEmail\x18\x01 \x01(\tR\vtargetEmail\x12\x10\n" +
-	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\x12\n" +
-	"\x04time\x18\x03 \x01(\tR\x04time\x12\x18\n" +
-	"\acontact\x18\x04 \x01(\tR\acontact\"\x97\x01\n" +
+	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\x17\n" +
+	"\x04time\x18\x03 \x01(\tH\x00R\x04time\x88\x01\x01\x12\x18\n" +
+	"\acontact\x18\x04 \x01(\tR\acontact\x12\x0e\n" +
+	"\x02to\x18\x05 \x03(\tR\x02to\x12\x0e\n" +
+	"\x02cc\x18\x06 \x03(\tR\x02cc\x12\x10\n" +
+	"\x03bcc\x18\a \x03(\tR\x03bcc\x12+\n" +
+	"\x0eidempotencyKey\x18\b \x01(\tH\x01R\x0eidempotencyKey\x88\x01\x01\x12\x14\n" +
+	"\x05async\x18\t \x01(\bR\x05async\x122\n" +
+	"\x06timeAt\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x06timeAtB\a\n" +
+	"\x05_timeB\x11\n" +
+	"\x0f_idempotencyKey\"\x9f\x02\n" +
 	"\x13ApplicationRejected\x12 \n" +
 	"\vtargetEmail\x18\x01 \x01(\tR\vtargetEmail\x12\x10\n" +
 	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\x1a\n" +
 	"\boperator\x18\x03 \x01(\tR\boperator\x12\x16\n" +
 	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x18\n" +
-	"\acontact\x18\x05 \x01(\tR\acontact\"\xb3\x01\n" +
+	"\acontact\x18\x05 \x01(\tR\acontact\x12\x0e\n" +
+	"\x02to\x18\x06 \x03(\tR\x02to\x12\x0e\n" +
+	"\x02cc\x18\a \x03(\tR\x02cc\x12\x10\n" +
+	"\x03bcc\x18\b \x03(\tR\x03bcc\x12+\n" +
+	"\x0eidempotencyKey\x18\t \x01(\tH\x00R\x0eidempotencyKey\x88\x01\x01\x12\x14\n" +
+	"\x05async\x18\n" +
+	" \x01(\bR\x05asyncB\x11\n" +
+	"\x0f_idempotencyKey\"\xbb\x02\n" +
 	"\x0fAtcRatingChange\x12 \n" +
 	"\vtargetEmail\x18\x01 \x01(\tR\vtargetEmail\x12\x10\n" +
 	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\x1a\n" +
 	"\bnewValue\x18\x03 \x01(\tR\bnewValue\x12\x1a\n" +
 	"\boldValue\x18\x04 \x01(\tR\boldValue\x12\x1a\n" +
 	"\boperator\x18\x05 \x01(\tR\boperator\x12\x18\n" +
-	"\acontact\x18\x06 \x01(\tR\acontact\"\x9e\x01\n" +
+	"\acontact\x18\x06 \x01(\tR\acontact\x12\x0e\n" +
+	"\x02to\x18\a \x03(\tR\x02to\x12\x0e\n" +
+	"\x02cc\x18\b \x03(\tR\x02cc\x12\x10\n" +
+	"\x03bcc\x18\t \x03(\tR\x03bcc\x12+\n" +
+	"\x0eidempotencyKey\x18\n" +
+	" \x01(\tH\x00R\x0eidempotencyKey\x88\x01\x01\x12\x14\n" +
+	"\x05async\x18\v \x01(\bR\x05asyncB\x11\n" +
+	"\x0f_idempotencyKey\"\xe8\x02\n" +
 	"\x06Banned\x12 \n" +
 	"\vtargetEmail\x18\x01 \x01(\tR\vtargetEmail\x12\x10\n" +
 	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\x16\n" +
-	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x12\n" +
-	"\x04time\x18\x04 \x01(\tR\x04time\x12\x1a\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x17\n" +
+	"\x04time\x18\x04 \x01(\tH\x00R\x04time\x88\x01\x01\x12\x1a\n" +
 	"\boperator\x18\x05 \x01(\tR\boperator\x12\x18\n" +
-	"\acontact\x18\x06 \x01(\tR\acontact\"t\n" +
+	"\acontact\x18\x06 \x01(\tR\acontact\x12\x0e\n" +
+	"\x02to\x18\a \x03(\tR\x02to\x12\x0e\n" +
+	"\x02cc\x18\b \x03(\tR\x02cc\x12\x10\n" +
+	"\x03bcc\x18\t \x03(\tR\x03bcc\x12+\n" +
+	"\x0eidempotencyKey\x18\n" +
+	" \x01(\tH\x01R\x0eidempotencyKey\x88\x01\x01\x12\x14\n" +
+	"\x05async\x18\v \x01(\bR\x05async\x122\n" +
+	"\x06timeAt\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\x06timeAtB\a\n" +
+	"\x05_timeB\x11\n" +
+	"\x0f_idempotencyKey\"\xfc\x01\n" +
 	"\bUnbanned\x12 \n" +
 	"\vtargetEmail\x18\x01 \x01(\tR\vtargetEmail\x12\x10\n" +
 	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\x1a\n" +
 	"\boperator\x18\x03 \x01(\tR\boperator\x12\x18\n" +
-	"\acontact\x18\x04 \x01(\tR\acontact\"\xb4\x01\n" +
+	"\acontact\x18\x04 \x01(\tR\acontact\x12\x0e\n" +
+	"\x02to\x18\x05 \x03(\tR\x02to\x12\x0e\n" +
+	"\x02cc\x18\x06 \x03(\tR\x02cc\x12\x10\n" +
+	"\x03bcc\x18\a \x03(\tR\x03bcc\x12+\n" +
+	"\x0eidempotencyKey\x18\b \x01(\tH\x00R\x0eidempotencyKey\x88\x01\x01\x12\x14\n" +
+	"\x05async\x18\t \x01(\bR\x05asyncB\x11\n" +
+	"\x0f_idempotencyKey\"\xbc\x02\n" +
 	"\x10InstructorChange\x12 \n" +
 	"\vtargetEmail\x18\x01 \x01(\tR\vtargetEmail\x12\x10\n" +
 	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\x16\n" +
@@ -1751,56 +3702,139 @@ const file_email_proto_rawDesc = "" +
 	"instructor\x18\x04 \x01(\tR\n" +
 	"instructor\x12\x1a\n" +
 	"\boperator\x18\x05 \x01(\tR\boperator\x12\x18\n" +
-	"\acontact\x18\x06 \x01(\tR\acontact\"\xa8\x01\n" +
+	"\acontact\x18\x06 \x01(\tR\acontact\x12\x0e\n" +
+	"\x02to\x18\a \x03(\tR\x02to\x12\x0e\n" +
+	"\x02cc\x18\b \x03(\tR\x02cc\x12\x10\n" +
+	"\x03bcc\x18\t \x03(\tR\x03bcc\x12+\n" +
+	"\x0eidempotencyKey\x18\n" +
+	" \x01(\tH\x00R\x0eidempotencyKey\x88\x01\x01\x12\x14\n" +
+	"\x05async\x18\v \x01(\bR\x05asyncB\x11\n" +
+	"\x0f_idempotencyKey\"\xf2\x02\n" +
 	"\x10KickedFromServer\x12 \n" +
 	"\vtargetEmail\x18\x01 \x01(\tR\vtargetEmail\x12\x10\n" +
 	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\x16\n" +
-	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x12\n" +
-	"\x04time\x18\x04 \x01(\tR\x04time\x12\x1a\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x17\n" +
+	"\x04time\x18\x04 \x01(\tH\x00R\x04time\x88\x01\x01\x12\x1a\n" +
 	"\boperator\x18\x05 \x01(\tR\boperator\x12\x18\n" +
-	"\acontact\x18\x06 \x01(\tR\acontact\"\x86\x01\n" +
+	"\acontact\x18\x06 \x01(\tR\acontact\x12\x0e\n" +
+	"\x02to\x18\a \x03(\tR\x02to\x12\x0e\n" +
+	"\x02cc\x18\b \x03(\tR\x02cc\x12\x10\n" +
+	"\x03bcc\x18\t \x03(\tR\x03bcc\x12+\n" +
+	"\x0eidempotencyKey\x18\n" +
+	" \x01(\tH\x01R\x0eidempotencyKey\x88\x01\x01\x12\x14\n" +
+	"\x05async\x18\v \x01(\bR\x05async\x122\n" +
+	"\x06timeAt\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\x06timeAtB\a\n" +
+	"\x05_timeB\x11\n" +
+	"\x0f_idempotencyKey\"\xd0\x02\n" +
 	"\x0ePasswordChange\x12 \n" +
 	"\vtargetEmail\x18\x01 \x01(\tR\vtargetEmail\x12\x10\n" +
-	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\x12\n" +
-	"\x04time\x18\x03 \x01(\tR\x04time\x12\x0e\n" +
+	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\x17\n" +
+	"\x04time\x18\x03 \x01(\tH\x00R\x04time\x88\x01\x01\x12\x0e\n" +
 	"\x02ip\x18\x04 \x01(\tR\x02ip\x12\x1c\n" +
-	"\tuserAgent\x18\x05 \x01(\tR\tuserAgent\"\x85\x01\n" +
+	"\tuserAgent\x18\x05 \x01(\tR\tuserAgent\x12\x0e\n" +
+	"\x02to\x18\x06 \x03(\tR\x02to\x12\x0e\n" +
+	"\x02cc\x18\a \x03(\tR\x02cc\x12\x10\n" +
+	"\x03bcc\x18\b \x03(\tR\x03bcc\x12+\n" +
+	"\x0eidempotencyKey\x18\t \x01(\tH\x01R\x0eidempotencyKey\x88\x01\x01\x12\x14\n" +
+	"\x05async\x18\n" +
+	" \x01(\bR\x05async\x122\n" +
+	"\x06timeAt\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x06timeAtB\a\n" +
+	"\x05_timeB\x11\n" +
+	"\x0f_idempotencyKey\"\xcf\x02\n" +
 	"\rPasswordReset\x12 \n" +
 	"\vtargetEmail\x18\x01 \x01(\tR\vtargetEmail\x12\x10\n" +
-	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\x12\n" +
-	"\x04time\x18\x03 \x01(\tR\x04time\x12\x0e\n" +
+	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\x17\n" +
+	"\x04time\x18\x03 \x01(\tH\x00R\x04time\x88\x01\x01\x12\x0e\n" +
 	"\x02ip\x18\x04 \x01(\tR\x02ip\x12\x1c\n" +
-	"\tuserAgent\x18\x05 \x01(\tR\tuserAgent\"\x9e\x01\n" +
+	"\tuserAgent\x18\x05 \x01(\tR\tuserAgent\x12\x0e\n" +
+	"\x02to\x18\x06 \x03(\tR\x02to\x12\x0e\n" +
+	"\x02cc\x18\a \x03(\tR\x02cc\x12\x10\n" +
+	"\x03bcc\x18\b \x03(\tR\x03bcc\x12+\n" +
+	"\x0eidempotencyKey\x18\t \x01(\tH\x01R\x0eidempotencyKey\x88\x01\x01\x12\x14\n" +
+	"\x05async\x18\n" +
+	" \x01(\bR\x05async\x122\n" +
+	"\x06timeAt\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x06timeAtB\a\n" +
+	"\x05_timeB\x11\n" +
+	"\x0f_idempotencyKey\"\xa6\x02\n" +
 	"\x10PermissionChange\x12 \n" +
 	"\vtargetEmail\x18\x01 \x01(\tR\vtargetEmail\x12\x10\n" +
 	"\x03cid\x18\x02 \x01(\tR\x03cid\x12 \n" +
 	"\vpermissions\x18\x03 \x01(\tR\vpermissions\x12\x1a\n" +
 	"\boperator\x18\x04 \x01(\tR\boperator\x12\x18\n" +
-	"\acontact\x18\x05 \x01(\tR\acontact\"\x8c\x01\n" +
+	"\acontact\x18\x05 \x01(\tR\acontact\x12\x0e\n" +
+	"\x02to\x18\x06 \x03(\tR\x02to\x12\x0e\n" +
+	"\x02cc\x18\a \x03(\tR\x02cc\x12\x10\n" +
+	"\x03bcc\x18\b \x03(\tR\x03bcc\x12+\n" +
+	"\x0eidempotencyKey\x18\t \x01(\tH\x00R\x0eidempotencyKey\x88\x01\x01\x12\x14\n" +
+	"\x05async\x18\n" +
+	" \x01(\bR\x05asyncB\x11\n" +
+	"\x0f_idempotencyKey\"\x84\x02\n" +
 	"\n" +
 	"RoleChange\x12 \n" +
 	"\vtargetEmail\x18\x01 \x03(\tR\vtargetEmail\x12\x10\n" +
 	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\x14\n" +
 	"\x05roles\x18\x03 \x01(\tR\x05roles\x12\x1a\n" +
 	"\boperator\x18\x04 \x01(\tR\boperator\x12\x18\n" +
-	"\acontact\x18\x05 \x01(\tR\acontact\"m\n" +
+	"\acontact\x18\x05 \x01(\tR\acontact\x12\x0e\n" +
+	"\x02cc\x18\x06 \x03(\tR\x02cc\x12\x10\n" +
+	"\x03bcc\x18\a \x03(\tR\x03bcc\x12+\n" +
+	"\x0eidempotencyKey\x18\b \x01(\tH\x00R\x0eidempotencyKey\x88\x01\x01\x12\x14\n" +
+	"\x05async\x18\t \x01(\bR\x05asyncB\x11\n" +
+	"\x0f_idempotencyKey\"\xa3\x02\n" +
 	"\vTicketReply\x12 \n" +
 	"\vtargetEmail\x18\x01 \x01(\tR\vtargetEmail\x12\x10\n" +
 	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\x14\n" +
 	"\x05title\x18\x03 \x01(\tR\x05title\x12\x14\n" +
-	"\x05reply\x18\x04 \x01(\tR\x05reply\"=\n" +
+	"\x05reply\x18\x04 \x01(\tR\x05reply\x12\x1f\n" +
+	"\bticketId\x18\x05 \x01(\tH\x00R\bticketId\x88\x01\x01\x12\x0e\n" +
+	"\x02to\x18\x06 \x03(\tR\x02to\x12\x0e\n" +
+	"\x02cc\x18\a \x03(\tR\x02cc\x12\x10\n" +
+	"\x03bcc\x18\b \x03(\tR\x03bcc\x12+\n" +
+	"\x0eidempotencyKey\x18\t \x01(\tH\x01R\x0eidempotencyKey\x88\x01\x01\x12\x14\n" +
+	"\x05async\x18\n" +
+	" \x01(\bR\x05asyncB\v\n" +
+	"\t_ticketIdB\x11\n" +
+	"\x0f_idempotencyKey\"\xc5\x01\n" +
 	"\aWelcome\x12 \n" +
 	"\vtargetEmail\x18\x01 \x01(\tR\vtargetEmail\x12\x10\n" +
-	"\x03cid\x18\x02 \x01(\tR\x03cid\"\x99\x01\n" +
+	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\x0e\n" +
+	"\x02to\x18\x03 \x03(\tR\x02to\x12\x0e\n" +
+	"\x02cc\x18\x04 \x03(\tR\x02cc\x12\x10\n" +
+	"\x03bcc\x18\x05 \x03(\tR\x03bcc\x12+\n" +
+	"\x0eidempotencyKey\x18\x06 \x01(\tH\x00R\x0eidempotencyKey\x88\x01\x01\x12\x14\n" +
+	"\x05async\x18\a \x01(\bR\x05asyncB\x11\n" +
+	"\x0f_idempotencyKey\"\xe3\x02\n" +
 	"\vEmailChange\x12 \n" +
 	"\vtargetEmail\x18\x01 \x01(\tR\vtargetEmail\x12\x10\n" +
 	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\x14\n" +
-	"\x05email\x18\x03 \x01(\tR\x05email\x12\x12\n" +
-	"\x04time\x18\x04 \x01(\tR\x04time\x12\x0e\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x17\n" +
+	"\x04time\x18\x04 \x01(\tH\x00R\x04time\x88\x01\x01\x12\x0e\n" +
 	"\x02ip\x18\x05 \x01(\tR\x02ip\x12\x1c\n" +
-	"\tuserAgent\x18\x06 \x01(\tR\tuserAgent\"(\n" +
+	"\tuserAgent\x18\x06 \x01(\tR\tuserAgent\x12\x0e\n" +
+	"\x02to\x18\a \x03(\tR\x02to\x12\x0e\n" +
+	"\x02cc\x18\b \x03(\tR\x02cc\x12\x10\n" +
+	"\x03bcc\x18\t \x03(\tR\x03bcc\x12+\n" +
+	"\x0eidempotencyKey\x18\n" +
+	" \x01(\tH\x01R\x0eidempotencyKey\x88\x01\x01\x12\x14\n" +
+	"\x05async\x18\v \x01(\bR\x05async\x122\n" +
+	"\x06timeAt\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\x06timeAtB\a\n" +
+	"\x05_timeB\x11\n" +
+	"\x0f_idempotencyKey\"f\n" +
 	"\fSendResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"6\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1c\n" +
+	"\tmessageId\x18\x02 \x01(\tR\tmessageId\x12\x1e\n" +
+	"\n" +
+	"messageIds\x18\x03 \x03(\tR\n" +
+	"messageIds\"4\n" +
+	"\x14MessageStatusRequest\x12\x1c\n" +
+	"\tmessageId\x18\x01 \x01(\tR\tmessageId\"\xcf\x01\n" +
+	"\rMessageStatus\x12\x1c\n" +
+	"\tmessageId\x18\x01 \x01(\tR\tmessageId\x12\x1c\n" +
+	"\temailType\x18\x02 \x01(\tR\temailType\x120\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x1a.fsd_universe.MessageStateR\x05state\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x1c\n" +
+	"\tcreatedAt\x18\x05 \x01(\x03R\tcreatedAt\x12\x1c\n" +
+	"\tupdatedAt\x18\x06 \x01(\x03R\tupdatedAt\"6\n" +
 	"\n" +
 	"VerifyCode\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\tR\x04code\x12\x14\n" +
@@ -1811,7 +3845,71 @@ const file_email_proto_rawDesc = "" +
 	"\x10RemoveVerifyCode\x12\x14\n" +
 	"\x05email\x18\x01 \x01(\tR\x05email\"4\n" +
 	"\x18RemoveVerifyCodeResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\x9d\r\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x91\x01\n" +
+	"\x17StartEmailChangeRequest\x12\x10\n" +
+	"\x03cid\x18\x01 \x01(\tR\x03cid\x12\x1a\n" +
+	"\boldEmail\x18\x02 \x01(\tR\boldEmail\x12\x1a\n" +
+	"\bnewEmail\x18\x03 \x01(\tR\bnewEmail\x12\x0e\n" +
+	"\x02ip\x18\x04 \x01(\tR\x02ip\x12\x1c\n" +
+	"\tuserAgent\x18\x05 \x01(\tR\tuserAgent\"P\n" +
+	"\x18StartEmailChangeResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1a\n" +
+	"\bexpireAt\x18\x02 \x01(\x03R\bexpireAt\"A\n" +
+	"\x19ConfirmEmailChangeRequest\x12\x10\n" +
+	"\x03cid\x18\x01 \x01(\tR\x03cid\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"\x96\x01\n" +
+	"\x1aConfirmEmailChangeResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1a\n" +
+	"\boldEmail\x18\x02 \x01(\tR\boldEmail\x12\x1a\n" +
+	"\bnewEmail\x18\x03 \x01(\tR\bnewEmail\x12&\n" +
+	"\x0erevertDeadline\x18\x04 \x01(\x03R\x0erevertDeadline\"0\n" +
+	"\x18CancelEmailChangeRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x9b\x01\n" +
+	"\x19CancelEmailChangeResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1a\n" +
+	"\breverted\x18\x02 \x01(\bR\breverted\x12\x10\n" +
+	"\x03cid\x18\x03 \x01(\tR\x03cid\x12\x1a\n" +
+	"\boldEmail\x18\x04 \x01(\tR\boldEmail\x12\x1a\n" +
+	"\bnewEmail\x18\x05 \x01(\tR\bnewEmail\"n\n" +
+	"\x16IssueResetTokenRequest\x12\x10\n" +
+	"\x03cid\x18\x01 \x01(\tR\x03cid\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x0e\n" +
+	"\x02ip\x18\x03 \x01(\tR\x02ip\x12\x1c\n" +
+	"\tuserAgent\x18\x04 \x01(\tR\tuserAgent\"O\n" +
+	"\x17IssueResetTokenResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1a\n" +
+	"\bexpireAt\x18\x02 \x01(\x03R\bexpireAt\"Q\n" +
+	"\x11ResetTokenRequest\x12\x10\n" +
+	"\x03cid\x18\x01 \x01(\tR\x03cid\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\"\\\n" +
+	"\x12ResetTokenResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1a\n" +
+	"\bexpireAt\x18\x03 \x01(\x03R\bexpireAt\"S\n" +
+	"\x19ListCapturedEmailsRequest\x12 \n" +
+	"\vtargetEmail\x18\x01 \x01(\tR\vtargetEmail\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\x99\x02\n" +
+	"\rCapturedEmail\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04time\x18\x02 \x01(\x03R\x04time\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x03(\tR\x02to\x12\x18\n" +
+	"\asubject\x18\x05 \x01(\tR\asubject\x12\x12\n" +
+	"\x04html\x18\x06 \x01(\tR\x04html\x12\x12\n" +
+	"\x04text\x18\a \x01(\tR\x04text\x12B\n" +
+	"\aheaders\x18\b \x03(\v2(.fsd_universe.CapturedEmail.HeadersEntryR\aheaders\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"Q\n" +
+	"\x1aListCapturedEmailsResponse\x123\n" +
+	"\x06emails\x18\x01 \x03(\v2\x1b.fsd_universe.CapturedEmailR\x06emails*\x90\x01\n" +
+	"\fMessageState\x12\x19\n" +
+	"\x15MESSAGE_STATE_UNKNOWN\x10\x00\x12\x18\n" +
+	"\x14MESSAGE_STATE_QUEUED\x10\x01\x12\x19\n" +
+	"\x15MESSAGE_STATE_SENDING\x10\x02\x12\x16\n" +
+	"\x12MESSAGE_STATE_SENT\x10\x03\x12\x18\n" +
+	"\x14MESSAGE_STATE_FAILED\x10\x042\xf7\x13\n" +
 	"\x05Email\x12P\n" +
 	"\x13SendActivityAtcJoin\x12\x1d.fsd_universe.ActivityAtcJoin\x1a\x1a.fsd_universe.SendResponse\x12R\n" +
 	"\x14SendActivityAtcLeave\x12\x1e.fsd_universe.ActivityAtcLeave\x1a\x1a.fsd_universe.SendResponse\x12T\n" +
@@ -1834,7 +3932,16 @@ const file_email_proto_rawDesc = "" +
 	"\vSendWelcome\x12\x15.fsd_universe.Welcome\x1a\x1a.fsd_universe.SendResponse\x12H\n" +
 	"\x0fSendEmailChange\x12\x19.fsd_universe.EmailChange\x1a\x1a.fsd_universe.SendResponse\x12I\n" +
 	"\x0fVerifyEmailCode\x12\x18.fsd_universe.VerifyCode\x1a\x1c.fsd_universe.VerifyResponse\x12Y\n" +
-	"\x0fRemoveEmailCode\x12\x1e.fsd_universe.RemoveVerifyCode\x1a&.fsd_universe.RemoveVerifyCodeResponseB\x15Z\x13src/interfaces/grpcb\x06proto3"
+	"\x0fRemoveEmailCode\x12\x1e.fsd_universe.RemoveVerifyCode\x1a&.fsd_universe.RemoveVerifyCodeResponse\x12a\n" +
+	"\x10StartEmailChange\x12%.fsd_universe.StartEmailChangeRequest\x1a&.fsd_universe.StartEmailChangeResponse\x12g\n" +
+	"\x12ConfirmEmailChange\x12'.fsd_universe.ConfirmEmailChangeRequest\x1a(.fsd_universe.ConfirmEmailChangeResponse\x12d\n" +
+	"\x11CancelEmailChange\x12&.fsd_universe.CancelEmailChangeRequest\x1a'.fsd_universe.CancelEmailChangeResponse\x12^\n" +
+	"\x0fIssueResetToken\x12$.fsd_universe.IssueResetTokenRequest\x1a%.fsd_universe.IssueResetTokenResponse\x12W\n" +
+	"\x12ValidateResetToken\x12\x1f.fsd_universe.ResetTokenRequest\x1a .fsd_universe.ResetTokenResponse\x12V\n" +
+	"\x11ConsumeResetToken\x12\x1f.fsd_universe.ResetTokenRequest\x1a .fsd_universe.ResetTokenResponse\x12S\n" +
+	"\x10GetMessageStatus\x12\".fsd_universe.MessageStatusRequest\x1a\x1b.fsd_universe.MessageStatus\x12W\n" +
+	"\x12WatchMessageStatus\x12\".fsd_universe.MessageStatusRequest\x1a\x1b.fsd_universe.MessageStatus0\x01\x12g\n" +
+	"\x12ListCapturedEmails\x12'.fsd_universe.ListCapturedEmailsRequest\x1a(.fsd_universe.ListCapturedEmailsResponseB\x15Z\x13src/interfaces/grpcb\x06proto3"
 
 var (
 	file_email_proto_rawDescOnce sync.Once
@@ -1848,81 +3955,129 @@ func file_email_proto_rawDescGZIP() []byte {
 	return file_email_proto_rawDescData
 }
 
-var file_email_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_email_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_email_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
 var file_email_proto_goTypes = []any{
-	(*ActivityAtcJoin)(nil),          // 0: fsd_universe.ActivityAtcJoin
-	(*ActivityAtcLeave)(nil),         // 1: fsd_universe.ActivityAtcLeave
-	(*ActivityPilotJoin)(nil),        // 2: fsd_universe.ActivityPilotJoin
-	(*ActivityPilotLeave)(nil),       // 3: fsd_universe.ActivityPilotLeave
-	(*ApplicationPassed)(nil),        // 4: fsd_universe.ApplicationPassed
-	(*ApplicationProcessing)(nil),    // 5: fsd_universe.ApplicationProcessing
-	(*ApplicationRejected)(nil),      // 6: fsd_universe.ApplicationRejected
-	(*AtcRatingChange)(nil),          // 7: fsd_universe.AtcRatingChange
-	(*Banned)(nil),                   // 8: fsd_universe.Banned
-	(*Unbanned)(nil),                 // 9: fsd_universe.Unbanned
-	(*InstructorChange)(nil),         // 10: fsd_universe.InstructorChange
-	(*KickedFromServer)(nil),         // 11: fsd_universe.KickedFromServer
-	(*PasswordChange)(nil),           // 12: fsd_universe.PasswordChange
-	(*PasswordReset)(nil),            // 13: fsd_universe.PasswordReset
-	(*PermissionChange)(nil),         // 14: fsd_universe.PermissionChange
-	(*RoleChange)(nil),               // 15: fsd_universe.RoleChange
-	(*TicketReply)(nil),              // 16: fsd_universe.TicketReply
-	(*Welcome)(nil),                  // 17: fsd_universe.Welcome
-	(*EmailChange)(nil),              // 18: fsd_universe.EmailChange
-	(*SendResponse)(nil),             // 19: fsd_universe.SendResponse
-	(*VerifyCode)(nil),               // 20: fsd_universe.VerifyCode
-	(*VerifyResponse)(nil),           // 21: fsd_universe.VerifyResponse
-	(*RemoveVerifyCode)(nil),         // 22: fsd_universe.RemoveVerifyCode
-	(*RemoveVerifyCodeResponse)(nil), // 23: fsd_universe.RemoveVerifyCodeResponse
+	(MessageState)(0),                  // 0: fsd_universe.MessageState
+	(*ActivityAtcJoin)(nil),            // 1: fsd_universe.ActivityAtcJoin
+	(*ActivityAtcLeave)(nil),           // 2: fsd_universe.ActivityAtcLeave
+	(*ActivityPilotJoin)(nil),          // 3: fsd_universe.ActivityPilotJoin
+	(*ActivityPilotLeave)(nil),         // 4: fsd_universe.ActivityPilotLeave
+	(*ApplicationPassed)(nil),          // 5: fsd_universe.ApplicationPassed
+	(*ApplicationProcessing)(nil),      // 6: fsd_universe.ApplicationProcessing
+	(*ApplicationRejected)(nil),        // 7: fsd_universe.ApplicationRejected
+	(*AtcRatingChange)(nil),            // 8: fsd_universe.AtcRatingChange
+	(*Banned)(nil),                     // 9: fsd_universe.Banned
+	(*Unbanned)(nil),                   // 10: fsd_universe.Unbanned
+	(*InstructorChange)(nil),           // 11: fsd_universe.InstructorChange
+	(*KickedFromServer)(nil),           // 12: fsd_universe.KickedFromServer
+	(*PasswordChange)(nil),             // 13: fsd_universe.PasswordChange
+	(*PasswordReset)(nil),              // 14: fsd_universe.PasswordReset
+	(*PermissionChange)(nil),           // 15: fsd_universe.PermissionChange
+	(*RoleChange)(nil),                 // 16: fsd_universe.RoleChange
+	(*TicketReply)(nil),                // 17: fsd_universe.TicketReply
+	(*Welcome)(nil),                    // 18: fsd_universe.Welcome
+	(*EmailChange)(nil),                // 19: fsd_universe.EmailChange
+	(*SendResponse)(nil),               // 20: fsd_universe.SendResponse
+	(*MessageStatusRequest)(nil),       // 21: fsd_universe.MessageStatusRequest
+	(*MessageStatus)(nil),              // 22: fsd_universe.MessageStatus
+	(*VerifyCode)(nil),                 // 23: fsd_universe.VerifyCode
+	(*VerifyResponse)(nil),             // 24: fsd_universe.VerifyResponse
+	(*RemoveVerifyCode)(nil),           // 25: fsd_universe.RemoveVerifyCode
+	(*RemoveVerifyCodeResponse)(nil),   // 26: fsd_universe.RemoveVerifyCodeResponse
+	(*StartEmailChangeRequest)(nil),    // 27: fsd_universe.StartEmailChangeRequest
+	(*StartEmailChangeResponse)(nil),   // 28: fsd_universe.StartEmailChangeResponse
+	(*ConfirmEmailChangeRequest)(nil),  // 29: fsd_universe.ConfirmEmailChangeRequest
+	(*ConfirmEmailChangeResponse)(nil), // 30: fsd_universe.ConfirmEmailChangeResponse
+	(*CancelEmailChangeRequest)(nil),   // 31: fsd_universe.CancelEmailChangeRequest
+	(*CancelEmailChangeResponse)(nil),  // 32: fsd_universe.CancelEmailChangeResponse
+	(*IssueResetTokenRequest)(nil),     // 33: fsd_universe.IssueResetTokenRequest
+	(*IssueResetTokenResponse)(nil),    // 34: fsd_universe.IssueResetTokenResponse
+	(*ResetTokenRequest)(nil),          // 35: fsd_universe.ResetTokenRequest
+	(*ResetTokenResponse)(nil),         // 36: fsd_universe.ResetTokenResponse
+	(*ListCapturedEmailsRequest)(nil),  // 37: fsd_universe.ListCapturedEmailsRequest
+	(*CapturedEmail)(nil),              // 38: fsd_universe.CapturedEmail
+	(*ListCapturedEmailsResponse)(nil), // 39: fsd_universe.ListCapturedEmailsResponse
+	nil,                                // 40: fsd_universe.CapturedEmail.HeadersEntry
+	(*timestamppb.Timestamp)(nil),      // 41: google.protobuf.Timestamp
 }
 var file_email_proto_depIdxs = []int32{
-	0,  // 0: fsd_universe.Email.SendActivityAtcJoin:input_type -> fsd_universe.ActivityAtcJoin
-	1,  // 1: fsd_universe.Email.SendActivityAtcLeave:input_type -> fsd_universe.ActivityAtcLeave
-	2,  // 2: fsd_universe.Email.SendActivityPilotJoin:input_type -> fsd_universe.ActivityPilotJoin
-	3,  // 3: fsd_universe.Email.SendActivityPilotLeave:input_type -> fsd_universe.ActivityPilotLeave
-	4,  // 4: fsd_universe.Email.SendApplicationPassed:input_type -> fsd_universe.ApplicationPassed
-	5,  // 5: fsd_universe.Email.SendApplicationProcessing:input_type -> fsd_universe.ApplicationProcessing
-	6,  // 6: fsd_universe.Email.SendApplicationRejected:input_type -> fsd_universe.ApplicationRejected
-	7,  // 7: fsd_universe.Email.SendAtcRatingChange:input_type -> fsd_universe.AtcRatingChange
-	8,  // 8: fsd_universe.Email.SendBanned:input_type -> fsd_universe.Banned
-	9,  // 9: fsd_universe.Email.SendUnbanned:input_type -> fsd_universe.Unbanned
-	10, // 10: fsd_universe.Email.SendInstructorChange:input_type -> fsd_universe.InstructorChange
-	11, // 11: fsd_universe.Email.SendKickedFromServer:input_type -> fsd_universe.KickedFromServer
-	12, // 12: fsd_universe.Email.SendPasswordChange:input_type -> fsd_universe.PasswordChange
-	13, // 13: fsd_universe.Email.SendPasswordReset:input_type -> fsd_universe.PasswordReset
-	14, // 14: fsd_universe.Email.SendPermissionChange:input_type -> fsd_universe.PermissionChange
-	15, // 15: fsd_universe.Email.SendRoleChange:input_type -> fsd_universe.RoleChange
-	16, // 16: fsd_universe.Email.SendTicketReply:input_type -> fsd_universe.TicketReply
-	17, // 17: fsd_universe.Email.SendWelcome:input_type -> fsd_universe.Welcome
-	18, // 18: fsd_universe.Email.SendEmailChange:input_type -> fsd_universe.EmailChange
-	20, // 19: fsd_universe.Email.VerifyEmailCode:input_type -> fsd_universe.VerifyCode
-	22, // 20: fsd_universe.Email.RemoveEmailCode:input_type -> fsd_universe.RemoveVerifyCode
-	19, // 21: fsd_universe.Email.SendActivityAtcJoin:output_type -> fsd_universe.SendResponse
-	19, // 22: fsd_universe.Email.SendActivityAtcLeave:output_type -> fsd_universe.SendResponse
-	19, // 23: fsd_universe.Email.SendActivityPilotJoin:output_type -> fsd_universe.SendResponse
-	19, // 24: fsd_universe.Email.SendActivityPilotLeave:output_type -> fsd_universe.SendResponse
-	19, // 25: fsd_universe.Email.SendApplicationPassed:output_type -> fsd_universe.SendResponse
-	19, // 26: fsd_universe.Email.SendApplicationProcessing:output_type -> fsd_universe.SendResponse
-	19, // 27: fsd_universe.Email.SendApplicationRejected:output_type -> fsd_universe.SendResponse
-	19, // 28: fsd_universe.Email.SendAtcRatingChange:output_type -> fsd_universe.SendResponse
-	19, // 29: fsd_universe.Email.SendBanned:output_type -> fsd_universe.SendResponse
-	19, // 30: fsd_universe.Email.SendUnbanned:output_type -> fsd_universe.SendResponse
-	19, // 31: fsd_universe.Email.SendInstructorChange:output_type -> fsd_universe.SendResponse
-	19, // 32: fsd_universe.Email.SendKickedFromServer:output_type -> fsd_universe.SendResponse
-	19, // 33: fsd_universe.Email.SendPasswordChange:output_type -> fsd_universe.SendResponse
-	19, // 34: fsd_universe.Email.SendPasswordReset:output_type -> fsd_universe.SendResponse
-	19, // 35: fsd_universe.Email.SendPermissionChange:output_type -> fsd_universe.SendResponse
-	19, // 36: fsd_universe.Email.SendRoleChange:output_type -> fsd_universe.SendResponse
-	19, // 37: fsd_universe.Email.SendTicketReply:output_type -> fsd_universe.SendResponse
-	19, // 38: fsd_universe.Email.SendWelcome:output_type -> fsd_universe.SendResponse
-	19, // 39: fsd_universe.Email.SendEmailChange:output_type -> fsd_universe.SendResponse
-	21, // 40: fsd_universe.Email.VerifyEmailCode:output_type -> fsd_universe.VerifyResponse
-	23, // 41: fsd_universe.Email.RemoveEmailCode:output_type -> fsd_universe.RemoveVerifyCodeResponse
-	21, // [21:42] is the sub-list for method output_type
-	0,  // [0:21] is the sub-list for method input_type
-	0,  // [0:0] is the sub-list for extension type_name
-	0,  // [0:0] is the sub-list for extension extendee
-	0,  // [0:0] is the sub-list for field type_name
+	41, // 0: fsd_universe.ActivityAtcJoin.activityTimeAt:type_name -> google.protobuf.Timestamp
+	41, // 1: fsd_universe.ActivityPilotJoin.activityTimeAt:type_name -> google.protobuf.Timestamp
+	41, // 2: fsd_universe.ApplicationProcessing.timeAt:type_name -> google.protobuf.Timestamp
+	41, // 3: fsd_universe.Banned.timeAt:type_name -> google.protobuf.Timestamp
+	41, // 4: fsd_universe.KickedFromServer.timeAt:type_name -> google.protobuf.Timestamp
+	41, // 5: fsd_universe.PasswordChange.timeAt:type_name -> google.protobuf.Timestamp
+	41, // 6: fsd_universe.PasswordReset.timeAt:type_name -> google.protobuf.Timestamp
+	41, // 7: fsd_universe.EmailChange.timeAt:type_name -> google.protobuf.Timestamp
+	0,  // 8: fsd_universe.MessageStatus.state:type_name -> fsd_universe.MessageState
+	40, // 9: fsd_universe.CapturedEmail.headers:type_name -> fsd_universe.CapturedEmail.HeadersEntry
+	38, // 10: fsd_universe.ListCapturedEmailsResponse.emails:type_name -> fsd_universe.CapturedEmail
+	1,  // 11: fsd_universe.Email.SendActivityAtcJoin:input_type -> fsd_universe.ActivityAtcJoin
+	2,  // 12: fsd_universe.Email.SendActivityAtcLeave:input_type -> fsd_universe.ActivityAtcLeave
+	3,  // 13: fsd_universe.Email.SendActivityPilotJoin:input_type -> fsd_universe.ActivityPilotJoin
+	4,  // 14: fsd_universe.Email.SendActivityPilotLeave:input_type -> fsd_universe.ActivityPilotLeave
+	5,  // 15: fsd_universe.Email.SendApplicationPassed:input_type -> fsd_universe.ApplicationPassed
+	6,  // 16: fsd_universe.Email.SendApplicationProcessing:input_type -> fsd_universe.ApplicationProcessing
+	7,  // 17: fsd_universe.Email.SendApplicationRejected:input_type -> fsd_universe.ApplicationRejected
+	8,  // 18: fsd_universe.Email.SendAtcRatingChange:input_type -> fsd_universe.AtcRatingChange
+	9,  // 19: fsd_universe.Email.SendBanned:input_type -> fsd_universe.Banned
+	10, // 20: fsd_universe.Email.SendUnbanned:input_type -> fsd_universe.Unbanned
+	11, // 21: fsd_universe.Email.SendInstructorChange:input_type -> fsd_universe.InstructorChange
+	12, // 22: fsd_universe.Email.SendKickedFromServer:input_type -> fsd_universe.KickedFromServer
+	13, // 23: fsd_universe.Email.SendPasswordChange:input_type -> fsd_universe.PasswordChange
+	14, // 24: fsd_universe.Email.SendPasswordReset:input_type -> fsd_universe.PasswordReset
+	15, // 25: fsd_universe.Email.SendPermissionChange:input_type -> fsd_universe.PermissionChange
+	16, // 26: fsd_universe.Email.SendRoleChange:input_type -> fsd_universe.RoleChange
+	17, // 27: fsd_universe.Email.SendTicketReply:input_type -> fsd_universe.TicketReply
+	18, // 28: fsd_universe.Email.SendWelcome:input_type -> fsd_universe.Welcome
+	19, // 29: fsd_universe.Email.SendEmailChange:input_type -> fsd_universe.EmailChange
+	23, // 30: fsd_universe.Email.VerifyEmailCode:input_type -> fsd_universe.VerifyCode
+	25, // 31: fsd_universe.Email.RemoveEmailCode:input_type -> fsd_universe.RemoveVerifyCode
+	27, // 32: fsd_universe.Email.StartEmailChange:input_type -> fsd_universe.StartEmailChangeRequest
+	29, // 33: fsd_universe.Email.ConfirmEmailChange:input_type -> fsd_universe.ConfirmEmailChangeRequest
+	31, // 34: fsd_universe.Email.CancelEmailChange:input_type -> fsd_universe.CancelEmailChangeRequest
+	33, // 35: fsd_universe.Email.IssueResetToken:input_type -> fsd_universe.IssueResetTokenRequest
+	35, // 36: fsd_universe.Email.ValidateResetToken:input_type -> fsd_universe.ResetTokenRequest
+	35, // 37: fsd_universe.Email.ConsumeResetToken:input_type -> fsd_universe.ResetTokenRequest
+	21, // 38: fsd_universe.Email.GetMessageStatus:input_type -> fsd_universe.MessageStatusRequest
+	21, // 39: fsd_universe.Email.WatchMessageStatus:input_type -> fsd_universe.MessageStatusRequest
+	37, // 40: fsd_universe.Email.ListCapturedEmails:input_type -> fsd_universe.ListCapturedEmailsRequest
+	20, // 41: fsd_universe.Email.SendActivityAtcJoin:output_type -> fsd_universe.SendResponse
+	20, // 42: fsd_universe.Email.SendActivityAtcLeave:output_type -> fsd_universe.SendResponse
+	20, // 43: fsd_universe.Email.SendActivityPilotJoin:output_type -> fsd_universe.SendResponse
+	20, // 44: fsd_universe.Email.SendActivityPilotLeave:output_type -> fsd_universe.SendResponse
+	20, // 45: fsd_universe.Email.SendApplicationPassed:output_type -> fsd_universe.SendResponse
+	20, // 46: fsd_universe.Email.SendApplicationProcessing:output_type -> fsd_universe.SendResponse
+	20, // 47: fsd_universe.Email.SendApplicationRejected:output_type -> fsd_universe.SendResponse
+	20, // 48: fsd_universe.Email.SendAtcRatingChange:output_type -> fsd_universe.SendResponse
+	20, // 49: fsd_universe.Email.SendBanned:output_type -> fsd_universe.SendResponse
+	20, // 50: fsd_universe.Email.SendUnbanned:output_type -> fsd_universe.SendResponse
+	20, // 51: fsd_universe.Email.SendInstructorChange:output_type -> fsd_universe.SendResponse
+	20, // 52: fsd_universe.Email.SendKickedFromServer:output_type -> fsd_universe.SendResponse
+	20, // 53: fsd_universe.Email.SendPasswordChange:output_type -> fsd_universe.SendResponse
+	20, // 54: fsd_universe.Email.SendPasswordReset:output_type -> fsd_universe.SendResponse
+	20, // 55: fsd_universe.Email.SendPermissionChange:output_type -> fsd_universe.SendResponse
+	20, // 56: fsd_universe.Email.SendRoleChange:output_type -> fsd_universe.SendResponse
+	20, // 57: fsd_universe.Email.SendTicketReply:output_type -> fsd_universe.SendResponse
+	20, // 58: fsd_universe.Email.SendWelcome:output_type -> fsd_universe.SendResponse
+	20, // 59: fsd_universe.Email.SendEmailChange:output_type -> fsd_universe.SendResponse
+	24, // 60: fsd_universe.Email.VerifyEmailCode:output_type -> fsd_universe.VerifyResponse
+	26, // 61: fsd_universe.Email.RemoveEmailCode:output_type -> fsd_universe.RemoveVerifyCodeResponse
+	28, // 62: fsd_universe.Email.StartEmailChange:output_type -> fsd_universe.StartEmailChangeResponse
+	30, // 63: fsd_universe.Email.ConfirmEmailChange:output_type -> fsd_universe.ConfirmEmailChangeResponse
+	32, // 64: fsd_universe.Email.CancelEmailChange:output_type -> fsd_universe.CancelEmailChangeResponse
+	34, // 65: fsd_universe.Email.IssueResetToken:output_type -> fsd_universe.IssueResetTokenResponse
+	36, // 66: fsd_universe.Email.ValidateResetToken:output_type -> fsd_universe.ResetTokenResponse
+	36, // 67: fsd_universe.Email.ConsumeResetToken:output_type -> fsd_universe.ResetTokenResponse
+	22, // 68: fsd_universe.Email.GetMessageStatus:output_type -> fsd_universe.MessageStatus
+	22, // 69: fsd_universe.Email.WatchMessageStatus:output_type -> fsd_universe.MessageStatus
+	39, // 70: fsd_universe.Email.ListCapturedEmails:output_type -> fsd_universe.ListCapturedEmailsResponse
+	41, // [41:71] is the sub-list for method output_type
+	11, // [11:41] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_email_proto_init() }
@@ -1930,18 +4085,38 @@ func file_email_proto_init() {
 	if File_email_proto != nil {
 		return
 	}
+	file_email_proto_msgTypes[0].OneofWrappers = []any{}
+	file_email_proto_msgTypes[1].OneofWrappers = []any{}
+	file_email_proto_msgTypes[2].OneofWrappers = []any{}
+	file_email_proto_msgTypes[3].OneofWrappers = []any{}
+	file_email_proto_msgTypes[4].OneofWrappers = []any{}
+	file_email_proto_msgTypes[5].OneofWrappers = []any{}
+	file_email_proto_msgTypes[6].OneofWrappers = []any{}
+	file_email_proto_msgTypes[7].OneofWrappers = []any{}
+	file_email_proto_msgTypes[8].OneofWrappers = []any{}
+	file_email_proto_msgTypes[9].OneofWrappers = []any{}
+	file_email_proto_msgTypes[10].OneofWrappers = []any{}
+	file_email_proto_msgTypes[11].OneofWrappers = []any{}
+	file_email_proto_msgTypes[12].OneofWrappers = []any{}
+	file_email_proto_msgTypes[13].OneofWrappers = []any{}
+	file_email_proto_msgTypes[14].OneofWrappers = []any{}
+	file_email_proto_msgTypes[15].OneofWrappers = []any{}
+	file_email_proto_msgTypes[16].OneofWrappers = []any{}
+	file_email_proto_msgTypes[17].OneofWrappers = []any{}
+	file_email_proto_msgTypes[18].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_email_proto_rawDesc), len(file_email_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   24,
+			NumEnums:      1,
+			NumMessages:   40,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_email_proto_goTypes,
 		DependencyIndexes: file_email_proto_depIdxs,
+		EnumInfos:         file_email_proto_enumTypes,
 		MessageInfos:      file_email_proto_msgTypes,
 	}.Build()
 	File_email_proto = out.File
@@ -43,6 +43,15 @@ const (
 	Email_SendEmailChange_FullMethodName           = "/fsd_universe.Email/SendEmailChange"
 	Email_VerifyEmailCode_FullMethodName           = "/fsd_universe.Email/VerifyEmailCode"
 	Email_RemoveEmailCode_FullMethodName           = "/fsd_universe.Email/RemoveEmailCode"
+	Email_StartEmailChange_FullMethodName          = "/fsd_universe.Email/StartEmailChange"
+	Email_ConfirmEmailChange_FullMethodName        = "/fsd_universe.Email/ConfirmEmailChange"
+	Email_CancelEmailChange_FullMethodName         = "/fsd_universe.Email/CancelEmailChange"
+	Email_IssueResetToken_FullMethodName           = "/fsd_universe.Email/IssueResetToken"
+	Email_ValidateResetToken_FullMethodName        = "/fsd_universe.Email/ValidateResetToken"
+	Email_ConsumeResetToken_FullMethodName         = "/fsd_universe.Email/ConsumeResetToken"
+	Email_GetMessageStatus_FullMethodName          = "/fsd_universe.Email/GetMessageStatus"
+	Email_WatchMessageStatus_FullMethodName        = "/fsd_universe.Email/WatchMessageStatus"
+	Email_ListCapturedEmails_FullMethodName        = "/fsd_universe.Email/ListCapturedEmails"
 )
 
 // EmailClient is the client API for Email service.
@@ -70,6 +79,15 @@ type EmailClient interface {
 	SendEmailChange(ctx context.Context, in *EmailChange, opts ...grpc.CallOption) (*SendResponse, error)
 	VerifyEmailCode(ctx context.Context, in *VerifyCode, opts ...grpc.CallOption) (*VerifyResponse, error)
 	RemoveEmailCode(ctx context.Context, in *RemoveVerifyCode, opts ...grpc.CallOption) (*RemoveVerifyCodeResponse, error)
+	StartEmailChange(ctx context.Context, in *StartEmailChangeRequest, opts ...grpc.CallOption) (*StartEmailChangeResponse, error)
+	ConfirmEmailChange(ctx context.Context, in *ConfirmEmailChangeRequest, opts ...grpc.CallOption) (*ConfirmEmailChangeResponse, error)
+	CancelEmailChange(ctx context.Context, in *CancelEmailChangeRequest, opts ...grpc.CallOption) (*CancelEmailChangeResponse, error)
+	IssueResetToken(ctx context.Context, in *IssueResetTokenRequest, opts ...grpc.CallOption) (*IssueResetTokenResponse, error)
+	ValidateResetToken(ctx context.Context, in *ResetTokenRequest, opts ...grpc.CallOption) (*ResetTokenResponse, error)
+	ConsumeResetToken(ctx context.Context, in *ResetTokenRequest, opts ...grpc.CallOption) (*ResetTokenResponse, error)
+	GetMessageStatus(ctx context.Context, in *MessageStatusRequest, opts ...grpc.CallOption) (*MessageStatus, error)
+	WatchMessageStatus(ctx context.Context, in *MessageStatusRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MessageStatus], error)
+	ListCapturedEmails(ctx context.Context, in *ListCapturedEmailsRequest, opts ...grpc.CallOption) (*ListCapturedEmailsResponse, error)
 }
 
 type emailClient struct {
@@ -290,6 +308,105 @@ func (c *emailClient) RemoveEmailCode(ctx context.Context, in *RemoveVerifyCode,
 	return out, nil
 }
 
+func (c *emailClient) StartEmailChange(ctx context.Context, in *StartEmailChangeRequest, opts ...grpc.CallOption) (*StartEmailChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartEmailChangeResponse)
+	err := c.cc.Invoke(ctx, Email_StartEmailChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emailClient) ConfirmEmailChange(ctx context.Context, in *ConfirmEmailChangeRequest, opts ...grpc.CallOption) (*ConfirmEmailChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmEmailChangeResponse)
+	err := c.cc.Invoke(ctx, Email_ConfirmEmailChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emailClient) CancelEmailChange(ctx context.Context, in *CancelEmailChangeRequest, opts ...grpc.CallOption) (*CancelEmailChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelEmailChangeResponse)
+	err := c.cc.Invoke(ctx, Email_CancelEmailChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emailClient) IssueResetToken(ctx context.Context, in *IssueResetTokenRequest, opts ...grpc.CallOption) (*IssueResetTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IssueResetTokenResponse)
+	err := c.cc.Invoke(ctx, Email_IssueResetToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emailClient) ValidateResetToken(ctx context.Context, in *ResetTokenRequest, opts ...grpc.CallOption) (*ResetTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResetTokenResponse)
+	err := c.cc.Invoke(ctx, Email_ValidateResetToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emailClient) ConsumeResetToken(ctx context.Context, in *ResetTokenRequest, opts ...grpc.CallOption) (*ResetTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResetTokenResponse)
+	err := c.cc.Invoke(ctx, Email_ConsumeResetToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emailClient) GetMessageStatus(ctx context.Context, in *MessageStatusRequest, opts ...grpc.CallOption) (*MessageStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MessageStatus)
+	err := c.cc.Invoke(ctx, Email_GetMessageStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emailClient) WatchMessageStatus(ctx context.Context, in *MessageStatusRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MessageStatus], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Email_ServiceDesc.Streams[0], Email_WatchMessageStatus_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[MessageStatusRequest, MessageStatus]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Email_WatchMessageStatusClient = grpc.ServerStreamingClient[MessageStatus]
+
+func (c *emailClient) ListCapturedEmails(ctx context.Context, in *ListCapturedEmailsRequest, opts ...grpc.CallOption) (*ListCapturedEmailsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCapturedEmailsResponse)
+	err := c.cc.Invoke(ctx, Email_ListCapturedEmails_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // EmailServer is the server API for Email service.
 // All implementations must embed UnimplementedEmailServer
 // for forward compatibility.
@@ -315,6 +432,15 @@ type EmailServer interface {
 	SendEmailChange(context.Context, *EmailChange) (*SendResponse, error)
 	VerifyEmailCode(context.Context, *VerifyCode) (*VerifyResponse, error)
 	RemoveEmailCode(context.Context, *RemoveVerifyCode) (*RemoveVerifyCodeResponse, error)
+	StartEmailChange(context.Context, *StartEmailChangeRequest) (*StartEmailChangeResponse, error)
+	ConfirmEmailChange(context.Context, *ConfirmEmailChangeRequest) (*ConfirmEmailChangeResponse, error)
+	CancelEmailChange(context.Context, *CancelEmailChangeRequest) (*CancelEmailChangeResponse, error)
+	IssueResetToken(context.Context, *IssueResetTokenRequest) (*IssueResetTokenResponse, error)
+	ValidateResetToken(context.Context, *ResetTokenRequest) (*ResetTokenResponse, error)
+	ConsumeResetToken(context.Context, *ResetTokenRequest) (*ResetTokenResponse, error)
+	GetMessageStatus(context.Context, *MessageStatusRequest) (*MessageStatus, error)
+	WatchMessageStatus(*MessageStatusRequest, grpc.ServerStreamingServer[MessageStatus]) error
+	ListCapturedEmails(context.Context, *ListCapturedEmailsRequest) (*ListCapturedEmailsResponse, error)
 	mustEmbedUnimplementedEmailServer()
 }
 
@@ -388,6 +514,33 @@ func (UnimplementedEmailServer) VerifyEmailCode(context.Context, *VerifyCode) (*
 func (UnimplementedEmailServer) RemoveEmailCode(context.Context, *RemoveVerifyCode) (*RemoveVerifyCodeResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method RemoveEmailCode not implemented")
 }
+func (UnimplementedEmailServer) StartEmailChange(context.Context, *StartEmailChangeRequest) (*StartEmailChangeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartEmailChange not implemented")
+}
+func (UnimplementedEmailServer) ConfirmEmailChange(context.Context, *ConfirmEmailChangeRequest) (*ConfirmEmailChangeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ConfirmEmailChange not implemented")
+}
+func (UnimplementedEmailServer) CancelEmailChange(context.Context, *CancelEmailChangeRequest) (*CancelEmailChangeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelEmailChange not implemented")
+}
+func (UnimplementedEmailServer) IssueResetToken(context.Context, *IssueResetTokenRequest) (*IssueResetTokenResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method IssueResetToken not implemented")
+}
+func (UnimplementedEmailServer) ValidateResetToken(context.Context, *ResetTokenRequest) (*ResetTokenResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ValidateResetToken not implemented")
+}
+func (UnimplementedEmailServer) ConsumeResetToken(context.Context, *ResetTokenRequest) (*ResetTokenResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ConsumeResetToken not implemented")
+}
+func (UnimplementedEmailServer) GetMessageStatus(context.Context, *MessageStatusRequest) (*MessageStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMessageStatus not implemented")
+}
+func (UnimplementedEmailServer) WatchMessageStatus(*MessageStatusRequest, grpc.ServerStreamingServer[MessageStatus]) error {
+	return status.Error(codes.Unimplemented, "method WatchMessageStatus not implemented")
+}
+func (UnimplementedEmailServer) ListCapturedEmails(context.Context, *ListCapturedEmailsRequest) (*ListCapturedEmailsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCapturedEmails not implemented")
+}
 func (UnimplementedEmailServer) mustEmbedUnimplementedEmailServer() {}
 func (UnimplementedEmailServer) testEmbeddedByValue()               {}
 
@@ -787,6 +940,161 @@ func _Email_RemoveEmailCode_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Email_StartEmailChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartEmailChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailServer).StartEmailChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Email_StartEmailChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailServer).StartEmailChange(ctx, req.(*StartEmailChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Email_ConfirmEmailChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmEmailChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailServer).ConfirmEmailChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Email_ConfirmEmailChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailServer).ConfirmEmailChange(ctx, req.(*ConfirmEmailChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Email_CancelEmailChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelEmailChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailServer).CancelEmailChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Email_CancelEmailChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailServer).CancelEmailChange(ctx, req.(*CancelEmailChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Email_IssueResetToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueResetTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailServer).IssueResetToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Email_IssueResetToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailServer).IssueResetToken(ctx, req.(*IssueResetTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Email_ValidateResetToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailServer).ValidateResetToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Email_ValidateResetToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailServer).ValidateResetToken(ctx, req.(*ResetTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Email_ConsumeResetToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailServer).ConsumeResetToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Email_ConsumeResetToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailServer).ConsumeResetToken(ctx, req.(*ResetTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Email_GetMessageStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MessageStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailServer).GetMessageStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Email_GetMessageStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailServer).GetMessageStatus(ctx, req.(*MessageStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Email_WatchMessageStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MessageStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EmailServer).WatchMessageStatus(m, &grpc.GenericServerStream[MessageStatusRequest, MessageStatus]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Email_WatchMessageStatusServer = grpc.ServerStreamingServer[MessageStatus]
+
+func _Email_ListCapturedEmails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCapturedEmailsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailServer).ListCapturedEmails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Email_ListCapturedEmails_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailServer).ListCapturedEmails(ctx, req.(*ListCapturedEmailsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Email_ServiceDesc is the grpc.ServiceDesc for Email service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -878,7 +1186,45 @@ var Email_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveEmailCode",
 			Handler:    _Email_RemoveEmailCode_Handler,
 		},
+		{
+			MethodName: "StartEmailChange",
+			Handler:    _Email_StartEmailChange_Handler,
+		},
+		{
+			MethodName: "ConfirmEmailChange",
+			Handler:    _Email_ConfirmEmailChange_Handler,
+		},
+		{
+			MethodName: "CancelEmailChange",
+			Handler:    _Email_CancelEmailChange_Handler,
+		},
+		{
+			MethodName: "IssueResetToken",
+			Handler:    _Email_IssueResetToken_Handler,
+		},
+		{
+			MethodName: "ValidateResetToken",
+			Handler:    _Email_ValidateResetToken_Handler,
+		},
+		{
+			MethodName: "ConsumeResetToken",
+			Handler:    _Email_ConsumeResetToken_Handler,
+		},
+		{
+			MethodName: "GetMessageStatus",
+			Handler:    _Email_GetMessageStatus_Handler,
+		},
+		{
+			MethodName: "ListCapturedEmails",
+			Handler:    _Email_ListCapturedEmails_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchMessageStatus",
+			Handler:       _Email_WatchMessageStatus_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "email.proto",
 }
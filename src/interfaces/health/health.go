@@ -0,0 +1,31 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package health
+package health
+
+import (
+	"context"
+	"time"
+)
+
+type CheckResult struct {
+	Healthy bool   `json:"healthy"`
+	Message string `json:"message,omitempty"`
+}
+
+type Report struct {
+	Healthy      bool                    `json:"healthy"`
+	Time         time.Time               `json:"time"`
+	QueueBacklog int                     `json:"queue_backlog"`
+	Checks       map[string]*CheckResult `json:"checks"`
+}
+
+type CheckerInterface interface {
+	Check(ctx context.Context) *Report
+}
+
+type MonitorInterface interface {
+	// Report 返回最近一次检查的结果
+	Report() *Report
+}
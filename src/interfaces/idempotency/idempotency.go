@@ -0,0 +1,21 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package idempotency
+package idempotency
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrKeyReused = errors.New("idempotency key reused with different request")
+
+// StoreInterface 在有效期内为相同的幂等键保存首次请求的结果
+type StoreInterface interface {
+	// Do 执行fn并返回其结果, 有效期内相同key的重复请求直接返回首次结果而不再执行fn
+	// 并发的重复请求会等待首次请求完成; fn返回false时不保存结果, 调用方可以使用相同key重试
+	// fingerprint用于识别请求内容, 相同key携带不同内容时返回 ErrKeyReused
+	// 等待首次请求完成期间ctx结束时返回ctx的错误
+	Do(ctx context.Context, key string, fingerprint string, fn func() (any, bool)) (result any, replayed bool, err error)
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package metrics
+package metrics
+
+import (
+	"net/http"
+	"time"
+)
+
+// 邮件发送结果
+const (
+	OutcomeSuccess    = "success"
+	OutcomeFailed     = "failed"
+	OutcomeSuppressed = "suppressed"
+	OutcomeDisabled   = "disabled"
+	OutcomeInvalid    = "invalid"
+	OutcomeOptedOut   = "opted_out"
+	OutcomeDigested   = "digested"
+)
+
+// 验证码校验结果
+const (
+	VerifySuccess = "success"
+	VerifyExpired = "expired"
+	VerifyInvalid = "invalid"
+)
+
+// 验证码发送被拒绝的原因
+const (
+	RejectCooldown         = "cooldown"
+	RejectIpQuota          = "ip_quota"
+	RejectFingerprintQuota = "fingerprint_quota"
+	RejectGlobalQuota      = "global_quota"
+)
+
+type RecorderInterface interface {
+	EmailSent(emailType string, outcome string)
+	RenderDuration(emailType string, duration time.Duration)
+	SmtpLatency(duration time.Duration, success bool)
+	// LaneWait 记录邮件在优先级通道中等待发送位置的时间
+	LaneWait(priority string, duration time.Duration)
+	CodeVerified(result string)
+	CodeRejected(reason string)
+	// RegisterSize 注册一个在采集时读取的容量指标, 如缓存条目数与队列长度
+	RegisterSize(name string, size func() int)
+	// Handler 返回Prometheus指标的HTTP处理器
+	Handler() http.Handler
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package render
+package render
+
+import (
+	"fmt"
+	"html/template"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FuncMap 注册到所有邮件模板上的函数, siteUrl为站点根地址, 用于urlFor生成完整链接
+//
+//	时间:
+//	  date "2006-01-02" .Time    按收件人时区以Go时间格式显示时间
+//	  local .Time                收件人时区的时间
+//	  utc .Time                  UTC时间
+//	  relative .Time             距现在的相对时间, 例如"3小时后"、"2天前"
+//	  duration "90m"             时长, 例如"1小时30分钟", 参数可以是time.Duration、时长文本或秒数
+//	文本:
+//	  .Reason | default "无"     参数为空时使用默认值
+//	  .Reply | truncate 100      按字符截断, 超出部分以省略号代替
+//	  upper / lower              大小写转换
+//	  plural .Count "item" "items"  按数量选择单复数形式
+//	  markdown .Reply            将markdown渲染为经过清理的HTML
+//	链接:
+//	  urlFor "/tickets" "id" .TicketId  基于站点根地址生成链接, 之后的参数为成对的查询参数
+//	航空:
+//	  callsign .Callsign         去掉首尾空白并转为大写
+//	  frequency .Frequency       频率保留三位小数并附带单位, 例如"118.500MHz"
+func FuncMap(siteUrl string) template.FuncMap {
+	return template.FuncMap{
+		"date":      Date,
+		"local":     func(t *Time) string { return t.Local() },
+		"utc":       func(t *Time) string { return t.UTC() },
+		"relative":  Relative,
+		"duration":  Duration,
+		"default":   Default,
+		"truncate":  Truncate,
+		"upper":     strings.ToUpper,
+		"lower":     strings.ToLower,
+		"plural":    Plural,
+		"markdown":  Markdown,
+		"urlFor":    func(path string, query ...string) (string, error) { return UrlFor(siteUrl, path, query...) },
+		"callsign":  Callsign,
+		"frequency": Frequency,
+	}
+}
+
+// Date 按收件人时区格式化时间, 无法解析的时间原样返回
+func Date(layout string, t *Time) string {
+	if !t.Valid() {
+		return t.raw()
+	}
+	location := t.Location
+	if location == nil {
+		location = time.UTC
+	}
+	return t.At.In(location).Format(layout)
+}
+
+// Duration 格式化时长, 精确到分钟, 不足一分钟时显示秒
+func Duration(value any) (string, error) {
+	var duration time.Duration
+	switch v := value.(type) {
+	case time.Duration:
+		duration = v
+	case string:
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return "", err
+		}
+		duration = parsed
+	case int:
+		duration = time.Duration(v) * time.Second
+	case int64:
+		duration = time.Duration(v) * time.Second
+	default:
+		return "", fmt.Errorf("unsupported duration type %T", value)
+	}
+	if duration < 0 {
+		duration = -duration
+	}
+	if duration < time.Minute {
+		return fmt.Sprintf("%d秒", int(duration.Seconds())), nil
+	}
+	var sb strings.Builder
+	days := int(duration / (24 * time.Hour))
+	hours := int(duration % (24 * time.Hour) / time.Hour)
+	minutes := int(duration % time.Hour / time.Minute)
+	if days > 0 {
+		_, _ = fmt.Fprintf(&sb, "%d天", days)
+	}
+	if hours > 0 {
+		_, _ = fmt.Fprintf(&sb, "%d小时", hours)
+	}
+	if minutes > 0 {
+		_, _ = fmt.Fprintf(&sb, "%d分钟", minutes)
+	}
+	return sb.String(), nil
+}
+
+// Relative 描述时间与当前时间的相对关系, 只保留最大的单位
+func Relative(t *Time) string {
+	if !t.Valid() {
+		return ""
+	}
+	diff := time.Until(t.At)
+	suffix := "后"
+	if diff < 0 {
+		diff = -diff
+		suffix = "前"
+	}
+	switch {
+	case diff < time.Minute:
+		return "刚刚"
+	case diff < time.Hour:
+		return fmt.Sprintf("%d分钟%s", int(diff/time.Minute), suffix)
+	case diff < 24*time.Hour:
+		return fmt.Sprintf("%d小时%s", int(diff/time.Hour), suffix)
+	default:
+		return fmt.Sprintf("%d天%s", int(diff/(24*time.Hour)), suffix)
+	}
+}
+
+// Default 参数为零值或空白文本时返回默认值
+func Default(fallback any, value any) any {
+	if value == nil {
+		return fallback
+	}
+	if s, ok := value.(string); ok {
+		if strings.TrimSpace(s) == "" {
+			return fallback
+		}
+		return s
+	}
+	if reflect.ValueOf(value).IsZero() {
+		return fallback
+	}
+	return value
+}
+
+// Truncate 按字符截断文本, 超出部分以省略号代替
+func Truncate(length int, s string) string {
+	runes := []rune(s)
+	if length <= 0 || len(runes) <= length {
+		return s
+	}
+	return string(runes[:length]) + "…"
+}
+
+func Plural(count int, singular string, plural string) string {
+	if count == 1 {
+		return singular
+	}
+	return plural
+}
+
+// UrlFor 拼接站点根地址与路径, query为成对的查询参数, 路径中已有的查询参数会保留
+func UrlFor(siteUrl string, path string, query ...string) (string, error) {
+	if len(query)%2 != 0 {
+		return "", fmt.Errorf("urlFor query must be key value pairs, got %d arguments", len(query))
+	}
+	path, rawQuery, _ := strings.Cut(path, "?")
+	link := path
+	if siteUrl != "" {
+		joined, err := url.JoinPath(siteUrl, path)
+		if err != nil {
+			return "", err
+		}
+		link = joined
+	}
+	if rawQuery != "" {
+		link += "?" + rawQuery
+	}
+	if len(query) == 0 {
+		return link, nil
+	}
+	values := url.Values{}
+	for i := 0; i < len(query); i += 2 {
+		values.Add(query[i], query[i+1])
+	}
+	separator := "?"
+	if rawQuery != "" {
+		separator = "&"
+	}
+	return link + separator + values.Encode(), nil
+}
+
+func Callsign(callsign string) string {
+	return strings.ToUpper(strings.TrimSpace(callsign))
+}
+
+// Frequency 将频率格式化为三位小数并附带MHz, 无法解析时原样返回
+func Frequency(frequency string) string {
+	frequency = strings.TrimSpace(frequency)
+	value, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(frequency, "MHz")), 64)
+	if err != nil {
+		return frequency
+	}
+	return strconv.FormatFloat(value, 'f', 3, 64) + "MHz"
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package render
+package render
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDate(t *testing.T) {
+	shanghai := time.FixedZone("CST", 8*3600)
+	at := time.Date(2026, 1, 1, 16, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		layout string
+		time   *Time
+		want   string
+	}{
+		{"utc by default", "2006-01-02 15:04", NewTime(at), "2026-01-01 16:30"},
+		{"recipient location", "2006-01-02 15:04", &Time{At: at, Location: shanghai}, "2026-01-02 00:30"},
+		{"raw text", "2006-01-02", &Time{Raw: "下周一"}, "下周一"},
+		{"nil time", "2006-01-02", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Date(tt.layout, tt.time); got != tt.want {
+				t.Errorf("Date() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefault(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  any
+	}{
+		{"nil", nil, "无"},
+		{"empty string", "", "无"},
+		{"blank string", "  \t", "无"},
+		{"string", "违规", "违规"},
+		{"zero int", 0, "无"},
+		{"int", 3, 3},
+		{"nil time", (*Time)(nil), "无"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Default("无", tt.value); got != tt.want {
+				t.Errorf("Default() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name   string
+		length int
+		input  string
+		want   string
+	}{
+		{"shorter", 10, "hello", "hello"},
+		{"exact", 5, "hello", "hello"},
+		{"ascii", 3, "hello", "hel…"},
+		{"multi-byte", 2, "你好世界", "你好…"},
+		{"mixed", 4, "ATC管制员", "ATC管…"},
+		{"emoji", 1, "✈️起飞", "✈…"},
+		{"non-positive length", 0, "你好", "你好"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Truncate(tt.length, tt.input); got != tt.want {
+				t.Errorf("Truncate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlural(t *testing.T) {
+	tests := []struct {
+		count int
+		want  string
+	}{
+		{0, "items"},
+		{1, "item"},
+		{2, "items"},
+		{-1, "items"},
+	}
+	for _, tt := range tests {
+		if got := Plural(tt.count, "item", "items"); got != tt.want {
+			t.Errorf("Plural(%d) = %q, want %q", tt.count, got, tt.want)
+		}
+	}
+}
+
+func TestUrlFor(t *testing.T) {
+	tests := []struct {
+		name    string
+		siteUrl string
+		path    string
+		query   []string
+		want    string
+		wantErr bool
+	}{
+		{"path only", "https://fsd.example.com", "/tickets", nil, "https://fsd.example.com/tickets", false},
+		{"site url with path", "https://example.com/fsd/", "tickets", nil, "https://example.com/fsd/tickets", false},
+		{"query", "https://fsd.example.com", "/tickets", []string{"id", "42"}, "https://fsd.example.com/tickets?id=42", false},
+		{"query escaped", "https://fsd.example.com", "/search", []string{"q", "a b&c"}, "https://fsd.example.com/search?q=a+b%26c", false},
+		{"existing query", "https://fsd.example.com", "/tickets?tab=open", []string{"id", "42"}, "https://fsd.example.com/tickets?tab=open&id=42", false},
+		{"existing query only", "https://fsd.example.com", "/tickets?tab=open", nil, "https://fsd.example.com/tickets?tab=open", false},
+		{"empty site url", "", "/tickets", []string{"id", "42"}, "/tickets?id=42", false},
+		{"empty site url with existing query", "", "/tickets?tab=open", []string{"id", "42"}, "/tickets?tab=open&id=42", false},
+		{"odd arguments", "https://fsd.example.com", "/tickets", []string{"id"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UrlFor(tt.siteUrl, tt.path, tt.query...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UrlFor() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("UrlFor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCallsign(t *testing.T) {
+	tests := []struct{ input, want string }{
+		{"cca1234", "CCA1234"},
+		{"  ZSSS_APP \n", "ZSSS_APP"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Callsign(tt.input); got != tt.want {
+			t.Errorf("Callsign(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestFrequency(t *testing.T) {
+	tests := []struct{ input, want string }{
+		{"118.5", "118.500MHz"},
+		{"121.95", "121.950MHz"},
+		{"118.500MHz", "118.500MHz"},
+		{" 124.35 MHz ", "124.350MHz"},
+		{"122.8125", "122.812MHz"},
+		{"unicom MHz", "unicom MHz"},
+		{"待定", "待定"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Frequency(tt.input); got != tt.want {
+			t.Errorf("Frequency(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    string
+		wantErr bool
+	}{
+		{"duration", 90 * time.Minute, "1小时30分钟", false},
+		{"text", "26h5m", "1天2小时5分钟", false},
+		{"seconds int", 45, "45秒", false},
+		{"seconds int64", int64(7200), "2小时", false},
+		{"negative", -3 * time.Minute, "3分钟", false},
+		{"whole days", 48 * time.Hour, "2天", false},
+		{"invalid text", "soon", "", true},
+		{"unsupported type", 1.5, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Duration(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Duration() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Duration() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRelative(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		time *Time
+		want string
+	}{
+		{"just now", NewTime(now.Add(10 * time.Second)), "刚刚"},
+		{"minutes later", NewTime(now.Add(15*time.Minute + 30*time.Second)), "15分钟后"},
+		{"hours later", NewTime(now.Add(3*time.Hour + 30*time.Second)), "3小时后"},
+		{"days ago", NewTime(now.Add(-50 * time.Hour)), "2天前"},
+		{"minutes ago", NewTime(now.Add(-5*time.Minute - 30*time.Second)), "5分钟前"},
+		{"raw text", &Time{Raw: "下周一"}, ""},
+		{"nil", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Relative(tt.time); got != tt.want {
+				t.Errorf("Relative() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package render
+package render
+
+import (
+	"bytes"
+	"html/template"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+var (
+	markdownRenderer = goldmark.New(goldmark.WithExtensions(extension.GFM))
+	// markdownPolicy 只保留用户内容中常见的排版标签, 去掉脚本、样式与事件属性
+	markdownPolicy = bluemonday.UGCPolicy()
+)
+
+// Markdown 将markdown渲染为经过清理的HTML, 渲染失败时按纯文本转义输出
+func Markdown(source string) template.HTML {
+	var buf bytes.Buffer
+	if err := markdownRenderer.Convert([]byte(source), &buf); err != nil {
+		return template.HTML(template.HTMLEscapeString(source))
+	}
+	return template.HTML(markdownPolicy.SanitizeBytes(buf.Bytes()))
+}
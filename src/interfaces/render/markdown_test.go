@@ -0,0 +1,97 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package render
+package render
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarkdownSanitizes(t *testing.T) {
+	tests := []struct {
+		name      string
+		source    string
+		forbidden []string
+	}{
+		{"script tag", "<script>alert(1)</script>", []string{"<script", "alert(1)"}},
+		{"inline script", "text <script>alert(1)</script> text", []string{"<script"}},
+		{"javascript link", "[点击](javascript:alert(1))", []string{"javascript:", "href"}},
+		{"mixed case javascript link", "[点击](JaVaScRiPt:alert(1))", []string{"javascript:", "JaVaScRiPt:", "href"}},
+		{"onerror attribute", `<img src=x onerror=alert(1)>`, []string{"onerror", "alert(1)"}},
+		{"event attribute on link", `<a href="https://example.com" onmouseover="steal()">link</a>`, []string{"onmouseover", "steal()"}},
+		{"raw html block", "<div onclick=\"steal()\">\n\n**内容**\n\n</div>", []string{"<div", "onclick"}},
+		{"iframe", `<iframe src="https://evil.example.com"></iframe>`, []string{"<iframe", "evil.example.com"}},
+		{"style tag", "<style>body{display:none}</style>", []string{"<style", "display:none"}},
+		{"data uri image", "![x](data:text/html;base64,PHNjcmlwdD4=)", []string{"data:text/html"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(Markdown(tt.source))
+			for _, s := range tt.forbidden {
+				if strings.Contains(got, s) {
+					t.Errorf("Markdown(%q) = %q, contains %q", tt.source, got, s)
+				}
+			}
+		})
+	}
+}
+
+func TestMarkdownKeepsFormatting(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   []string
+	}{
+		{"emphasis", "*斜体* **加粗** `代码`", []string{"<em>斜体</em>", "<strong>加粗</strong>", "<code>代码</code>"}},
+		{"list", "- 第一项\n- 第二项", []string{"<ul>", "<li>第一项</li>", "<li>第二项</li>"}},
+		{"link", "[工单](https://fsd.example.com/tickets/1)", []string{`<a href="https://fsd.example.com/tickets/1" rel="nofollow">工单</a>`}},
+		{"autolink", "<https://fsd.example.com>", []string{`<a href="https://fsd.example.com" rel="nofollow">https://fsd.example.com</a>`}},
+		{"bare url", "访问 https://fsd.example.com/a 查看", []string{`<a href="https://fsd.example.com/a" rel="nofollow">https://fsd.example.com/a</a>`}},
+		{"escaped text", "1 < 2 & 3 > 2", []string{"1 &lt; 2 &amp; 3 &gt; 2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(Markdown(tt.source))
+			for _, s := range tt.want {
+				if !strings.Contains(got, s) {
+					t.Errorf("Markdown(%q) = %q, want to contain %q", tt.source, got, s)
+				}
+			}
+		})
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{"paragraphs", "<p>第一段</p>\n<p>第二段</p>", "第一段\n\n第二段"},
+		{"line break", "第一行<br>第二行", "第一行\n第二行"},
+		{"list", "<p>变更:</p>\n<ul>\n<li>a</li>\n<li>b</li>\n</ul>", "变更:\n\n- a\n- b"},
+		{"link", `<p>查看<a href="https://fsd.example.com/t/1">工单</a></p>`, "查看工单 (https://fsd.example.com/t/1)"},
+		{"autolink", `<a href="https://fsd.example.com">https://fsd.example.com</a>`, "https://fsd.example.com"},
+		{"mailto", `<a href="mailto:support@example.com">support@example.com</a>`, "support@example.com"},
+		{"script and style", "<style>p{color:red}</style><p>内容</p><script>alert(1)</script>", "内容"},
+		{"entities", "<p>1 &lt; 2 &amp;&amp; 3 &gt; 2</p>", "1 < 2 && 3 > 2"},
+		{"indentation", "<table>\n  <tr>\n    <td>  席位  </td>\n  </tr>\n</table>", "席位"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PlainText(tt.source); got != tt.want {
+				t.Errorf("PlainText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlainTextOfMarkdown(t *testing.T) {
+	source := "**通过** 审核\n\n- 席位: ZSSS_APP\n- 说明: [细则](https://fsd.example.com/rules)\n\n<script>alert(1)</script>"
+	want := "通过 审核\n\n- 席位: ZSSS_APP\n- 说明: 细则 (https://fsd.example.com/rules)"
+	if got := PlainText(string(Markdown(source))); got != want {
+		t.Errorf("PlainText(Markdown()) = %q, want %q", got, want)
+	}
+}
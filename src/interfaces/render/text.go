@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package render
+package render
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// blockTags 结束后需要换行的标签, 列表项在开始时换行
+var blockTags = map[string]bool{
+	"p": true, "div": true, "br": true, "hr": true, "tr": true, "table": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"ul": true, "ol": true, "pre": true, "blockquote": true,
+}
+
+// PlainText 将HTML转换为纯文本, 用于邮件的text/plain部分
+// 块级标签转换为换行, 列表项前加"- ", 链接在文字后附带地址
+func PlainText(source string) string {
+	tokenizer := html.NewTokenizer(strings.NewReader(source))
+	var sb strings.Builder
+	var links []string
+	skip := 0
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return collapseBlankLines(sb.String())
+		case html.TextToken:
+			if skip == 0 {
+				sb.WriteString(string(tokenizer.Text()))
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			tag := string(name)
+			switch tag {
+			case "script", "style":
+				skip++
+			case "li":
+				// 列表项之间的换行文本已经换行, 不再重复换行以免出现空行
+				if !strings.HasSuffix(strings.TrimRight(sb.String(), " \t"), "\n") {
+					sb.WriteString("\n")
+				}
+				sb.WriteString("- ")
+			case "br", "hr":
+				sb.WriteString("\n")
+			case "a":
+				href := ""
+				for hasAttr {
+					var key, value []byte
+					key, value, hasAttr = tokenizer.TagAttr()
+					if string(key) == "href" {
+						href = string(value)
+					}
+				}
+				links = append(links, href)
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			tag := string(name)
+			switch {
+			case tag == "script" || tag == "style":
+				if skip > 0 {
+					skip--
+				}
+			case tag == "a" && len(links) > 0:
+				href := links[len(links)-1]
+				links = links[:len(links)-1]
+				// 链接文字与地址相同或为邮件地址时不重复显示
+				if href != "" && !strings.HasPrefix(href, "mailto:") && !strings.HasSuffix(sb.String(), href) {
+					sb.WriteString(" (" + href + ")")
+				}
+			case blockTags[tag]:
+				sb.WriteString("\n")
+			}
+		}
+	}
+}
+
+// collapseBlankLines 去掉每行首尾空白, 连续的空行只保留一行
+func collapseBlankLines(text string) string {
+	lines := strings.Split(text, "\n")
+	result := make([]string, 0, len(lines))
+	blank := true
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			if !blank {
+				result = append(result, "")
+			}
+			blank = true
+			continue
+		}
+		result = append(result, line)
+		blank = false
+	}
+	return strings.TrimSpace(strings.Join(result, "\n"))
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package render
+package render
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+const (
+	localTimeLayout = "2006-01-02 15:04 (UTC-07:00)"
+	utcTimeLayout   = "2006-01-02 15:04Z"
+)
+
+// timeLayouts 兼容调用方传入的时间文本格式, 不带时区的时间按默认时区解析
+var timeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02T15:04:05", "2006-01-02T15:04"}
+
+// Time 模板中的时间, 渲染时按收件人时区显示并附带UTC时间
+type Time struct {
+	At time.Time
+	// 无法解析的时间文本, 渲染时原样显示
+	Raw string
+	// 收件人时区, 由发送器在渲染前设置到副本上, 为空时使用UTC
+	Location *time.Location
+}
+
+func NewTime(at time.Time) *Time {
+	return &Time{At: at}
+}
+
+// ParseTime 解析调用方传入的时间文本, 无法识别的格式保留原文
+func ParseTime(value string, location *time.Location) *Time {
+	value = strings.TrimSpace(value)
+	for _, layout := range timeLayouts {
+		if at, err := time.ParseInLocation(layout, value, location); err == nil {
+			return &Time{At: at}
+		}
+	}
+	return &Time{Raw: value}
+}
+
+// Valid 是否包含可用于计算的时间
+func (t *Time) Valid() bool {
+	return t != nil && !t.At.IsZero()
+}
+
+// Local 收件人时区的时间
+func (t *Time) Local() string {
+	if !t.Valid() {
+		return t.raw()
+	}
+	location := t.Location
+	if location == nil {
+		location = time.UTC
+	}
+	return t.At.In(location).Format(localTimeLayout)
+}
+
+// UTC 航空业务中使用的UTC时间
+func (t *Time) UTC() string {
+	if !t.Valid() {
+		return t.raw()
+	}
+	return t.At.UTC().Format(utcTimeLayout)
+}
+
+func (t *Time) String() string {
+	if !t.Valid() {
+		return t.raw()
+	}
+	return t.Local() + " / " + t.UTC()
+}
+
+// In 返回按指定时区显示的副本, 不修改原时间
+func (t *Time) In(location *time.Location) *Time {
+	if t == nil {
+		return nil
+	}
+	localized := *t
+	localized.Location = location
+	return &localized
+}
+
+// MarshalJSON 时间序列化为RFC 3339格式的UTC时间, 无法解析的时间序列化为原文
+func (t *Time) MarshalJSON() ([]byte, error) {
+	if !t.Valid() {
+		return json.Marshal(t.raw())
+	}
+	return json.Marshal(t.At.UTC().Format(time.RFC3339))
+}
+
+func (t *Time) raw() string {
+	if t == nil {
+		return ""
+	}
+	return t.Raw
+}
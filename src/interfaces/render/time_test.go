@@ -0,0 +1,49 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package render
+package render
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimeIn(t *testing.T) {
+	shanghai := time.FixedZone("CST", 8*3600)
+	original := NewTime(time.Date(2026, 1, 1, 16, 30, 0, 0, time.UTC))
+	localized := original.In(shanghai)
+	if original.Location != nil {
+		t.Errorf("original location = %v, want unchanged", original.Location)
+	}
+	if got := localized.Local(); got != "2026-01-02 00:30 (UTC+08:00)" {
+		t.Errorf("Local() = %q", got)
+	}
+	if (*Time)(nil).In(shanghai) != nil {
+		t.Error("nil time localized to non-nil")
+	}
+}
+
+func TestTimeMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		time *Time
+		want string
+	}{
+		{"utc time", &Time{At: time.Date(2026, 1, 2, 0, 30, 0, 0, time.FixedZone("CST", 8*3600)), Location: time.UTC}, `"2026-01-01T16:30:00Z"`},
+		{"raw text", &Time{Raw: "下周一"}, `"下周一"`},
+		{"nil time", nil, `null`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.time)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("json = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package controller
+package controller
+
+import "github.com/labstack/echo/v4"
+
+type AdminInterface interface {
+	ListEmailTypes(ctx echo.Context) error
+	ToggleEmailType(ctx echo.Context) error
+	ReloadTemplates(ctx echo.Context) error
+	ListAudits(ctx echo.Context) error
+	ResendEmail(ctx echo.Context) error
+	ListSuppressions(ctx echo.Context) error
+	AddSuppression(ctx echo.Context) error
+	RemoveSuppression(ctx echo.Context) error
+	QueueStatus(ctx echo.Context) error
+}
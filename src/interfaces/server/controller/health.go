@@ -0,0 +1,11 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package controller
+package controller
+
+import "github.com/labstack/echo/v4"
+
+type HealthInterface interface {
+	Ready(ctx echo.Context) error
+}
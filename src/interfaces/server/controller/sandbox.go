@@ -0,0 +1,14 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package controller
+package controller
+
+import "github.com/labstack/echo/v4"
+
+type SandboxInterface interface {
+	Inbox(ctx echo.Context) error
+	ListCapturedEmails(ctx echo.Context) error
+	GetCapturedEmail(ctx echo.Context) error
+	ClearCapturedEmails(ctx echo.Context) error
+}
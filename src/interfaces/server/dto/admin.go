@@ -0,0 +1,79 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package dto
+package dto
+
+import (
+	"email-service/src/interfaces/audit"
+	"email-service/src/interfaces/email"
+)
+
+type EmailTypeInfo struct {
+	Type    string `json:"type"`
+	Subject string `json:"subject"`
+	Enable  bool   `json:"enable"`
+	Loaded  bool   `json:"loaded"`
+}
+
+type ListEmailTypesResponse = []*EmailTypeInfo
+
+type ToggleEmailType struct {
+	Type   string `param:"type" valid:"required"`
+	Enable bool   `json:"enable"`
+	// 内部字段
+	Operator string `json:"-"`
+}
+
+type ToggleEmailTypeResponse = bool
+
+type ReloadTemplates struct {
+	// 内部字段
+	Operator string `json:"-"`
+}
+
+type ReloadTemplatesResponse = bool
+
+type ListAudits struct {
+	Offset int `query:"offset"`
+	Limit  int `query:"limit"`
+}
+
+type ListAuditsResponse struct {
+	Total   int             `json:"total"`
+	Records []*audit.Record `json:"records"`
+}
+
+type ResendEmail struct {
+	Id string `param:"id" valid:"required"`
+	// 内部字段
+	Operator string `json:"-"`
+}
+
+type ResendEmailResponse = bool
+
+type ListSuppressionsResponse = []*email.Suppression
+
+type AddSuppression struct {
+	Email  string `json:"email" valid:"required"`
+	Reason string `json:"reason"`
+	// 内部字段
+	Operator string `json:"-"`
+}
+
+type AddSuppressionResponse = *email.Suppression
+
+type RemoveSuppression struct {
+	Email string `param:"email" valid:"required"`
+	// 内部字段
+	Operator string `json:"-"`
+}
+
+type RemoveSuppressionResponse = bool
+
+// QueueStatusResponse 发送积压, Pending为异步队列中等待的邮件与正在发送的邮件之和
+type QueueStatusResponse struct {
+	Pending int `json:"pending"`
+	Queued  int `json:"queued"`
+	Sending int `json:"sending"`
+}
@@ -5,7 +5,12 @@
 package dto
 
 type SendEmailCode struct {
-	Email string `json:"email" valid:"required,regex=^[\\w-]+@[\\w-]+(\\.[\\w-]+)+$"`
+	Email        string `json:"email" valid:"required"`
+	CaptchaToken string `json:"captcha_token"`
+	// 内部字段
+	Ip             string `json:"-"`
+	Fingerprint    string `json:"-"`
+	IdempotencyKey string `json:"-"`
 }
 
 type SendEmailCodeResponse = bool
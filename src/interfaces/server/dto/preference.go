@@ -0,0 +1,35 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package dto
+package dto
+
+import "time"
+
+type PreferenceCategory struct {
+	Name   string   `json:"name"`
+	Emails []string `json:"emails"`
+	Enable bool     `json:"enable"`
+}
+
+type GetPreference struct {
+	Cid string `param:"cid" valid:"required"`
+}
+
+type PreferenceResponse struct {
+	Cid        string                `json:"cid"`
+	Categories []*PreferenceCategory `json:"categories"`
+	Timezone   string                `json:"timezone"`
+	UpdatedAt  *time.Time            `json:"updated_at,omitempty"`
+}
+
+type GetPreferenceResponse = *PreferenceResponse
+
+type UpdatePreference struct {
+	Cid        string          `param:"cid" valid:"required"`
+	Categories map[string]bool `json:"categories"`
+	// 为nil时不修改时区, 为空字符串时恢复默认时区
+	Timezone *string `json:"timezone"`
+}
+
+type UpdatePreferenceResponse = *PreferenceResponse
@@ -0,0 +1,22 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package dto
+package dto
+
+import "email-service/src/interfaces/email"
+
+type ListCapturedEmails struct {
+	To    string `query:"to"`
+	Limit int    `query:"limit"`
+}
+
+type ListCapturedEmailsResponse = []*email.CapturedEmail
+
+type GetCapturedEmail struct {
+	Id string `param:"id" valid:"required"`
+}
+
+type GetCapturedEmailResponse = *email.CapturedEmail
+
+type ClearCapturedEmailsResponse = bool
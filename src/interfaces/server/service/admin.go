@@ -0,0 +1,33 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package service
+package service
+
+import (
+	DTO "email-service/src/interfaces/server/dto"
+
+	"half-nothing.cn/service-core/interfaces/http/dto"
+)
+
+var (
+	ErrAdminUnauthorized   = dto.NewApiStatus("ADMIN_UNAUTHORIZED", "管理令牌无效", dto.HttpCodeUnauthorized)
+	ErrEmailTypeNotFound   = dto.NewApiStatus("EMAIL_TYPE_NOT_FOUND", "邮件类型不存在", dto.HttpCodeNotFound)
+	ErrTemplateLoad        = dto.NewApiStatus("TEMPLATE_LOAD_FAILED", "模板加载失败", dto.HttpCodeInternalError)
+	ErrAuditNotFound       = dto.NewApiStatus("AUDIT_NOT_FOUND", "审计记录不存在", dto.HttpCodeNotFound)
+	ErrResendEmail         = dto.NewApiStatus("EMAIL_RESEND_FAILED", "邮件重发失败", dto.HttpCodeInternalError)
+	ErrResendForbidden     = dto.NewApiStatus("EMAIL_RESEND_FORBIDDEN", "该类邮件包含一次性凭据, 不允许重发", dto.HttpCodeBadRequest)
+	ErrSuppressionNotFound = dto.NewApiStatus("SUPPRESSION_NOT_FOUND", "该邮箱不在禁止投递列表中", dto.HttpCodeNotFound)
+)
+
+type AdminInterface interface {
+	ListEmailTypes() *dto.ApiResponse[DTO.ListEmailTypesResponse]
+	ToggleEmailType(form *DTO.ToggleEmailType) *dto.ApiResponse[DTO.ToggleEmailTypeResponse]
+	ReloadTemplates(form *DTO.ReloadTemplates) *dto.ApiResponse[DTO.ReloadTemplatesResponse]
+	ListAudits(form *DTO.ListAudits) *dto.ApiResponse[*DTO.ListAuditsResponse]
+	ResendEmail(form *DTO.ResendEmail) *dto.ApiResponse[DTO.ResendEmailResponse]
+	ListSuppressions() *dto.ApiResponse[DTO.ListSuppressionsResponse]
+	AddSuppression(form *DTO.AddSuppression) *dto.ApiResponse[DTO.AddSuppressionResponse]
+	RemoveSuppression(form *DTO.RemoveSuppression) *dto.ApiResponse[DTO.RemoveSuppressionResponse]
+	QueueStatus() *dto.ApiResponse[*DTO.QueueStatusResponse]
+}
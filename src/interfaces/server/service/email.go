@@ -5,15 +5,35 @@
 package service
 
 import (
+	"context"
 	DTO "email-service/src/interfaces/server/dto"
+	"net/http"
+	"time"
 
 	"half-nothing.cn/service-core/interfaces/http/dto"
 )
 
 var (
-	ErrSendEmailCode = dto.NewApiStatus("EMAIL_SEND_FAILED", "邮件发送失败", dto.HttpCodeInternalError)
+	ErrSendEmailCode        = dto.NewApiStatus("EMAIL_SEND_FAILED", "邮件发送失败", dto.HttpCodeInternalError)
+	ErrCaptchaRequired      = dto.NewApiStatus("CAPTCHA_REQUIRED", "请完成人机验证", dto.HttpCodeBadRequest)
+	ErrCaptchaInvalid       = dto.NewApiStatus("CAPTCHA_INVALID", "人机验证失败", dto.HttpCodeBadRequest)
+	ErrCaptchaUnavailable   = dto.NewApiStatus("CAPTCHA_UNAVAILABLE", "人机验证服务不可用", dto.HttpCodeInternalError)
+	ErrAddressInvalid       = dto.NewApiStatus("EMAIL_ADDRESS_INVALID", "邮箱地址格式错误", dto.HttpCodeBadRequest)
+	ErrDomainDisposable     = dto.NewApiStatus("EMAIL_DOMAIN_DISPOSABLE", "不支持使用临时邮箱", dto.HttpCodeBadRequest)
+	ErrDomainNoMx           = dto.NewApiStatus("EMAIL_DOMAIN_UNREACHABLE", "该邮箱域名无法接收邮件", dto.HttpCodeBadRequest)
+	ErrIdempotencyKeyReused = dto.NewApiStatus("IDEMPOTENCY_KEY_REUSED", "幂等键已被用于其他请求", dto.HttpCodeBadRequest)
+)
+
+// HttpCodeTooManyRequests 超出配额或发送间隔时的状态码, 响应头Retry-After为可以重试的秒数
+const HttpCodeTooManyRequests = dto.HttpCode(http.StatusTooManyRequests)
+
+const (
+	CodeSendIpLimit          = "EMAIL_SEND_IP_LIMIT"
+	CodeSendFingerprintLimit = "EMAIL_SEND_FINGERPRINT_LIMIT"
+	CodeSendGlobalLimit      = "EMAIL_SEND_GLOBAL_LIMIT"
 )
 
 type EmailInterface interface {
-	SendEmailCode(form *DTO.SendEmailCode) *dto.ApiResponse[DTO.SendEmailCodeResponse]
+	// SendEmailCode 发送验证码, 超出配额或发送间隔时retryAfter为距离可以重试的时间, 其他情况为0
+	SendEmailCode(ctx context.Context, form *DTO.SendEmailCode) (response *dto.ApiResponse[DTO.SendEmailCodeResponse], retryAfter time.Duration)
 }
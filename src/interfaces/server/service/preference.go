@@ -0,0 +1,22 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package service
+package service
+
+import (
+	DTO "email-service/src/interfaces/server/dto"
+
+	"half-nothing.cn/service-core/interfaces/http/dto"
+)
+
+var (
+	ErrPreferenceUnauthorized    = dto.NewApiStatus("PREFERENCE_UNAUTHORIZED", "访问令牌无效", dto.HttpCodeUnauthorized)
+	ErrPreferenceCategoryUnknown = dto.NewApiStatus("PREFERENCE_CATEGORY_UNKNOWN", "通知分类不存在", dto.HttpCodeBadRequest)
+	ErrPreferenceTimezoneInvalid = dto.NewApiStatus("PREFERENCE_TIMEZONE_INVALID", "时区无效", dto.HttpCodeBadRequest)
+)
+
+type PreferenceInterface interface {
+	GetPreference(form *DTO.GetPreference) *dto.ApiResponse[DTO.GetPreferenceResponse]
+	UpdatePreference(form *DTO.UpdatePreference) *dto.ApiResponse[DTO.UpdatePreferenceResponse]
+}
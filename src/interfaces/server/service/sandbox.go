@@ -0,0 +1,19 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package service
+package service
+
+import (
+	DTO "email-service/src/interfaces/server/dto"
+
+	"half-nothing.cn/service-core/interfaces/http/dto"
+)
+
+var ErrCapturedEmailNotFound = dto.NewApiStatus("CAPTURED_EMAIL_NOT_FOUND", "邮件不存在", dto.HttpCodeNotFound)
+
+type SandboxInterface interface {
+	ListCapturedEmails(form *DTO.ListCapturedEmails) *dto.ApiResponse[DTO.ListCapturedEmailsResponse]
+	GetCapturedEmail(form *DTO.GetCapturedEmail) *dto.ApiResponse[DTO.GetCapturedEmailResponse]
+	ClearCapturedEmails() *dto.ApiResponse[DTO.ClearCapturedEmailsResponse]
+}
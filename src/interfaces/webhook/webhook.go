@@ -0,0 +1,46 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package webhook
+package webhook
+
+import "time"
+
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderId        = "X-Webhook-Id"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Payload 推送给接收方的事件
+// 签名为 X-Webhook-Signature: t=<unix秒>,v1=<hex(HMAC-SHA256(secret, "<t>.<body>"))>
+type Payload struct {
+	Id    string    `json:"id"`
+	Event string    `json:"event"`
+	Time  time.Time `json:"time"`
+	Data  any       `json:"data"`
+}
+
+// EmailEvent 邮件投递结果事件, 出于隐私考虑不包含密送地址与模板参数
+type EmailEvent struct {
+	EmailType string `json:"email_type"`
+	MessageId string `json:"message_id,omitempty"`
+	// 异步发送时的消息Id, 与查询消息状态使用的Id相同
+	AsyncId    string   `json:"async_id,omitempty"`
+	ThreadId   string   `json:"thread_id,omitempty"`
+	To         []string `json:"to"`
+	Cc         []string `json:"cc,omitempty"`
+	Suppressed []string `json:"suppressed,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// CodeEvent 验证码校验事件
+type CodeEvent struct {
+	Email string `json:"email"`
+}
+
+type DispatcherInterface interface {
+	// Dispatch 将事件放入推送队列, 不会阻塞调用方, 没有订阅该事件的地址时直接忽略
+	// data在调用时即被序列化, 调用方之后可以继续修改
+	Dispatch(event string, data any)
+}
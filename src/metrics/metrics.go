@@ -0,0 +1,193 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package metrics
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+const namespace = "email_service"
+
+// Metrics 同时向Prometheus与OpenTelemetry记录指标
+// 未启用遥测时OpenTelemetry使用全局的空实现, 不产生额外开销
+type Metrics struct {
+	logger   logger.Interface
+	registry *prometheus.Registry
+	meter    metric.Meter
+
+	emailSent      *prometheus.CounterVec
+	renderDuration *prometheus.HistogramVec
+	smtpLatency    *prometheus.HistogramVec
+	laneWait       *prometheus.HistogramVec
+	codeVerified   *prometheus.CounterVec
+	codeRejected   *prometheus.CounterVec
+
+	otelEmailSent      metric.Int64Counter
+	otelRenderDuration metric.Float64Histogram
+	otelSmtpLatency    metric.Float64Histogram
+	otelLaneWait       metric.Float64Histogram
+	otelCodeVerified   metric.Int64Counter
+	otelCodeRejected   metric.Int64Counter
+}
+
+func NewMetrics(lg logger.Interface) *Metrics {
+	m := &Metrics{
+		logger:   logger.NewLoggerAdapter(lg, "metrics"),
+		registry: prometheus.NewRegistry(),
+		meter:    otel.Meter("email-service"),
+		emailSent: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "emails_sent_total",
+			Help:      "Number of email send attempts by email type and outcome.",
+		}, []string{"type", "outcome"}),
+		renderDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "template_render_duration_seconds",
+			Help:      "Time spent rendering email templates.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1},
+		}, []string{"type"}),
+		smtpLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "smtp_send_duration_seconds",
+			Help:      "Time spent delivering messages to the SMTP server.",
+			Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		}, []string{"success"}),
+		laneWait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "lane_wait_duration_seconds",
+			Help:      "Time spent waiting for a free slot in the priority lane.",
+			Buckets:   []float64{.001, .01, .05, .1, .5, 1, 5, 10, 30, 60},
+		}, []string{"priority"}),
+		codeVerified: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "code_verifications_total",
+			Help:      "Number of verification code checks by result.",
+		}, []string{"result"}),
+		codeRejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "code_rejections_total",
+			Help:      "Number of verification code requests rejected by cooldown or quota.",
+		}, []string{"reason"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.emailSent,
+		m.renderDuration,
+		m.smtpLatency,
+		m.laneWait,
+		m.codeVerified,
+		m.codeRejected,
+	)
+	m.initOtel()
+	return m
+}
+
+func (m *Metrics) initOtel() {
+	var err error
+	if m.otelEmailSent, err = m.meter.Int64Counter("email_service.emails_sent",
+		metric.WithDescription("Number of email send attempts by email type and outcome.")); err != nil {
+		m.logger.Errorf("fail to create otel instrument, %v", err)
+	}
+	if m.otelRenderDuration, err = m.meter.Float64Histogram("email_service.template_render_duration",
+		metric.WithDescription("Time spent rendering email templates."), metric.WithUnit("s")); err != nil {
+		m.logger.Errorf("fail to create otel instrument, %v", err)
+	}
+	if m.otelSmtpLatency, err = m.meter.Float64Histogram("email_service.smtp_send_duration",
+		metric.WithDescription("Time spent delivering messages to the SMTP server."), metric.WithUnit("s")); err != nil {
+		m.logger.Errorf("fail to create otel instrument, %v", err)
+	}
+	if m.otelLaneWait, err = m.meter.Float64Histogram("email_service.lane_wait_duration",
+		metric.WithDescription("Time spent waiting for a free slot in the priority lane."), metric.WithUnit("s")); err != nil {
+		m.logger.Errorf("fail to create otel instrument, %v", err)
+	}
+	if m.otelCodeVerified, err = m.meter.Int64Counter("email_service.code_verifications",
+		metric.WithDescription("Number of verification code checks by result.")); err != nil {
+		m.logger.Errorf("fail to create otel instrument, %v", err)
+	}
+	if m.otelCodeRejected, err = m.meter.Int64Counter("email_service.code_rejections",
+		metric.WithDescription("Number of verification code requests rejected by cooldown or quota.")); err != nil {
+		m.logger.Errorf("fail to create otel instrument, %v", err)
+	}
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+func (m *Metrics) EmailSent(emailType string, outcome string) {
+	m.emailSent.WithLabelValues(emailType, outcome).Inc()
+	if m.otelEmailSent != nil {
+		m.otelEmailSent.Add(context.Background(), 1, metric.WithAttributes(
+			attribute.String("type", emailType), attribute.String("outcome", outcome)))
+	}
+}
+
+func (m *Metrics) RenderDuration(emailType string, duration time.Duration) {
+	m.renderDuration.WithLabelValues(emailType).Observe(duration.Seconds())
+	if m.otelRenderDuration != nil {
+		m.otelRenderDuration.Record(context.Background(), duration.Seconds(), metric.WithAttributes(
+			attribute.String("type", emailType)))
+	}
+}
+
+func (m *Metrics) SmtpLatency(duration time.Duration, success bool) {
+	m.smtpLatency.WithLabelValues(strconv.FormatBool(success)).Observe(duration.Seconds())
+	if m.otelSmtpLatency != nil {
+		m.otelSmtpLatency.Record(context.Background(), duration.Seconds(), metric.WithAttributes(
+			attribute.Bool("success", success)))
+	}
+}
+
+func (m *Metrics) LaneWait(priority string, duration time.Duration) {
+	m.laneWait.WithLabelValues(priority).Observe(duration.Seconds())
+	if m.otelLaneWait != nil {
+		m.otelLaneWait.Record(context.Background(), duration.Seconds(), metric.WithAttributes(
+			attribute.String("priority", priority)))
+	}
+}
+
+func (m *Metrics) CodeVerified(result string) {
+	m.codeVerified.WithLabelValues(result).Inc()
+	if m.otelCodeVerified != nil {
+		m.otelCodeVerified.Add(context.Background(), 1, metric.WithAttributes(attribute.String("result", result)))
+	}
+}
+
+func (m *Metrics) CodeRejected(reason string) {
+	m.codeRejected.WithLabelValues(reason).Inc()
+	if m.otelCodeRejected != nil {
+		m.otelCodeRejected.Add(context.Background(), 1, metric.WithAttributes(attribute.String("reason", reason)))
+	}
+}
+
+func (m *Metrics) RegisterSize(name string, size func() int) {
+	m.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   namespace,
+		Name:        "size",
+		Help:        "Current number of entries held by caches and queues.",
+		ConstLabels: prometheus.Labels{"name": name},
+	}, func() float64 { return float64(size()) }))
+	_, err := m.meter.Int64ObservableGauge("email_service.size",
+		metric.WithDescription("Current number of entries held by caches and queues."),
+		metric.WithInt64Callback(func(_ context.Context, observer metric.Int64Observer) error {
+			observer.Observe(int64(size()), metric.WithAttributes(attribute.String("name", name)))
+			return nil
+		}))
+	if err != nil {
+		m.logger.Errorf("fail to create otel instrument, %v", err)
+	}
+}
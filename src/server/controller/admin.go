@@ -0,0 +1,110 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package controller
+package controller
+
+import (
+	DTO "email-service/src/interfaces/server/dto"
+	"email-service/src/interfaces/server/service"
+	"email-service/src/server/middleware"
+
+	"github.com/labstack/echo/v4"
+	"half-nothing.cn/service-core/interfaces/http/dto"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+type AdminController struct {
+	logger  logger.Interface
+	service service.AdminInterface
+}
+
+func NewAdminController(
+	lg logger.Interface,
+	service service.AdminInterface,
+) *AdminController {
+	return &AdminController{
+		logger:  logger.NewLoggerAdapter(lg, "admin-controller"),
+		service: service,
+	}
+}
+
+// bind 解析并校验请求参数, 失败时返回需要响应的错误状态
+func (controller *AdminController) bind(ctx echo.Context, name string, data interface{}) *dto.ApiStatus {
+	if err := ctx.Bind(data); err != nil {
+		controller.logger.Errorf("%s handle fail, parse argument fail, %v", name, err)
+		return dto.ErrErrorParam
+	}
+	controller.logger.Debugf("%s with argument %#v", name, data)
+	res, err := dto.ValidStruct(data)
+	if err != nil {
+		controller.logger.Errorf("%s handle fail, validate err, %v", name, err)
+		return dto.ErrServerError
+	}
+	if res != nil {
+		controller.logger.Errorf("%s handle fail, validate argument fail, %v", name, res)
+		return res
+	}
+	return nil
+}
+
+func (controller *AdminController) ListEmailTypes(ctx echo.Context) error {
+	return controller.service.ListEmailTypes().Response(ctx)
+}
+
+func (controller *AdminController) ToggleEmailType(ctx echo.Context) error {
+	data := &DTO.ToggleEmailType{}
+	if res := controller.bind(ctx, "ToggleEmailType", data); res != nil {
+		return dto.ErrorResponse(ctx, res)
+	}
+	data.Operator = middleware.AdminName(ctx)
+	return controller.service.ToggleEmailType(data).Response(ctx)
+}
+
+func (controller *AdminController) ReloadTemplates(ctx echo.Context) error {
+	data := &DTO.ReloadTemplates{Operator: middleware.AdminName(ctx)}
+	return controller.service.ReloadTemplates(data).Response(ctx)
+}
+
+func (controller *AdminController) ListAudits(ctx echo.Context) error {
+	data := &DTO.ListAudits{}
+	if res := controller.bind(ctx, "ListAudits", data); res != nil {
+		return dto.ErrorResponse(ctx, res)
+	}
+	return controller.service.ListAudits(data).Response(ctx)
+}
+
+func (controller *AdminController) ResendEmail(ctx echo.Context) error {
+	data := &DTO.ResendEmail{}
+	if res := controller.bind(ctx, "ResendEmail", data); res != nil {
+		return dto.ErrorResponse(ctx, res)
+	}
+	data.Operator = middleware.AdminName(ctx)
+	return controller.service.ResendEmail(data).Response(ctx)
+}
+
+func (controller *AdminController) ListSuppressions(ctx echo.Context) error {
+	return controller.service.ListSuppressions().Response(ctx)
+}
+
+func (controller *AdminController) AddSuppression(ctx echo.Context) error {
+	data := &DTO.AddSuppression{}
+	if res := controller.bind(ctx, "AddSuppression", data); res != nil {
+		return dto.ErrorResponse(ctx, res)
+	}
+	data.Operator = middleware.AdminName(ctx)
+	return controller.service.AddSuppression(data).Response(ctx)
+}
+
+func (controller *AdminController) RemoveSuppression(ctx echo.Context) error {
+	data := &DTO.RemoveSuppression{}
+	if res := controller.bind(ctx, "RemoveSuppression", data); res != nil {
+		return dto.ErrorResponse(ctx, res)
+	}
+	data.Operator = middleware.AdminName(ctx)
+	return controller.service.RemoveSuppression(data).Response(ctx)
+}
+
+func (controller *AdminController) QueueStatus(ctx echo.Context) error {
+	return controller.service.QueueStatus().Response(ctx)
+}
@@ -5,8 +5,11 @@
 package controller
 
 import (
+	"email-service/src/interfaces/config"
 	DTO "email-service/src/interfaces/server/dto"
 	"email-service/src/interfaces/server/service"
+	"math"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 	"half-nothing.cn/service-core/interfaces/http/dto"
@@ -14,17 +17,23 @@ import (
 )
 
 type EmailController struct {
-	logger  logger.Interface
-	service service.EmailInterface
+	logger      logger.Interface
+	config      *config.CodeLimitConfig
+	idempotency *config.IdempotencyConfig
+	service     service.EmailInterface
 }
 
 func NewEmailController(
 	lg logger.Interface,
+	config *config.CodeLimitConfig,
+	idempotency *config.IdempotencyConfig,
 	service service.EmailInterface,
 ) *EmailController {
 	return &EmailController{
-		logger:  logger.NewLoggerAdapter(lg, "email-controller"),
-		service: service,
+		logger:      logger.NewLoggerAdapter(lg, "email-controller"),
+		config:      config,
+		idempotency: idempotency,
+		service:     service,
 	}
 }
 
@@ -44,5 +53,16 @@ func (controller *EmailController) SendEmailCode(ctx echo.Context) error {
 		controller.logger.Errorf("SendEmailCode handle fail, validate argument fail, %v", res)
 		return dto.ErrorResponse(ctx, res)
 	}
-	return controller.service.SendEmailCode(data).Response(ctx)
+	data.Ip = ctx.RealIP()
+	if controller.config.FingerprintHeader != "" {
+		data.Fingerprint = ctx.Request().Header.Get(controller.config.FingerprintHeader)
+	}
+	if controller.idempotency.Enable {
+		data.IdempotencyKey = ctx.Request().Header.Get(controller.idempotency.Header)
+	}
+	response, retryAfter := controller.service.SendEmailCode(ctx.Request().Context(), data)
+	if retryAfter > 0 {
+		ctx.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	}
+	return response.Response(ctx)
 }
@@ -0,0 +1,28 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package controller
+package controller
+
+import (
+	"email-service/src/interfaces/health"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type HealthController struct {
+	monitor health.MonitorInterface
+}
+
+func NewHealthController(monitor health.MonitorInterface) *HealthController {
+	return &HealthController{monitor: monitor}
+}
+
+func (controller *HealthController) Ready(ctx echo.Context) error {
+	report := controller.monitor.Report()
+	if report == nil || !report.Healthy {
+		return ctx.JSON(http.StatusServiceUnavailable, report)
+	}
+	return ctx.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package controller
+package controller
+
+import (
+	DTO "email-service/src/interfaces/server/dto"
+	"email-service/src/interfaces/server/service"
+
+	"github.com/labstack/echo/v4"
+	"half-nothing.cn/service-core/interfaces/http/dto"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+type PreferenceController struct {
+	logger  logger.Interface
+	service service.PreferenceInterface
+}
+
+func NewPreferenceController(
+	lg logger.Interface,
+	service service.PreferenceInterface,
+) *PreferenceController {
+	return &PreferenceController{
+		logger:  logger.NewLoggerAdapter(lg, "preference-controller"),
+		service: service,
+	}
+}
+
+func (controller *PreferenceController) GetPreference(ctx echo.Context) error {
+	data := &DTO.GetPreference{}
+	if err := ctx.Bind(data); err != nil {
+		controller.logger.Errorf("GetPreference handle fail, parse argument fail, %v", err)
+		return dto.ErrorResponse(ctx, dto.ErrErrorParam)
+	}
+	res, err := dto.ValidStruct(data)
+	if err != nil {
+		controller.logger.Errorf("GetPreference handle fail, validate err, %v", err)
+		return dto.ErrorResponse(ctx, dto.ErrServerError)
+	}
+	if res != nil {
+		controller.logger.Errorf("GetPreference handle fail, validate argument fail, %v", res)
+		return dto.ErrorResponse(ctx, res)
+	}
+	return controller.service.GetPreference(data).Response(ctx)
+}
+
+func (controller *PreferenceController) UpdatePreference(ctx echo.Context) error {
+	data := &DTO.UpdatePreference{}
+	if err := ctx.Bind(data); err != nil {
+		controller.logger.Errorf("UpdatePreference handle fail, parse argument fail, %v", err)
+		return dto.ErrorResponse(ctx, dto.ErrErrorParam)
+	}
+	controller.logger.Debugf("UpdatePreference with argument %#v", data)
+	res, err := dto.ValidStruct(data)
+	if err != nil {
+		controller.logger.Errorf("UpdatePreference handle fail, validate err, %v", err)
+		return dto.ErrorResponse(ctx, dto.ErrServerError)
+	}
+	if res != nil {
+		controller.logger.Errorf("UpdatePreference handle fail, validate argument fail, %v", res)
+		return dto.ErrorResponse(ctx, res)
+	}
+	return controller.service.UpdatePreference(data).Response(ctx)
+}
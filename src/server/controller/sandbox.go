@@ -0,0 +1,108 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package controller
+package controller
+
+import (
+	DTO "email-service/src/interfaces/server/dto"
+	"email-service/src/interfaces/server/service"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"half-nothing.cn/service-core/interfaces/http/dto"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// inboxPage 沙盒收件箱页面, 通过同路径下的 /emails 接口获取数据
+const inboxPage = `<!DOCTYPE html>
+<html lang="zh-CN">
+<head>
+<meta charset="UTF-8">
+<title>Sandbox Inbox</title>
+<style>
+body { margin: 0; display: flex; height: 100vh; font-family: sans-serif; }
+#list { width: 360px; overflow-y: auto; border-right: 1px solid #ddd; }
+#list div { padding: 8px 12px; border-bottom: 1px solid #eee; cursor: pointer; }
+#list div:hover { background: #f5f5f5; }
+#list small { color: #888; display: block; }
+#toolbar { padding: 8px 12px; border-bottom: 1px solid #ddd; }
+#view { flex: 1; border: none; }
+</style>
+</head>
+<body>
+<div id="list"><div id="toolbar"><button onclick="load()">刷新</button> <button onclick="clearAll()">清空</button></div></div>
+<iframe id="view" sandbox></iframe>
+<script>
+const base = location.pathname.replace(/\/$/, '') + '/emails';
+const list = document.getElementById('list');
+const toolbar = document.getElementById('toolbar');
+async function load() {
+  const emails = (await (await fetch(base)).json()).data || [];
+  list.replaceChildren(toolbar);
+  for (const email of emails) {
+    const item = document.createElement('div');
+    const subject = document.createElement('b');
+    subject.textContent = email.subject;
+    const meta = document.createElement('small');
+    meta.textContent = email.to.join(', ') + ' · ' + new Date(email.time).toLocaleString();
+    item.append(subject, meta);
+    item.onclick = () => { document.getElementById('view').srcdoc = email.html || ('<pre>' + email.text.replace(/</g, '&lt;') + '</pre>'); };
+    list.append(item);
+  }
+}
+async function clearAll() { await fetch(base, {method: 'DELETE'}); await load(); }
+load();
+</script>
+</body>
+</html>`
+
+type SandboxController struct {
+	logger  logger.Interface
+	service service.SandboxInterface
+}
+
+func NewSandboxController(
+	lg logger.Interface,
+	service service.SandboxInterface,
+) *SandboxController {
+	return &SandboxController{
+		logger:  logger.NewLoggerAdapter(lg, "sandbox-controller"),
+		service: service,
+	}
+}
+
+func (controller *SandboxController) Inbox(ctx echo.Context) error {
+	return ctx.HTML(http.StatusOK, inboxPage)
+}
+
+func (controller *SandboxController) ListCapturedEmails(ctx echo.Context) error {
+	data := &DTO.ListCapturedEmails{}
+	if err := ctx.Bind(data); err != nil {
+		controller.logger.Errorf("ListCapturedEmails handle fail, parse argument fail, %v", err)
+		return dto.ErrorResponse(ctx, dto.ErrErrorParam)
+	}
+	return controller.service.ListCapturedEmails(data).Response(ctx)
+}
+
+func (controller *SandboxController) GetCapturedEmail(ctx echo.Context) error {
+	data := &DTO.GetCapturedEmail{}
+	if err := ctx.Bind(data); err != nil {
+		controller.logger.Errorf("GetCapturedEmail handle fail, parse argument fail, %v", err)
+		return dto.ErrorResponse(ctx, dto.ErrErrorParam)
+	}
+	res, err := dto.ValidStruct(data)
+	if err != nil {
+		controller.logger.Errorf("GetCapturedEmail handle fail, validate err, %v", err)
+		return dto.ErrorResponse(ctx, dto.ErrServerError)
+	}
+	if res != nil {
+		controller.logger.Errorf("GetCapturedEmail handle fail, validate argument fail, %v", res)
+		return dto.ErrorResponse(ctx, res)
+	}
+	return controller.service.GetCapturedEmail(data).Response(ctx)
+}
+
+func (controller *SandboxController) ClearCapturedEmails(ctx echo.Context) error {
+	return controller.service.ClearCapturedEmails().Response(ctx)
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package middleware
+package middleware
+
+import (
+	"crypto/subtle"
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/server/service"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"half-nothing.cn/service-core/interfaces/http/dto"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+const AdminNameKey = "admin_name"
+
+// AdminAuth 校验请求头中的管理令牌, 并将令牌名称写入上下文
+func AdminAuth(lg logger.Interface, c *config.AdminConfig) echo.MiddlewareFunc {
+	lg = logger.NewLoggerAdapter(lg, "admin-auth")
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			token, found := strings.CutPrefix(ctx.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+			if !found || token == "" {
+				return dto.ErrorResponse(ctx, service.ErrAdminUnauthorized)
+			}
+			for _, adminToken := range c.Tokens {
+				if subtle.ConstantTimeCompare([]byte(adminToken.Token), []byte(token)) == 1 {
+					ctx.Set(AdminNameKey, adminToken.Name)
+					return next(ctx)
+				}
+			}
+			lg.Warnf("reject admin request from %s with invalid token", ctx.RealIP())
+			return dto.ErrorResponse(ctx, service.ErrAdminUnauthorized)
+		}
+	}
+}
+
+func AdminName(ctx echo.Context) string {
+	name, _ := ctx.Get(AdminNameKey).(string)
+	return name
+}
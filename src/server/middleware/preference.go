@@ -0,0 +1,31 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package middleware
+package middleware
+
+import (
+	"crypto/subtle"
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/server/service"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"half-nothing.cn/service-core/interfaces/http/dto"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// PreferenceAuth 校验用户站点的访问令牌, 站点负责确认当前用户与请求的CID一致
+func PreferenceAuth(lg logger.Interface, c *config.PreferenceConfig) echo.MiddlewareFunc {
+	lg = logger.NewLoggerAdapter(lg, "preference-auth")
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			token, found := strings.CutPrefix(ctx.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+			if !found || subtle.ConstantTimeCompare([]byte(c.Token), []byte(token)) != 1 {
+				lg.Warnf("reject preference request from %s with invalid token", ctx.RealIP())
+				return dto.ErrorResponse(ctx, service.ErrPreferenceUnauthorized)
+			}
+			return next(ctx)
+		}
+	}
+}
@@ -7,6 +7,7 @@ package server
 import (
 	"email-service/src/interfaces/content"
 	"email-service/src/server/controller"
+	"email-service/src/server/middleware"
 	"email-service/src/server/service"
 	"io"
 
@@ -33,19 +34,75 @@ func StartHttpServer(content *content.ApplicationContent) {
 
 	emailController := controller.NewEmailController(
 		lg,
+		c.EmailConfig.CodeLimit,
+		c.IdempotencyConfig,
 		service.NewEmailService(
 			lg,
 			content.EmailSender(),
 			content.CodeManager(),
+			content.CodeLimiter(),
+			content.AddressChecker(),
+			content.CaptchaVerifier(),
+			c.EmailConfig.Captcha,
+			content.IdempotencyStore(),
 		),
 	)
 
 	http.SetHealthPoint(e)
+	e.GET("/ready", controller.NewHealthController(content.HealthMonitor()).Ready)
+
+	if c.MetricsConfig.Enable {
+		e.GET(c.MetricsConfig.Path, echo.WrapHandler(content.Metrics().Handler()))
+	}
+
+	if c.EmailConfig.Sandbox.Enable {
+		sandboxController := controller.NewSandboxController(lg, service.NewSandboxService(lg, content.Sandbox()))
+		sandboxGroup := e.Group(c.EmailConfig.Sandbox.InboxPath)
+		sandboxGroup.GET("", sandboxController.Inbox)
+		sandboxGroup.GET("/emails", sandboxController.ListCapturedEmails)
+		sandboxGroup.GET("/emails/:id", sandboxController.GetCapturedEmail)
+		sandboxGroup.DELETE("/emails", sandboxController.ClearCapturedEmails)
+	}
 
 	apiGroup := e.Group("/api/v1")
 	emailGroup := apiGroup.Group("/emails")
 	emailGroup.POST("/code", emailController.SendEmailCode)
 
+	if c.PreferenceConfig.Enable {
+		preferenceController := controller.NewPreferenceController(
+			lg,
+			service.NewPreferenceService(lg, c.PreferenceConfig, content.Preferences()),
+		)
+		preferenceGroup := apiGroup.Group("/preferences", middleware.PreferenceAuth(lg, c.PreferenceConfig))
+		preferenceGroup.GET("/:cid", preferenceController.GetPreference)
+		preferenceGroup.PUT("/:cid", preferenceController.UpdatePreference)
+	}
+
+	if c.AdminConfig.Enable {
+		adminController := controller.NewAdminController(
+			lg,
+			service.NewAdminService(
+				lg,
+				c.EmailConfig.Template.Templates,
+				content.EmailSender(),
+				content.AsyncSender(),
+				content.AuditRecorder(),
+				content.Suppressions(),
+			),
+		)
+
+		adminGroup := apiGroup.Group("/admin", middleware.AdminAuth(lg, c.AdminConfig))
+		adminGroup.GET("/emails", adminController.ListEmailTypes)
+		adminGroup.PUT("/emails/:type", adminController.ToggleEmailType)
+		adminGroup.POST("/templates/reload", adminController.ReloadTemplates)
+		adminGroup.GET("/audits", adminController.ListAudits)
+		adminGroup.POST("/audits/:id/resend", adminController.ResendEmail)
+		adminGroup.GET("/suppressions", adminController.ListSuppressions)
+		adminGroup.POST("/suppressions", adminController.AddSuppression)
+		adminGroup.DELETE("/suppressions/:email", adminController.RemoveSuppression)
+		adminGroup.GET("/queue", adminController.QueueStatus)
+	}
+
 	http.SetUnmatchedRoute(e)
 	http.SetCleaner(content.Cleaner(), e)
 
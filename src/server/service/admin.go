@@ -0,0 +1,155 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package service
+package service
+
+import (
+	"email-service/src/interfaces/audit"
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/email"
+	DTO "email-service/src/interfaces/server/dto"
+	"email-service/src/interfaces/server/service"
+
+	"half-nothing.cn/service-core/interfaces/http/dto"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+const (
+	defaultAuditLimit = 50
+	maxAuditLimit     = 500
+)
+
+type AdminService struct {
+	logger       logger.Interface
+	templates    *config.TemplateConfig
+	sender       email.SenderInterface
+	asyncSender  email.AsyncSenderInterface
+	recorder     audit.RecorderInterface
+	suppressions email.SuppressionInterface
+}
+
+func NewAdminService(
+	lg logger.Interface,
+	templates *config.TemplateConfig,
+	sender email.SenderInterface,
+	asyncSender email.AsyncSenderInterface,
+	recorder audit.RecorderInterface,
+	suppressions email.SuppressionInterface,
+) *AdminService {
+	return &AdminService{
+		logger:       logger.NewLoggerAdapter(lg, "admin-service"),
+		templates:    templates,
+		sender:       sender,
+		asyncSender:  asyncSender,
+		recorder:     recorder,
+		suppressions: suppressions,
+	}
+}
+
+func (a *AdminService) ListEmailTypes() *dto.ApiResponse[DTO.ListEmailTypesResponse] {
+	result := make(DTO.ListEmailTypesResponse, 0, len(config.Emails))
+	for _, emailType := range config.Emails {
+		result = append(result, &DTO.EmailTypeInfo{
+			Type:    emailType.Value,
+			Subject: emailType.Data.Subject,
+			Enable:  emailType.Data.Enabled(),
+			Loaded:  emailType.Data.Template() != nil,
+		})
+	}
+	return dto.NewApiResponse[DTO.ListEmailTypesResponse](dto.SuccessHandleRequest, result)
+}
+
+func (a *AdminService) ToggleEmailType(form *DTO.ToggleEmailType) *dto.ApiResponse[DTO.ToggleEmailTypeResponse] {
+	emailType, ok := config.FindEmail(form.Type)
+	if !ok {
+		return dto.NewApiResponse[DTO.ToggleEmailTypeResponse](service.ErrEmailTypeNotFound, false)
+	}
+	// 配置中禁用的模板在启动时不会加载, 启用前需要先加载
+	if form.Enable && emailType.Data.Template() == nil {
+		if err := a.templates.Find(emailType).Load(); err != nil {
+			a.logger.Errorf("fail to load %s template, %v", emailType.Value, err)
+			return dto.NewApiResponse[DTO.ToggleEmailTypeResponse](service.ErrTemplateLoad, false)
+		}
+	}
+	emailType.Data.SetEnable(form.Enable)
+	a.logger.Infof("%s set %s email enable to %t", form.Operator, emailType.Value, form.Enable)
+	return dto.NewApiResponse[DTO.ToggleEmailTypeResponse](dto.SuccessHandleRequest, true)
+}
+
+func (a *AdminService) ReloadTemplates(form *DTO.ReloadTemplates) *dto.ApiResponse[DTO.ReloadTemplatesResponse] {
+	a.logger.Infof("%s reloading email templates", form.Operator)
+	if err := a.templates.Reload(); err != nil {
+		a.logger.Errorf("fail to reload templates, %v", err)
+		return dto.NewApiResponse[DTO.ReloadTemplatesResponse](service.ErrTemplateLoad, false)
+	}
+	return dto.NewApiResponse[DTO.ReloadTemplatesResponse](dto.SuccessHandleRequest, true)
+}
+
+func (a *AdminService) ListAudits(form *DTO.ListAudits) *dto.ApiResponse[*DTO.ListAuditsResponse] {
+	if form.Limit <= 0 {
+		form.Limit = defaultAuditLimit
+	}
+	records, total := a.recorder.List(form.Offset, min(form.Limit, maxAuditLimit))
+	return dto.NewApiResponse[*DTO.ListAuditsResponse](dto.SuccessHandleRequest, &DTO.ListAuditsResponse{Total: total, Records: records})
+}
+
+func (a *AdminService) ResendEmail(form *DTO.ResendEmail) *dto.ApiResponse[DTO.ResendEmailResponse] {
+	record, ok := a.recorder.Get(form.Id)
+	if !ok {
+		return dto.NewApiResponse[DTO.ResendEmailResponse](service.ErrAuditNotFound, false)
+	}
+	emailType, ok := config.FindEmail(record.EmailType)
+	if !ok {
+		return dto.NewApiResponse[DTO.ResendEmailResponse](service.ErrEmailTypeNotFound, false)
+	}
+	if email.Unresendable[emailType] {
+		return dto.NewApiResponse[DTO.ResendEmailResponse](service.ErrResendForbidden, false)
+	}
+	err := a.sender.SendEmail(emailType, &email.Recipients{To: record.To, Cc: record.Cc, Bcc: record.Bcc}, record.Data)
+	resend := &audit.Record{
+		Caller:    "admin:" + form.Operator,
+		Method:    "ResendEmail:" + record.Id,
+		EmailType: record.EmailType,
+		To:        record.To,
+		Cc:        record.Cc,
+		Bcc:       record.Bcc,
+		Data:      record.Data,
+		Success:   err == nil,
+	}
+	if err != nil {
+		resend.Error = err.Error()
+	}
+	a.recorder.Record(resend)
+	if err != nil {
+		a.logger.Errorf("fail to resend email %s, %v", record.Id, err)
+		return dto.NewApiResponse[DTO.ResendEmailResponse](service.ErrResendEmail, false)
+	}
+	return dto.NewApiResponse[DTO.ResendEmailResponse](dto.SuccessHandleRequest, true)
+}
+
+func (a *AdminService) ListSuppressions() *dto.ApiResponse[DTO.ListSuppressionsResponse] {
+	return dto.NewApiResponse[DTO.ListSuppressionsResponse](dto.SuccessHandleRequest, a.suppressions.List())
+}
+
+func (a *AdminService) AddSuppression(form *DTO.AddSuppression) *dto.ApiResponse[DTO.AddSuppressionResponse] {
+	a.logger.Infof("%s suppressed %s, reason: %s", form.Operator, form.Email, form.Reason)
+	return dto.NewApiResponse[DTO.AddSuppressionResponse](dto.SuccessHandleRequest, a.suppressions.Add(form.Email, form.Reason))
+}
+
+func (a *AdminService) RemoveSuppression(form *DTO.RemoveSuppression) *dto.ApiResponse[DTO.RemoveSuppressionResponse] {
+	if !a.suppressions.Remove(form.Email) {
+		return dto.NewApiResponse[DTO.RemoveSuppressionResponse](service.ErrSuppressionNotFound, false)
+	}
+	a.logger.Infof("%s removed suppression of %s", form.Operator, form.Email)
+	return dto.NewApiResponse[DTO.RemoveSuppressionResponse](dto.SuccessHandleRequest, true)
+}
+
+func (a *AdminService) QueueStatus() *dto.ApiResponse[*DTO.QueueStatusResponse] {
+	queued, sending := a.asyncSender.Pending(), a.sender.Pending()
+	return dto.NewApiResponse[*DTO.QueueStatusResponse](dto.SuccessHandleRequest, &DTO.QueueStatusResponse{
+		Pending: queued + sending,
+		Queued:  queued,
+		Sending: sending,
+	})
+}
@@ -5,12 +5,17 @@
 package service
 
 import (
+	"context"
+	"email-service/src/interfaces/captcha"
 	"email-service/src/interfaces/config"
 	"email-service/src/interfaces/email"
+	"email-service/src/interfaces/idempotency"
 	DTO "email-service/src/interfaces/server/dto"
 	"email-service/src/interfaces/server/service"
 	"errors"
 	"fmt"
+	"math"
+	"time"
 
 	"half-nothing.cn/service-core/interfaces/http/dto"
 	"half-nothing.cn/service-core/interfaces/logger"
@@ -20,39 +25,178 @@ type EmailService struct {
 	logger  logger.Interface
 	sender  email.SenderInterface
 	manager email.CodeManagerInterface
+	limiter email.CodeLimiterInterface
+	checker email.AddressCheckerInterface
+	captcha captcha.VerifierInterface
+	config  *config.CaptchaConfig
+	// 幂等键存储, 未启用时为nil
+	idempotency idempotency.StoreInterface
 }
 
 func NewEmailService(
 	lg logger.Interface,
 	sender email.SenderInterface,
 	manager email.CodeManagerInterface,
+	limiter email.CodeLimiterInterface,
+	checker email.AddressCheckerInterface,
+	captcha captcha.VerifierInterface,
+	config *config.CaptchaConfig,
+	idempotency idempotency.StoreInterface,
 ) *EmailService {
 	return &EmailService{
-		logger:  logger.NewLoggerAdapter(lg, "email-service"),
-		sender:  sender,
-		manager: manager,
+		logger:      logger.NewLoggerAdapter(lg, "email-service"),
+		sender:      sender,
+		manager:     manager,
+		limiter:     limiter,
+		checker:     checker,
+		captcha:     captcha,
+		config:      config,
+		idempotency: idempotency,
 	}
 }
 
-func (e *EmailService) SendEmailCode(form *DTO.SendEmailCode) *dto.ApiResponse[DTO.SendEmailCodeResponse] {
+func (e *EmailService) checkAddress(form *DTO.SendEmailCode) *dto.ApiStatus {
+	address, err := e.checker.Check(form.Email)
+	if err == nil {
+		form.Email = address
+		return nil
+	}
+	switch {
+	case errors.Is(err, email.ErrAddressInvalid):
+		return service.ErrAddressInvalid
+	case errors.Is(err, email.ErrDomainDisposable):
+		return service.ErrDomainDisposable
+	case errors.Is(err, email.ErrDomainNoMx):
+		return service.ErrDomainNoMx
+	default:
+		e.logger.Errorf("fail to check email address %s, %v", form.Email, err)
+		return dto.ErrServerError
+	}
+}
+
+func (e *EmailService) captchaRequired(ip string) bool {
+	if e.captcha == nil {
+		return false
+	}
+	if e.config.Mode == config.CaptchaModeSuspicious {
+		return e.limiter.IpUsage(ip) >= e.config.SuspiciousThreshold
+	}
+	return true
+}
+
+func (e *EmailService) verifyCaptcha(form *DTO.SendEmailCode) *dto.ApiStatus {
+	err := e.captcha.Verify(form.CaptchaToken, form.Ip)
+	if err == nil {
+		return nil
+	}
+	switch {
+	case errors.Is(err, captcha.ErrCaptchaRequired):
+		return service.ErrCaptchaRequired
+	case errors.Is(err, captcha.ErrCaptchaInvalid):
+		return service.ErrCaptchaInvalid
+	default:
+		e.logger.Errorf("fail to verify captcha, %v", err)
+		return service.ErrCaptchaUnavailable
+	}
+}
+
+func (e *EmailService) limitResponse(err error, duration time.Duration) *dto.ApiResponse[DTO.SendEmailCodeResponse] {
+	minutes := math.Ceil(duration.Minutes())
+	var status *dto.ApiStatus
+	switch {
+	case errors.Is(err, email.ErrCodeIpLimited):
+		status = dto.NewApiStatus(
+			service.CodeSendIpLimit,
+			fmt.Sprintf("当前IP请求验证码次数过多, 请在%.0f分钟后重试", minutes),
+			service.HttpCodeTooManyRequests,
+		)
+	case errors.Is(err, email.ErrCodeFingerprintLimited):
+		status = dto.NewApiStatus(
+			service.CodeSendFingerprintLimit,
+			fmt.Sprintf("当前设备请求验证码次数过多, 请在%.0f分钟后重试", minutes),
+			service.HttpCodeTooManyRequests,
+		)
+	case errors.Is(err, email.ErrCodeGlobalLimited):
+		status = dto.NewApiStatus(
+			service.CodeSendGlobalLimit,
+			fmt.Sprintf("验证码发送繁忙, 请在%.0f分钟后重试", minutes),
+			service.HttpCodeTooManyRequests,
+		)
+	default:
+		status = dto.ErrServerError
+	}
+	return dto.NewApiResponse[DTO.SendEmailCodeResponse](status, false)
+}
+
+// emailCodeResult 验证码发送结果, 重复请求直接返回首次结果时也需要携带重试时间
+type emailCodeResult struct {
+	response   *dto.ApiResponse[DTO.SendEmailCodeResponse]
+	retryAfter time.Duration
+}
+
+func (e *EmailService) SendEmailCode(ctx context.Context, form *DTO.SendEmailCode) (*dto.ApiResponse[DTO.SendEmailCodeResponse], time.Duration) {
+	if e.idempotency == nil || form.IdempotencyKey == "" {
+		result, _ := e.sendEmailCode(form)
+		return result.response, result.retryAfter
+	}
+	// HTTP接口没有调用方身份, 幂等键按客户端IP与设备指纹隔离, 避免其他客户端猜中幂等键后读取首次结果
+	key := "http:SendEmailCode:" + form.Ip + ":" + form.Fingerprint + ":" + form.IdempotencyKey
+	result, replayed, err := e.idempotency.Do(ctx, key, form.Email, func() (any, bool) {
+		return e.sendEmailCode(form)
+	})
+	if errors.Is(err, idempotency.ErrKeyReused) {
+		return dto.NewApiResponse[DTO.SendEmailCodeResponse](service.ErrIdempotencyKeyReused, false), 0
+	}
+	if err != nil {
+		return dto.NewApiResponse[DTO.SendEmailCodeResponse](dto.ErrServerError, false), 0
+	}
+	if replayed {
+		e.logger.Infof("replay SendEmailCode for idempotency key %s", form.IdempotencyKey)
+	}
+	sent := result.(*emailCodeResult)
+	return sent.response, sent.retryAfter
+}
+
+func failedEmailCode(status *dto.ApiStatus) (*emailCodeResult, bool) {
+	return &emailCodeResult{response: dto.NewApiResponse[DTO.SendEmailCodeResponse](status, false)}, false
+}
+
+// sendEmailCode 发送验证码, 仅在邮件发送成功时返回true
+func (e *EmailService) sendEmailCode(form *DTO.SendEmailCode) (*emailCodeResult, bool) {
+	// 是否需要人机验证按占用配额之前的请求次数判断
+	captchaRequired := e.captchaRequired(form.Ip)
+
+	// 先检查配额, 超出配额的请求不再调用人机验证服务
+	if duration, err := e.limiter.Acquire(form.Ip, form.Fingerprint); err != nil {
+		return &emailCodeResult{response: e.limitResponse(err, duration), retryAfter: duration}, false
+	}
+
+	if captchaRequired {
+		if status := e.verifyCaptcha(form); status != nil {
+			return failedEmailCode(status)
+		}
+	}
+
+	// MX查询放在限流之后, 避免被用来发起不受限制的DNS查询
+	if status := e.checkAddress(form); status != nil {
+		return failedEmailCode(status)
+	}
+
 	emailData, duration, err := e.manager.GenerateEmailCode(form.Email)
 	if err != nil {
 		if errors.Is(err, email.ErrEmailCodeCooldown) {
-			return dto.NewApiResponse[DTO.SendEmailCodeResponse](
-				dto.NewApiStatus(
-					"EMAIL_SEND_INTERVAL",
-					fmt.Sprintf("邮件已发送, 请在%.0f秒后重试", duration.Seconds()),
-					dto.HttpCodeBadRequest,
-				),
-				false,
-			)
+			return failedEmailCode(dto.NewApiStatus(
+				"EMAIL_SEND_INTERVAL",
+				fmt.Sprintf("邮件已发送, 请在%.0f秒后重试", duration.Seconds()),
+				dto.HttpCodeBadRequest,
+			))
 		}
-		return dto.NewApiResponse[DTO.SendEmailCodeResponse](dto.ErrServerError, false)
+		return failedEmailCode(dto.ErrServerError)
 	}
 
-	err = e.sender.SendEmail(config.EmailVerifyCode, form.Email, emailData)
+	err = e.sender.SendEmail(config.EmailVerifyCode, email.NewRecipients(form.Email), emailData)
 	if err != nil {
-		return dto.NewApiResponse[DTO.SendEmailCodeResponse](service.ErrSendEmailCode, false)
+		return failedEmailCode(service.ErrSendEmailCode)
 	}
-	return dto.NewApiResponse[DTO.SendEmailCodeResponse](dto.SuccessHandleRequest, true)
+	return &emailCodeResult{response: dto.NewApiResponse[DTO.SendEmailCodeResponse](dto.SuccessHandleRequest, true)}, true
 }
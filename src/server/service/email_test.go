@@ -0,0 +1,168 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package service
+package service
+
+import (
+	"context"
+	captchaImpl "email-service/src/captcha"
+	"email-service/src/interfaces/captcha"
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/email"
+	DTO "email-service/src/interfaces/server/dto"
+	"strings"
+	"testing"
+	"time"
+
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+type nopLogger struct{ logger.Interface }
+
+func (nopLogger) Debug(string)          {}
+func (nopLogger) Info(string)           {}
+func (nopLogger) Warn(string)           {}
+func (nopLogger) Error(string)          {}
+func (nopLogger) Fatal(string)          {}
+func (nopLogger) Debugf(string, ...any) {}
+func (nopLogger) Infof(string, ...any)  {}
+func (nopLogger) Warnf(string, ...any)  {}
+func (nopLogger) Errorf(string, ...any) {}
+func (nopLogger) Fatalf(string, ...any) {}
+
+// countingVerifier 记录人机验证的调用次数
+type countingVerifier struct {
+	captcha.VerifierInterface
+	calls int
+}
+
+func (v *countingVerifier) Verify(token string, ip string) error {
+	v.calls++
+	return v.VerifierInterface.Verify(token, ip)
+}
+
+// fakeLimiter err不为空时拒绝所有请求, 否则按IP累计次数
+type fakeLimiter struct {
+	err   error
+	usage map[string]int
+}
+
+func (l *fakeLimiter) Acquire(ip string, _ string) (time.Duration, error) {
+	if l.err != nil {
+		return time.Minute, l.err
+	}
+	l.usage[ip]++
+	return 0, nil
+}
+
+func (l *fakeLimiter) IpUsage(ip string) int { return l.usage[ip] }
+
+type fakeChecker struct{ calls int }
+
+func (c *fakeChecker) Check(address string) (string, error) {
+	c.calls++
+	return strings.ToLower(strings.TrimSpace(address)), nil
+}
+
+type fakeManager struct{ email.CodeManagerInterface }
+
+func (fakeManager) GenerateEmailCode(target string) (*email.VerifyCodeEmail, time.Duration, error) {
+	return &email.VerifyCodeEmail{Code: "123456"}, 0, nil
+}
+
+type fakeSender struct{ to []string }
+
+func (s *fakeSender) SendEmail(_ config.Email, recipients *email.Recipients, _ interface{}) error {
+	s.to = append(s.to, recipients.To...)
+	return nil
+}
+
+func (s *fakeSender) Pending() int { return 0 }
+
+type emailServiceFixture struct {
+	service  *EmailService
+	limiter  *fakeLimiter
+	verifier *countingVerifier
+	checker  *fakeChecker
+	sender   *fakeSender
+}
+
+func newEmailServiceFixture(captchaConfig *config.CaptchaConfig) *emailServiceFixture {
+	f := &emailServiceFixture{
+		limiter:  &fakeLimiter{usage: make(map[string]int)},
+		verifier: &countingVerifier{VerifierInterface: captchaImpl.NewFakeVerifier("pass")},
+		checker:  &fakeChecker{},
+		sender:   &fakeSender{},
+	}
+	f.service = NewEmailService(nopLogger{}, f.sender, fakeManager{}, f.limiter, f.checker, f.verifier, captchaConfig, nil)
+	return f
+}
+
+func sendForm(token string) *DTO.SendEmailCode {
+	return &DTO.SendEmailCode{Email: " Pilot@Example.com", CaptchaToken: token, Ip: "10.0.0.1", Fingerprint: "device"}
+}
+
+func TestSendEmailCodeChecksLimiterBeforeCaptcha(t *testing.T) {
+	f := newEmailServiceFixture(&config.CaptchaConfig{Mode: config.CaptchaModeAlways})
+	f.limiter.err = email.ErrCodeIpLimited
+
+	f.service.SendEmailCode(context.Background(), sendForm("pass"))
+	if f.verifier.calls != 0 {
+		t.Errorf("captcha verified %d times for a rate limited request, want 0", f.verifier.calls)
+	}
+	if f.checker.calls != 0 || len(f.sender.to) != 0 {
+		t.Errorf("address checked %d times and sent to %v for a rate limited request", f.checker.calls, f.sender.to)
+	}
+}
+
+func TestSendEmailCodeRequiresCaptcha(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		sent  bool
+	}{
+		{"missing token", "", false},
+		{"wrong token", "fail", false},
+		{"preset token", "pass", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newEmailServiceFixture(&config.CaptchaConfig{Mode: config.CaptchaModeAlways})
+			f.service.SendEmailCode(context.Background(), sendForm(tt.token))
+			if f.verifier.calls != 1 {
+				t.Errorf("captcha verified %d times, want 1", f.verifier.calls)
+			}
+			if sent := len(f.sender.to) == 1 && f.sender.to[0] == "pilot@example.com"; sent != tt.sent {
+				t.Errorf("sent to %v, want sent = %t", f.sender.to, tt.sent)
+			}
+		})
+	}
+}
+
+func TestSendEmailCodeSuspiciousMode(t *testing.T) {
+	f := newEmailServiceFixture(&config.CaptchaConfig{Mode: config.CaptchaModeSuspicious, SuspiciousThreshold: 2})
+
+	// 前两次请求不需要人机验证, 第三次起需要
+	for i := 0; i < 2; i++ {
+		f.service.SendEmailCode(context.Background(), sendForm(""))
+	}
+	if f.verifier.calls != 0 || len(f.sender.to) != 2 {
+		t.Fatalf("captcha verified %d times and sent %d emails, want 0 and 2", f.verifier.calls, len(f.sender.to))
+	}
+	f.service.SendEmailCode(context.Background(), sendForm(""))
+	if f.verifier.calls != 1 || len(f.sender.to) != 2 {
+		t.Errorf("captcha verified %d times and sent %d emails, want 1 and 2", f.verifier.calls, len(f.sender.to))
+	}
+}
+
+func TestSendEmailCodeReturnsRetryAfter(t *testing.T) {
+	f := newEmailServiceFixture(&config.CaptchaConfig{Mode: config.CaptchaModeAlways})
+	if _, retryAfter := f.service.SendEmailCode(context.Background(), sendForm("pass")); retryAfter != 0 {
+		t.Errorf("retry after = %s for a sent email, want 0", retryAfter)
+	}
+	f.limiter.err = email.ErrCodeGlobalLimited
+	if _, retryAfter := f.service.SendEmailCode(context.Background(), sendForm("pass")); retryAfter != time.Minute {
+		t.Errorf("retry after = %s for a rate limited request, want %s", retryAfter, time.Minute)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package service
+package service
+
+import (
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/email"
+	DTO "email-service/src/interfaces/server/dto"
+	"email-service/src/interfaces/server/service"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"half-nothing.cn/service-core/interfaces/http/dto"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+type PreferenceService struct {
+	logger      logger.Interface
+	config      *config.PreferenceConfig
+	preferences email.PreferenceInterface
+}
+
+func NewPreferenceService(
+	lg logger.Interface,
+	config *config.PreferenceConfig,
+	preferences email.PreferenceInterface,
+) *PreferenceService {
+	return &PreferenceService{
+		logger:      logger.NewLoggerAdapter(lg, "preference-service"),
+		config:      config,
+		preferences: preferences,
+	}
+}
+
+func (p *PreferenceService) response(preference *email.Preference) *DTO.PreferenceResponse {
+	result := &DTO.PreferenceResponse{
+		Cid:        preference.Cid,
+		Categories: make([]*DTO.PreferenceCategory, 0, len(p.config.Categories)),
+		Timezone:   preference.Timezone,
+	}
+	if !preference.UpdatedAt.IsZero() {
+		result.UpdatedAt = &preference.UpdatedAt
+	}
+	for name, emails := range p.config.Categories {
+		result.Categories = append(result.Categories, &DTO.PreferenceCategory{
+			Name:   name,
+			Emails: emails,
+			Enable: !slices.Contains(preference.OptOut, name),
+		})
+	}
+	slices.SortFunc(result.Categories, func(a, b *DTO.PreferenceCategory) int { return strings.Compare(a.Name, b.Name) })
+	return result
+}
+
+func (p *PreferenceService) GetPreference(form *DTO.GetPreference) *dto.ApiResponse[DTO.GetPreferenceResponse] {
+	return dto.NewApiResponse[DTO.GetPreferenceResponse](dto.SuccessHandleRequest, p.response(p.preferences.Get(form.Cid)))
+}
+
+func (p *PreferenceService) UpdatePreference(form *DTO.UpdatePreference) *dto.ApiResponse[DTO.UpdatePreferenceResponse] {
+	// 先校验时区, 避免分类已更新而时区更新失败
+	if form.Timezone != nil && *form.Timezone != "" {
+		if _, err := time.LoadLocation(*form.Timezone); err != nil {
+			return dto.NewApiResponse[DTO.UpdatePreferenceResponse](service.ErrPreferenceTimezoneInvalid, nil)
+		}
+	}
+	preference, err := p.preferences.Set(form.Cid, form.Categories)
+	if errors.Is(err, email.ErrPreferenceCategoryUnknown) {
+		return dto.NewApiResponse[DTO.UpdatePreferenceResponse](service.ErrPreferenceCategoryUnknown, nil)
+	}
+	if err == nil && form.Timezone != nil {
+		preference, err = p.preferences.SetTimezone(form.Cid, *form.Timezone)
+	}
+	if err != nil {
+		p.logger.Errorf("fail to update preference of %s, %v", form.Cid, err)
+		return dto.NewApiResponse[DTO.UpdatePreferenceResponse](dto.ErrServerError, nil)
+	}
+	p.logger.Infof("%s updated notification preference, opt out: %s", form.Cid, strings.Join(preference.OptOut, ","))
+	return dto.NewApiResponse[DTO.UpdatePreferenceResponse](dto.SuccessHandleRequest, p.response(preference))
+}
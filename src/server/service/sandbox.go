@@ -0,0 +1,47 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package service
+package service
+
+import (
+	"email-service/src/interfaces/email"
+	DTO "email-service/src/interfaces/server/dto"
+	"email-service/src/interfaces/server/service"
+
+	"half-nothing.cn/service-core/interfaces/http/dto"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+type SandboxService struct {
+	logger  logger.Interface
+	sandbox email.SandboxInterface
+}
+
+func NewSandboxService(
+	lg logger.Interface,
+	sandbox email.SandboxInterface,
+) *SandboxService {
+	return &SandboxService{
+		logger:  logger.NewLoggerAdapter(lg, "sandbox-service"),
+		sandbox: sandbox,
+	}
+}
+
+func (s *SandboxService) ListCapturedEmails(form *DTO.ListCapturedEmails) *dto.ApiResponse[DTO.ListCapturedEmailsResponse] {
+	return dto.NewApiResponse[DTO.ListCapturedEmailsResponse](dto.SuccessHandleRequest, s.sandbox.List(form.To, max(form.Limit, 0)))
+}
+
+func (s *SandboxService) GetCapturedEmail(form *DTO.GetCapturedEmail) *dto.ApiResponse[DTO.GetCapturedEmailResponse] {
+	captured, ok := s.sandbox.Get(form.Id)
+	if !ok {
+		return dto.NewApiResponse[DTO.GetCapturedEmailResponse](service.ErrCapturedEmailNotFound, nil)
+	}
+	return dto.NewApiResponse[DTO.GetCapturedEmailResponse](dto.SuccessHandleRequest, captured)
+}
+
+func (s *SandboxService) ClearCapturedEmails() *dto.ApiResponse[DTO.ClearCapturedEmailsResponse] {
+	s.logger.Info("clearing sandbox inbox")
+	s.sandbox.Clear()
+	return dto.NewApiResponse[DTO.ClearCapturedEmailsResponse](dto.SuccessHandleRequest, true)
+}
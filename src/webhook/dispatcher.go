@@ -0,0 +1,201 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package webhook
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"email-service/src/interfaces/config"
+	"email-service/src/interfaces/metrics"
+	"email-service/src/interfaces/webhook"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/thanhpk/randstr"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+type delivery struct {
+	endpoint *config.WebhookEndpointConfig
+	payload  *webhook.Payload
+	body     []byte
+}
+
+// Dispatcher 异步推送事件到订阅的地址, 推送失败时按指数退避重试
+type Dispatcher struct {
+	logger  logger.Interface
+	config  *config.WebhookConfig
+	client  *http.Client
+	queue   chan *delivery
+	pending atomic.Int64
+	workers sync.WaitGroup
+	// 关闭超时后中断正在等待的重试
+	stop chan struct{}
+	// 保护队列关闭
+	lock   sync.Mutex
+	closed bool
+}
+
+func NewDispatcher(
+	lg logger.Interface,
+	c *config.WebhookConfig,
+	metrics metrics.RecorderInterface,
+) *Dispatcher {
+	dispatcher := &Dispatcher{
+		logger: logger.NewLoggerAdapter(lg, "webhook"),
+		config: c,
+		client: &http.Client{Timeout: c.TimeoutDuration},
+		queue:  make(chan *delivery, c.QueueSize),
+		stop:   make(chan struct{}),
+	}
+	metrics.RegisterSize("webhook_queue", dispatcher.Pending)
+	return dispatcher
+}
+
+// Start 启动推送协程
+func (d *Dispatcher) Start() {
+	for i := 0; i < d.config.Workers; i++ {
+		d.workers.Add(1)
+		go d.work()
+	}
+}
+
+// Close 停止接收新事件, 等待队列中的事件推送完毕或ctx结束
+func (d *Dispatcher) Close(ctx context.Context) error {
+	d.lock.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.queue)
+	}
+	d.lock.Unlock()
+	finished := make(chan struct{})
+	go func() {
+		d.workers.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+		return nil
+	case <-ctx.Done():
+		close(d.stop)
+		d.logger.Warnf("webhook dispatcher closed with %d events left in queue", d.Pending())
+		return ctx.Err()
+	}
+}
+
+func (d *Dispatcher) Pending() int {
+	return int(d.pending.Load())
+}
+
+func (d *Dispatcher) Dispatch(event string, data any) {
+	if !d.config.Enable {
+		return
+	}
+	payload := &webhook.Payload{
+		Id:    randstr.Hex(16),
+		Event: event,
+		Time:  time.Now(),
+		Data:  data,
+	}
+	var body []byte
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.closed {
+		return
+	}
+	for _, endpoint := range d.config.Endpoints {
+		if !endpoint.Subscribed(event) {
+			continue
+		}
+		if body == nil {
+			var err error
+			if body, err = json.Marshal(payload); err != nil {
+				d.logger.Errorf("fail to marshal %s event, %v", event, err)
+				return
+			}
+		}
+		select {
+		case d.queue <- &delivery{endpoint: endpoint, payload: payload, body: body}:
+			d.pending.Add(1)
+		default:
+			d.logger.Warnf("webhook queue is full, drop %s event %s to %s", event, payload.Id, endpoint.Name)
+		}
+	}
+}
+
+func (d *Dispatcher) work() {
+	defer d.workers.Done()
+	for item := range d.queue {
+		d.pending.Add(-1)
+		d.deliver(item)
+	}
+}
+
+func (d *Dispatcher) deliver(item *delivery) {
+	interval := d.config.RetryIntervalDuration
+	for attempt := 0; ; attempt++ {
+		err := d.post(item)
+		if err == nil {
+			d.logger.Debugf("%s event %s delivered to %s", item.payload.Event, item.payload.Id, item.endpoint.Name)
+			return
+		}
+		if attempt >= d.config.MaxRetries {
+			d.logger.Errorf("fail to deliver %s event %s to %s after %d attempts, %v",
+				item.payload.Event, item.payload.Id, item.endpoint.Name, attempt+1, err)
+			return
+		}
+		d.logger.Warnf("fail to deliver %s event %s to %s, retry in %s, %v",
+			item.payload.Event, item.payload.Id, item.endpoint.Name, interval, err)
+		select {
+		case <-time.After(interval):
+		case <-d.stop:
+			return
+		}
+		interval *= 2
+	}
+}
+
+// sign 计算请求签名, 时间戳一同参与签名以便接收方拒绝重放的请求
+func (d *Dispatcher) sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (d *Dispatcher) post(item *delivery) error {
+	req, err := http.NewRequest(http.MethodPost, item.endpoint.Url, bytes.NewReader(item.body))
+	if err != nil {
+		return err
+	}
+	for key, value := range item.endpoint.Headers {
+		req.Header.Set(key, value)
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhook.HeaderEvent, item.payload.Event)
+	req.Header.Set(webhook.HeaderId, item.payload.Id)
+	req.Header.Set(webhook.HeaderSignature, "t="+timestamp+",v1="+d.sign(item.endpoint.Secret, timestamp, item.body))
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}